@@ -0,0 +1,19 @@
+package bargraph
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "bargraph",
+		Settings: wtf.ConfigSchema{
+			"graphIcon":  wtf.StringSetting,
+			"graphStars": wtf.IntSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+	})
+}
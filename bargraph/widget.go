@@ -5,6 +5,7 @@ This is a demo bargraph that just populates some random date/val data
 */
 
 import (
+	"context"
 	"github.com/rivo/tview"
 	"math/rand"
 	"time"
@@ -21,9 +22,9 @@ type Widget struct {
 }
 
 // NewWidget Make new instance of widget
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		BarGraph: wtf.NewBarGraph(app, "Sample Bar Graph", "bargraph", false),
+		BarGraph: wtf.NewBarGraph(app, "Sample Bar Graph", configKey, false),
 	}
 
 	widget.View.SetWrap(true)
@@ -58,16 +59,17 @@ func MakeGraph(widget *Widget) {
 }
 
 // Refresh & update after interval time
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
 	widget.View.Clear()
 
 	display(widget)
 
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -121,11 +121,9 @@ func CreateFile(fileName string) (string, error) {
 	return filePath, nil
 }
 
-// LoadConfigFile loads the config.yml file to configure the app
+// LoadConfigFile loads the config.yml file, and every file it includes, to configure the app
 func LoadConfigFile(filePath string) *config.Config {
-	absPath, _ := wtf.ExpandHomeDir(filePath)
-
-	cfg, err := config.ParseYamlFile(absPath)
+	cfg, _, err := loadConfig(filePath)
 	if err != nil {
 		fmt.Println("\n\n\033[1m ERROR:\033[0m Could not load '\033[0;33mconfig.yml\033[0m'.\n Please add a \033[0;33mconfig.yml\033[0m file to your \033[0;33m~/.config/wtf\033[0m directory.\n See \033[1;34mhttps://github.com/wtfutil/wtf\033[0m for details.")
 		fmt.Printf(" %s\n", err.Error())
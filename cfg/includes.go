@@ -0,0 +1,215 @@
+package cfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// ModsDir is the directory next to the config file whose *.yml and *.yaml files each define
+// one or more modules, keyed by name, that are merged into "wtf.mods"
+const ModsDir = "mods.d"
+
+// configFile is one of the files the config is built from, and the dotted path its contents
+// are merged under
+type configFile struct {
+	path   string
+	prefix string
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ConfigPaths returns every path that makes up the config: the config file itself, each file
+// it includes, and the mods.d directory if there is one
+func ConfigPaths(filePath string) ([]string, error) {
+	_, files, err := loadConfig(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, file := range files {
+		paths = append(paths, file.path)
+	}
+
+	modsDir := filepath.Join(filepath.Dir(files[0].path), ModsDir)
+	if _, err := os.Stat(modsDir); err == nil {
+		paths = append(paths, modsDir)
+	}
+
+	return paths, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loadConfig parses the config file and merges in, in order, the files listed in its
+// "include" setting and then the files in its mods.d directory. Later files override
+// earlier ones. Included files can't include other files
+func loadConfig(filePath string) (*config.Config, []configFile, error) {
+	absPath, _ := wtf.ExpandHomeDir(filePath)
+
+	root, err := parseConfigFile(absPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	includes, err := includedFiles(absPath, root["include"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := []configFile{{path: absPath}}
+
+	for _, include := range includes {
+		included, err := parseConfigFile(include.path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", include.path, err)
+		}
+
+		mergeConfig(mapAt(root, include.prefix), included)
+		files = append(files, include)
+	}
+
+	return &config.Config{Root: root}, files, nil
+}
+
+// includedFiles returns the files a config file includes. The include setting is either a
+// single path or a list of them, relative to the config file's directory, and each path may
+// be a glob
+func includedFiles(configPath string, include interface{}) ([]configFile, error) {
+	patterns := []string{}
+
+	switch include := include.(type) {
+	case nil:
+	case string:
+		patterns = append(patterns, include)
+	case []interface{}:
+		for _, pattern := range include {
+			str, ok := pattern.(string)
+			if !ok {
+				return nil, errors.New("include must be a file path or a list of file paths")
+			}
+			patterns = append(patterns, str)
+		}
+	default:
+		return nil, errors.New("include must be a file path or a list of file paths")
+	}
+
+	configDir := filepath.Dir(configPath)
+	files := []configFile{}
+
+	for _, pattern := range patterns {
+		paths, err := expandInclude(configDir, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			files = append(files, configFile{path: path})
+		}
+	}
+
+	mods := []string{}
+	for _, ext := range []string{"*.yml", "*.yaml"} {
+		paths, _ := filepath.Glob(filepath.Join(configDir, ModsDir, ext))
+		mods = append(mods, paths...)
+	}
+	sort.Strings(mods)
+
+	for _, path := range mods {
+		files = append(files, configFile{path: path, prefix: "wtf.mods"})
+	}
+
+	return files, nil
+}
+
+// expandInclude returns the files matched by one include pattern. A pattern without any glob
+// characters must name a file that exists
+func expandInclude(configDir, pattern string) ([]string, error) {
+	path, err := wtf.ExpandHomeDir(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(configDir, path)
+	}
+
+	if !strings.ContainsAny(path, "*?[") {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("could not include %s: %v", pattern, err)
+		}
+
+		return []string{path}, nil
+	}
+
+	paths, err := filepath.Glob(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not include %s: %v", pattern, err)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// mapAt returns the map at the given dotted path beneath root, creating any missing maps
+// along the way
+func mapAt(root map[string]interface{}, path string) map[string]interface{} {
+	if path == "" {
+		return root
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		child, ok := root[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			root[key] = child
+		}
+
+		root = child
+	}
+
+	return root
+}
+
+// mergeConfig deep-merges src into dest. Maps present in both are merged key by key, and any
+// other value in src replaces the one in dest
+func mergeConfig(dest, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		destMap, destIsMap := dest[key].(map[string]interface{})
+
+		if srcIsMap && destIsMap {
+			mergeConfig(destMap, srcMap)
+			continue
+		}
+
+		dest[key] = value
+	}
+}
+
+// parseConfigFile parses a YAML file into a map. An empty file parses to an empty map
+func parseConfigFile(path string) (map[string]interface{}, error) {
+	parsed, err := config.ParseYamlFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Root == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	root, ok := parsed.Root.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected a map at the top level")
+	}
+
+	return root, nil
+}
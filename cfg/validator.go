@@ -0,0 +1,408 @@
+package cfg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// ValidationError describes one problem found in a config file
+type ValidationError struct {
+	File    string
+	Line    int
+	Path    string
+	Message string
+}
+
+func (err ValidationError) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %s", err.File, err.Line, err.Path, err.Message)
+}
+
+// location is where in the config files a setting is defined
+type location struct {
+	file string
+	line int
+}
+
+// keyLine matches a "key:" line in a block-style YAML file, capturing its indentation and key
+var keyLine = regexp.MustCompile(`^(\s*)("[^"]*"|'[^']*'|[^\s#:"'-][^:#]*?)\s*:(\s|$)`)
+
+/* -------------------- Exported Functions -------------------- */
+
+// ValidateConfigFile checks every module in the config file, and the files it includes, against
+// the settings its module declares, and checks that the enabled modules fit on the grid without
+// overlapping. It returns an error only if the files can't be read or parsed at all
+func ValidateConfigFile(filePath string) ([]ValidationError, error) {
+	conf, files, err := loadConfig(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := validator{
+		config:    conf,
+		locations: map[string]location{},
+	}
+
+	// Settings in later files override those in earlier ones, so their locations do too
+	order := map[string]int{}
+	for idx, file := range files {
+		order[file.path] = idx
+
+		data, err := ioutil.ReadFile(file.path)
+		if err != nil {
+			return nil, err
+		}
+
+		for path, line := range lineNumbers(string(data)) {
+			if file.prefix != "" {
+				path = file.prefix + "." + path
+			}
+
+			validator.locations[path] = location{file: file.path, line: line}
+		}
+	}
+
+	validator.validate()
+
+	sort.SliceStable(validator.errors, func(i, j int) bool {
+		a, b := validator.errors[i], validator.errors[j]
+
+		if a.File != b.File {
+			return order[a.File] < order[b.File]
+		}
+
+		return a.Line < b.Line
+	})
+
+	return validator.errors, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+type validator struct {
+	config    *config.Config
+	errors    []ValidationError
+	locations map[string]location
+}
+
+// cell is one grid cell claimed by an enabled module
+type cell struct {
+	row, col int
+}
+
+func (validator *validator) validate() {
+	mods, _ := validator.config.Map("wtf.mods")
+
+	keys := []string{}
+	for key := range mods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		validator.validateMod(key, mods[key])
+	}
+
+	for _, problem := range wtf.KeyProblems(validator.config, "", wtf.AppKeys) {
+		validator.report("wtf.keys", problem)
+	}
+
+	boards, _ := validator.config.Map("wtf.boards")
+	if len(boards) == 0 {
+		validator.validatePositions(keys, "wtf.grid")
+		return
+	}
+
+	names := []string{}
+	for name := range boards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		validator.validateBoard(name, mods)
+	}
+}
+
+// validateBoard checks that every module on the board exists, and that they fit on its grid
+// without overlapping
+func (validator *validator) validateBoard(name string, mods map[string]interface{}) {
+	path := "wtf.boards." + name
+
+	gridKey := path + ".grid"
+	if _, err := validator.config.Map(gridKey); err != nil {
+		gridKey = "wtf.grid"
+	}
+
+	keys := []string{}
+	for _, key := range validator.config.UList(path + ".mods") {
+		str, ok := key.(string)
+		if !ok {
+			validator.report(path+".mods", fmt.Sprintf("expected a module name, got %s", describe(key)))
+			continue
+		}
+
+		if _, ok := mods[str]; !ok {
+			validator.report(path+".mods", fmt.Sprintf("no module named '%s'", str))
+			continue
+		}
+
+		keys = append(keys, str)
+	}
+
+	validator.validatePositions(keys, gridKey)
+}
+
+func (validator *validator) validateMod(key string, mod interface{}) {
+	path := "wtf.mods." + key
+
+	settings, ok := mod.(map[string]interface{})
+	if !ok {
+		validator.report(path, "expected a map of settings")
+		return
+	}
+
+	moduleName := key
+	if name, ok := settings["type"].(string); ok {
+		moduleName = name
+	}
+
+	module, ok := wtf.ModuleFor(moduleName)
+	if !ok {
+		validator.report(path, fmt.Sprintf("unknown module type '%s'", moduleName))
+		return
+	}
+
+	validator.validateSettings(path, "", settings, module.Schema())
+
+	for _, problem := range wtf.KeyProblems(validator.config, key, module.Keys) {
+		validator.report(path+".keys", problem)
+	}
+}
+
+// validateSettings walks the settings beneath a module, checking each one against the schema
+func (validator *validator) validateSettings(modPath, prefix string, settings map[string]interface{}, schema wtf.ConfigSchema) {
+	for name, value := range settings {
+		setting := prefix + name
+		path := modPath + "." + setting
+
+		if settingType, ok := schema[setting]; ok {
+			if !isA(settingType, value) {
+				validator.report(path, fmt.Sprintf("expected %s, got %s", settingType, describe(value)))
+			}
+			continue
+		}
+
+		if !hasSettingsBeneath(schema, setting) {
+			validator.report(path, "unknown setting")
+			continue
+		}
+
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			validator.report(path, fmt.Sprintf("expected map, got %s", describe(value)))
+			continue
+		}
+
+		validator.validateSettings(modPath, setting+".", nested, schema)
+	}
+}
+
+// validatePositions checks that each enabled module sits inside the grid at gridKey and that
+// no two enabled modules claim the same cell
+func (validator *validator) validatePositions(keys []string, gridKey string) {
+	rows := len(validator.config.UList(gridKey + ".rows"))
+	cols := len(validator.config.UList(gridKey + ".columns"))
+
+	claimed := map[cell]string{}
+
+mods:
+	for _, key := range keys {
+		if !validator.config.UBool(wtf.ConfigKeyFor(key, "enabled"), false) {
+			continue
+		}
+
+		path := wtf.ConfigKeyFor(key, "position")
+		if _, err := validator.config.Map(path); err != nil {
+			validator.report("wtf.mods."+key, "enabled but has no position")
+			continue
+		}
+
+		top := validator.config.UInt(path+".top", -1)
+		left := validator.config.UInt(path+".left", -1)
+		width := validator.config.UInt(path+".width", 0)
+		height := validator.config.UInt(path+".height", 0)
+
+		pos := wtf.NewPosition(top, left, width, height)
+		if !pos.IsValid() {
+			validator.report(path, "top and left must be 0 or more, and width and height must be 1 or more")
+			continue
+		}
+
+		if top+height > rows || left+width > cols {
+			validator.report(
+				path,
+				fmt.Sprintf("does not fit on the %d column by %d row grid", cols, rows),
+			)
+			continue
+		}
+
+		for row := top; row < top+height; row++ {
+			for col := left; col < left+width; col++ {
+				if other, ok := claimed[cell{row, col}]; ok {
+					validator.report(
+						path,
+						fmt.Sprintf("overlaps '%s' at row %d, column %d", other, row, col),
+					)
+					continue mods
+				}
+
+				claimed[cell{row, col}] = key
+			}
+		}
+	}
+}
+
+// report records a problem at the given dotted path. If that path can't be found in the files,
+// the location of its closest ancestor is used instead
+func (validator *validator) report(path, message string) {
+	loc := location{}
+	for search := path; search != ""; search = parentPath(search) {
+		if found, ok := validator.locations[search]; ok {
+			loc = found
+			break
+		}
+	}
+
+	validator.errors = append(
+		validator.errors,
+		ValidationError{File: loc.file, Line: loc.line, Path: path, Message: message},
+	)
+}
+
+// describe returns the YAML-ish name of the type of a parsed config value
+func describe(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		return "float"
+	case int:
+		return "int"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	case nil:
+		return "nothing"
+	default:
+		return fmt.Sprintf("'%v'", value)
+	}
+}
+
+// hasSettingsBeneath returns true if the schema has any settings nested under the given one
+func hasSettingsBeneath(schema wtf.ConfigSchema, setting string) bool {
+	for name := range schema {
+		if strings.HasPrefix(name, setting+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isA returns true if the value can be read as the given type of setting. It accepts the same
+// values the config package converts when the setting is read
+func isA(settingType wtf.SettingType, value interface{}) bool {
+	switch settingType {
+	case wtf.BoolSetting:
+		switch value := value.(type) {
+		case bool:
+			return true
+		case string:
+			_, err := strconv.ParseBool(value)
+			return err == nil
+		}
+		return false
+	case wtf.IntSetting:
+		switch value := value.(type) {
+		case int:
+			return true
+		case float64:
+			return value == float64(int(value))
+		case string:
+			_, err := strconv.Atoi(value)
+			return err == nil
+		}
+		return false
+	case wtf.ListSetting:
+		_, ok := value.([]interface{})
+		return ok
+	case wtf.MapSetting:
+		_, ok := value.(map[string]interface{})
+		return ok
+	case wtf.StringSetting:
+		switch value.(type) {
+		case bool, float64, int, string:
+			return true
+		}
+		return false
+	}
+
+	return true
+}
+
+// lineNumbers maps the dotted path of every key in a block-style YAML document to the line it's
+// defined on. Keys inside lists and flow-style maps are not tracked
+func lineNumbers(data string) map[string]int {
+	type parent struct {
+		indent int
+		key    string
+	}
+
+	lines := map[string]int{}
+	parents := []parent{}
+
+	for i, line := range strings.Split(data, "\n") {
+		match := keyLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		indent := len(match[1])
+		key := strings.Trim(match[2], `"'`)
+
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+
+		parents = append(parents, parent{indent: indent, key: key})
+
+		keys := []string{}
+		for _, p := range parents {
+			keys = append(keys, p.key)
+		}
+
+		path := strings.Join(keys, ".")
+		if _, ok := lines[path]; !ok {
+			lines[path] = i + 1
+		}
+	}
+
+	return lines
+}
+
+func parentPath(path string) string {
+	idx := strings.LastIndex(path, ".")
+	if idx < 0 {
+		return ""
+	}
+
+	return path[:idx]
+}
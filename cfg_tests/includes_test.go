@@ -0,0 +1,111 @@
+package cfg_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+const includingConfig = `include:
+  - secrets.yml
+  - people/*.yml
+wtf:
+  refreshInterval: 1
+  mods:
+    jira:
+      apiKey: "shared"
+      enabled: true
+      refreshInterval: 60
+`
+
+const secretsConfig = `wtf:
+  mods:
+    jira:
+      apiKey: "secret"
+`
+
+const personConfig = `wtf:
+  refreshInterval: 5
+`
+
+const modConfig = `cmdrunner:
+  cmd: "uptime"
+  enabled: true
+`
+
+const clockConfig = `clocks:
+  enabled: true
+`
+
+// writeConfigFiles writes the named files into a new temporary directory and returns its path
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "wtf_config")
+	Nil(t, err)
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+
+		Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		Nil(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	return dir
+}
+
+/* -------------------- LoadConfigFile() -------------------- */
+
+func TestLoadConfigFileWithIncludes(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml":        includingConfig,
+		"secrets.yml":       secretsConfig,
+		"people/me.yml":     personConfig,
+		"mods.d/uptime.yml": modConfig,
+		"mods.d/clock.yaml": clockConfig,
+	})
+	defer os.RemoveAll(dir)
+
+	config := LoadConfigFile(filepath.Join(dir, "config.yml"))
+
+	Equal(t, "secret", config.UString("wtf.mods.jira.apiKey"))
+	Equal(t, 60, config.UInt("wtf.mods.jira.refreshInterval"))
+	Equal(t, 5, config.UInt("wtf.refreshInterval"))
+	Equal(t, "uptime", config.UString("wtf.mods.cmdrunner.cmd"))
+	Equal(t, true, config.UBool("wtf.mods.clocks.enabled"))
+}
+
+/* -------------------- ConfigPaths() -------------------- */
+
+func TestConfigPaths(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml":        includingConfig,
+		"secrets.yml":       secretsConfig,
+		"people/me.yml":     personConfig,
+		"mods.d/uptime.yml": modConfig,
+	})
+	defer os.RemoveAll(dir)
+
+	paths, err := ConfigPaths(filepath.Join(dir, "config.yml"))
+	Nil(t, err)
+
+	Equal(t, []string{
+		filepath.Join(dir, "config.yml"),
+		filepath.Join(dir, "secrets.yml"),
+		filepath.Join(dir, "people/me.yml"),
+		filepath.Join(dir, "mods.d/uptime.yml"),
+		filepath.Join(dir, "mods.d"),
+	}, paths)
+}
+
+func TestConfigPathsMissingInclude(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": includingConfig,
+	})
+	defer os.RemoveAll(dir)
+
+	_, err := ConfigPaths(filepath.Join(dir, "config.yml"))
+	NotNil(t, err)
+}
@@ -0,0 +1,145 @@
+package cfg_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const testConfig = `wtf:
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    first:
+      type: validatortest
+      apiKey: "abc"
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 2
+      refreshInteval: 30
+    second:
+      type: validatortest
+      enabled: true
+      position:
+        top: 0
+        left: 1
+        height: 1
+        width: 1
+      refreshInterval: soon
+    third:
+      type: validatortest
+      enabled: true
+      position:
+        top: 1
+        left: 1
+        height: 1
+        width: 2
+    fourth:
+      type: nosuchmodule
+`
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name:     "validatortest",
+		Settings: wtf.ConfigSchema{"apiKey": wtf.StringSetting},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return nil
+		},
+	})
+}
+
+/* -------------------- ValidateConfigFile() -------------------- */
+
+func TestValidateConfigFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "wtf_config")
+	Nil(t, err)
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(testConfig)
+	Nil(t, err)
+	file.Close()
+
+	errs, err := ValidateConfigFile(file.Name())
+	Nil(t, err)
+
+	Equal(t, []ValidationError{
+		{File: file.Name(), Line: 15, Path: "wtf.mods.first.refreshInteval", Message: "unknown setting"},
+		{File: file.Name(), Line: 19, Path: "wtf.mods.second.position", Message: "overlaps 'first' at row 0, column 1"},
+		{File: file.Name(), Line: 24, Path: "wtf.mods.second.refreshInterval", Message: "expected int, got 'soon'"},
+		{File: file.Name(), Line: 28, Path: "wtf.mods.third.position", Message: "does not fit on the 2 column by 2 row grid"},
+		{File: file.Name(), Line: 33, Path: "wtf.mods.fourth", Message: "unknown module type 'nosuchmodule'"},
+	}, errs)
+}
+
+func TestValidateConfigFileMissing(t *testing.T) {
+	_, err := ValidateConfigFile("/this/file/does/not/exist.yml")
+	NotNil(t, err)
+}
+
+func TestValidateConfigFileWithIncludes(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml":     "wtf:\n  grid:\n    columns: [40]\n    rows: [10]\n",
+		"mods.d/api.yml": "api:\n  type: validatortest\n  apiKee: \"abc\"\n",
+	})
+	defer os.RemoveAll(dir)
+
+	errs, err := ValidateConfigFile(filepath.Join(dir, "config.yml"))
+	Nil(t, err)
+
+	Equal(t, []ValidationError{
+		{File: filepath.Join(dir, "mods.d/api.yml"), Line: 3, Path: "wtf.mods.api.apiKee", Message: "unknown setting"},
+	}, errs)
+}
+
+func TestValidateConfigFileWithBoards(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"config.yml": `wtf:
+  grid:
+    columns: [40]
+    rows: [10]
+  boards:
+    dev:
+      mods: [first, missing]
+    oncall:
+      grid:
+        columns: [40, 40]
+        rows: [10]
+      mods: [first, second]
+  mods:
+    first:
+      type: validatortest
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 1
+    second:
+      type: validatortest
+      enabled: true
+      position:
+        top: 0
+        left: 1
+        height: 1
+        width: 1
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	errs, err := ValidateConfigFile(filepath.Join(dir, "config.yml"))
+	Nil(t, err)
+
+	Equal(t, []ValidationError{
+		{File: filepath.Join(dir, "config.yml"), Line: 7, Path: "wtf.boards.dev.mods", Message: "no module named 'missing'"},
+	}, errs)
+}
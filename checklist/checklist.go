@@ -1,13 +1,32 @@
 package checklist
 
+import (
+	"sort"
+)
+
 // Checklist is a module for creating generic checklist implementations
 // See 'Todo' for an implementation example
 type Checklist struct {
+	// Selected is the index of the selected item among the listed rows, or -1 if none is
 	Selected int
 
 	Items []*ChecklistItem
+
+	filters []Filter
 }
 
+// ChecklistRow is an item as it's listed, along with how deeply it's nested under other items
+type ChecklistRow struct {
+	Depth int
+	Item  *ChecklistItem
+}
+
+// Filter decides whether an item is listed
+type Filter func(item *ChecklistItem) bool
+
+// Order returns true if item a belongs before item b
+type Order func(a, b *ChecklistItem) bool
+
 func NewChecklist() Checklist {
 	list := Checklist{
 		Selected: -1,
@@ -18,7 +37,7 @@ func NewChecklist() Checklist {
 
 /* -------------------- Exported Functions -------------------- */
 
-// Add creates a new item in the checklist
+// Add creates a new item at the top of the checklist
 func (list *Checklist) Add(checked bool, text string) {
 	item := ChecklistItem{
 		Checked: checked,
@@ -28,11 +47,11 @@ func (list *Checklist) Add(checked bool, text string) {
 	list.Items = append([]*ChecklistItem{&item}, list.Items...)
 }
 
-// CheckedItems returns a slice of all the checked items
+// CheckedItems returns a slice of all the checked items, nested ones included
 func (list *Checklist) CheckedItems() []*ChecklistItem {
 	items := []*ChecklistItem{}
 
-	for _, item := range list.Items {
+	for _, item := range list.allItems() {
 		if item.Checked {
 			items = append(items, item)
 		}
@@ -41,30 +60,67 @@ func (list *Checklist) CheckedItems() []*ChecklistItem {
 	return items
 }
 
-// Delete removes the selected item from the checklist
+// Delete removes the selected item, and the items nested under it, from the checklist
 func (list *Checklist) Delete() {
-	list.Items = append(list.Items[:list.Selected], list.Items[list.Selected+1:]...)
+	item := list.SelectedItem()
+	if item == nil {
+		return
+	}
+
+	parent, idx := list.locate(item)
+	siblings := list.childrenOf(parent)
+	*siblings = append((*siblings)[:idx], (*siblings)[idx+1:]...)
+
 	list.Prev()
 }
 
-// Demote moves the selected item down in the checklist
+// Demote moves the selected item down among the items at the same level
 func (list *Checklist) Demote() {
-	if list.IsUnselectable() {
+	list.move(1)
+}
+
+// Indent nests the selected item under the item before it at the same level, as its last item
+func (list *Checklist) Indent() {
+	item := list.SelectedItem()
+	if item == nil {
 		return
 	}
 
-	j := list.Selected + 1
-	if j >= len(list.Items) {
-		j = 0
+	parent, idx := list.locate(item)
+	if idx < 1 {
+		return
+	}
+
+	siblings := list.childrenOf(parent)
+	newParent := (*siblings)[idx-1]
+
+	*siblings = append((*siblings)[:idx], (*siblings)[idx+1:]...)
+	newParent.Items = append(newParent.Items, item)
+
+	list.SetSelectedByItem(item)
+}
+
+// Insert adds the item after the selected item, at the same level, or at the top of the
+// checklist if no item is selected. The new item is selected
+func (list *Checklist) Insert(item *ChecklistItem) {
+	selected := list.SelectedItem()
+	if selected == nil {
+		list.Items = append([]*ChecklistItem{item}, list.Items...)
+		list.SetSelectedByItem(item)
+		return
 	}
 
-	list.Swap(list.Selected, j)
-	list.Selected = j
+	parent, idx := list.locate(selected)
+	siblings := list.childrenOf(parent)
+
+	*siblings = append((*siblings)[:idx+1], append([]*ChecklistItem{item}, (*siblings)[idx+1:]...)...)
+
+	list.SetSelectedByItem(item)
 }
 
 // IsSelectable returns true if the checklist has selectable items, false if it does not
 func (list *Checklist) IsSelectable() bool {
-	return list.Selected >= 0 && list.Selected < len(list.Items)
+	return list.Selected >= 0 && list.Selected < len(list.Rows())
 }
 
 // IsUnselectable returns true if the checklist has no selectable items, false if it does
@@ -75,7 +131,7 @@ func (list *Checklist) IsUnselectable() bool {
 // Next selects the next item in the checklist
 func (list *Checklist) Next() {
 	list.Selected = list.Selected + 1
-	if list.Selected >= len(list.Items) {
+	if list.Selected >= len(list.Rows()) {
 		list.Selected = 0
 	}
 }
@@ -84,56 +140,99 @@ func (list *Checklist) Next() {
 func (list *Checklist) LongestLine() int {
 	maxLen := 0
 
-	for _, item := range list.Items {
-		if len(item.Text) > maxLen {
-			maxLen = len(item.Text)
+	for _, row := range list.Rows() {
+		if len(row.Item.Text) > maxLen {
+			maxLen = len(row.Item.Text)
 		}
 	}
 
 	return maxLen
 }
 
+// Outdent moves the selected item out from under the item it's nested under, to just after it
+func (list *Checklist) Outdent() {
+	item := list.SelectedItem()
+	if item == nil {
+		return
+	}
+
+	parent, idx := list.locate(item)
+	if parent == nil {
+		return
+	}
+
+	parent.Items = append(parent.Items[:idx], parent.Items[idx+1:]...)
+
+	grandparent, parentIdx := list.locate(parent)
+	siblings := list.childrenOf(grandparent)
+
+	*siblings = append((*siblings)[:parentIdx+1], append([]*ChecklistItem{item}, (*siblings)[parentIdx+1:]...)...)
+
+	list.SetSelectedByItem(item)
+}
+
 // Prev selects the previous item in the checklist
 func (list *Checklist) Prev() {
 	list.Selected = list.Selected - 1
 	if list.Selected < 0 {
-		list.Selected = len(list.Items) - 1
+		list.Selected = len(list.Rows()) - 1
 	}
 }
 
-// Promote moves the selected item up in the checklist
+// Promote moves the selected item up among the items at the same level
 func (list *Checklist) Promote() {
-	if list.IsUnselectable() {
-		return
-	}
+	list.move(-1)
+}
 
-	j := list.Selected - 1
-	if j < 0 {
-		j = len(list.Items) - 1
-	}
+// Rows returns the items that are listed, in order, with the items nested under each one after
+// it. An item that doesn't pass the filters is still listed if an item nested under it does
+func (list *Checklist) Rows() []ChecklistRow {
+	rows := []ChecklistRow{}
+	list.appendRows(&rows, list.Items, 0)
 
-	list.Swap(list.Selected, j)
-	list.Selected = j
+	return rows
 }
 
 // SelectedItem returns the currently-selected checklist item or nil if no item is selected
 func (list *Checklist) SelectedItem() *ChecklistItem {
-	if list.IsUnselectable() {
+	rows := list.Rows()
+	if list.Selected < 0 || list.Selected >= len(rows) {
 		return nil
 	}
 
-	return list.Items[list.Selected]
+	return rows[list.Selected].Item
+}
+
+// SetFilters sets the filters every listed item has to pass. With none, every item is listed
+func (list *Checklist) SetFilters(filters ...Filter) {
+	selected := list.SelectedItem()
+
+	list.filters = filters
+
+	list.Unselect()
+	list.SetSelectedByItem(selected)
 }
 
 func (list *Checklist) SetSelectedByItem(selectableItem *ChecklistItem) {
-	for idx, item := range list.Items {
-		if item == selectableItem {
+	for idx, row := range list.Rows() {
+		if row.Item == selectableItem {
 			list.Selected = idx
 			break
 		}
 	}
 }
 
+// Sort orders the items, and the items nested under each one, by the orders. Each order only
+// decides between items the orders before it consider equal, and items that every order
+// considers equal stay as they were. The selected item stays selected
+func (list *Checklist) Sort(orders ...Order) {
+	selected := list.SelectedItem()
+
+	sortItems(list.Items, orders)
+
+	list.SetSelectedByItem(selected)
+}
+
 // Toggle switches the checked state of the currently-selected item
 func (list *Checklist) Toggle() {
 	if list.IsUnselectable() {
@@ -143,11 +242,11 @@ func (list *Checklist) Toggle() {
 	list.SelectedItem().Toggle()
 }
 
-// UncheckedItems returns a slice of all the unchecked items
+// UncheckedItems returns a slice of all the unchecked items, nested ones included
 func (list *Checklist) UncheckedItems() []*ChecklistItem {
 	items := []*ChecklistItem{}
 
-	for _, item := range list.Items {
+	for _, item := range list.allItems() {
 		if !item.Checked {
 			items = append(items, item)
 		}
@@ -172,6 +271,69 @@ func (list *Checklist) Update(text string) {
 	item.Text = text
 }
 
+/* -------------------- Filters -------------------- */
+
+// Overdue lists only the items that are overdue
+func Overdue(item *ChecklistItem) bool {
+	return item.IsOverdue()
+}
+
+// Tagged lists only the items tagged with at least one of the tags
+func Tagged(tags ...string) Filter {
+	return func(item *ChecklistItem) bool {
+		for _, tag := range tags {
+			if item.HasTag(tag) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Unchecked hides the checked items
+func Unchecked(item *ChecklistItem) bool {
+	return !item.Checked
+}
+
+/* -------------------- Orders -------------------- */
+
+// ByChecked puts unchecked items before checked ones
+func ByChecked(a, b *ChecklistItem) bool {
+	return !a.Checked && b.Checked
+}
+
+// ByDue puts items that are due sooner first, and items without a due date last
+func ByDue(a, b *ChecklistItem) bool {
+	aDue, aOk := a.DueDate()
+	bDue, bOk := b.DueDate()
+
+	if aOk != bOk {
+		return aOk
+	}
+
+	return aOk && aDue.Before(bDue)
+}
+
+// ByOverdue puts overdue items first
+func ByOverdue(a, b *ChecklistItem) bool {
+	return a.IsOverdue() && !b.IsOverdue()
+}
+
+// ByPriority puts the most important items first, and items without a priority last
+func ByPriority(a, b *ChecklistItem) bool {
+	if (a.Priority == 0) != (b.Priority == 0) {
+		return a.Priority != 0
+	}
+
+	return a.Priority < b.Priority
+}
+
+// ByText puts items in alphabetical order
+func ByText(a, b *ChecklistItem) bool {
+	return a.Text < b.Text
+}
+
 /* -------------------- Sort Interface -------------------- */
 
 func (list *Checklist) Len() int {
@@ -185,3 +347,122 @@ func (list *Checklist) Less(i, j int) bool {
 func (list *Checklist) Swap(i, j int) {
 	list.Items[i], list.Items[j] = list.Items[j], list.Items[i]
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// allItems returns every item, with the items nested under each one after it
+func (list *Checklist) allItems() []*ChecklistItem {
+	items := []*ChecklistItem{}
+
+	var walk func([]*ChecklistItem)
+	walk = func(level []*ChecklistItem) {
+		for _, item := range level {
+			items = append(items, item)
+			walk(item.Items)
+		}
+	}
+	walk(list.Items)
+
+	return items
+}
+
+// appendRows appends the listed items of one level, and the levels nested under them, and
+// returns true if any were listed
+func (list *Checklist) appendRows(rows *[]ChecklistRow, items []*ChecklistItem, depth int) bool {
+	listed := false
+
+	for _, item := range items {
+		idx := len(*rows)
+		*rows = append(*rows, ChecklistRow{Depth: depth, Item: item})
+
+		nested := list.appendRows(rows, item.Items, depth+1)
+
+		if !nested && !list.passes(item) {
+			*rows = append((*rows)[:idx], (*rows)[idx+1:]...)
+			continue
+		}
+
+		listed = true
+	}
+
+	return listed
+}
+
+// childrenOf returns the items nested under the parent, or the top-level items if it's nil
+func (list *Checklist) childrenOf(parent *ChecklistItem) *[]*ChecklistItem {
+	if parent == nil {
+		return &list.Items
+	}
+
+	return &parent.Items
+}
+
+// locate returns the item the target is nested under, nil for the top level, and the target's
+// index among the items at its level
+func (list *Checklist) locate(target *ChecklistItem) (*ChecklistItem, int) {
+	var find func(parent *ChecklistItem, items []*ChecklistItem) (*ChecklistItem, int, bool)
+	find = func(parent *ChecklistItem, items []*ChecklistItem) (*ChecklistItem, int, bool) {
+		for idx, item := range items {
+			if item == target {
+				return parent, idx, true
+			}
+
+			if found, foundIdx, ok := find(item, item.Items); ok {
+				return found, foundIdx, true
+			}
+		}
+
+		return nil, -1, false
+	}
+
+	parent, idx, _ := find(nil, list.Items)
+
+	return parent, idx
+}
+
+// move moves the selected item by the offset among the items at the same level, wrapping
+// around at either end
+func (list *Checklist) move(offset int) {
+	item := list.SelectedItem()
+	if item == nil {
+		return
+	}
+
+	parent, idx := list.locate(item)
+	siblings := *list.childrenOf(parent)
+
+	j := (idx + offset + len(siblings)) % len(siblings)
+	siblings[idx], siblings[j] = siblings[j], siblings[idx]
+
+	list.SetSelectedByItem(item)
+}
+
+func (list *Checklist) passes(item *ChecklistItem) bool {
+	for _, filter := range list.filters {
+		if !filter(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortItems(items []*ChecklistItem, orders []Order) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, order := range orders {
+			if order(items[i], items[j]) {
+				return true
+			}
+
+			if order(items[j], items[i]) {
+				return false
+			}
+		}
+
+		return false
+	})
+
+	for _, item := range items {
+		sortItems(item.Items, orders)
+	}
+}
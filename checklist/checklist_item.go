@@ -1,27 +1,103 @@
 package checklist
 
 import (
-	"github.com/wtfutil/wtf/wtf"
+	"strings"
+	"time"
 )
 
+// DateFormat is the format of an item's dates, i.e.: "2019-04-30"
+const DateFormat = "2006-01-02"
+
 // ChecklistItem is a module for creating generic checklist implementations
 // See 'Todo' for an implementation example
 type ChecklistItem struct {
 	Checked bool
 	Text    string
+
+	// Due is the date the item is due, in DateFormat
+	Due string `yaml:"due,omitempty"`
+
+	// Priority orders items from 1, the most important, upwards. Items without one are 0, and
+	// come after every item that has one
+	Priority int `yaml:"priority,omitempty"`
+
+	Tags []string `yaml:"tags,omitempty"`
+
+	// Created and Completed are the dates the item was created and checked, in DateFormat
+	Created   string `yaml:"created,omitempty"`
+	Completed string `yaml:"completed,omitempty"`
+
+	// Items are the items nested under this one
+	Items []*ChecklistItem `yaml:"items,omitempty"`
 }
 
-// CheckMark returns the string used to indicate a ChecklistItem is checked or unchecked
-func (item *ChecklistItem) CheckMark() string {
+// CheckMark returns the string used to indicate a ChecklistItem is checked or unchecked,
+// which is checkedIcon if it's checked. Each widget passes in its own checkedIcon setting
+func (item *ChecklistItem) CheckMark(checkedIcon string) string {
 	if item.Checked {
-		return wtf.Config.UString("wtf.mods.todo.checkedIcon", "x")
+		return checkedIcon
 	}
 
 	return " "
 }
 
+// DueDate returns the date the item is due, and false if it has no due date or the date can't
+// be parsed
+func (item *ChecklistItem) DueDate() (time.Time, bool) {
+	if item.Due == "" {
+		return time.Time{}, false
+	}
+
+	date, err := time.ParseInLocation(DateFormat, item.Due, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+// HasTag returns true if the item is tagged with the tag
+func (item *ChecklistItem) HasTag(tag string) bool {
+	for _, itemTag := range item.Tags {
+		if itemTag == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Mentions returns true if the word appears on its own in the item's text
+func (item *ChecklistItem) Mentions(word string) bool {
+	for _, field := range strings.Fields(item.Text) {
+		if field == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsOverdue returns true if the item is unchecked and was due before today
+func (item *ChecklistItem) IsOverdue() bool {
+	due, ok := item.DueDate()
+	if !ok || item.Checked {
+		return false
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	return due.Before(today)
+}
+
 // Toggle changes the checked state of the ChecklistItem
-// If checked, it is unchecked. If unchecked, it is checked
+// If checked, it is unchecked. If unchecked, it is checked and marked as completed today
 func (item *ChecklistItem) Toggle() {
 	item.Checked = !item.Checked
+
+	item.Completed = ""
+	if item.Checked {
+		item.Completed = time.Now().Format(DateFormat)
+	}
 }
@@ -0,0 +1,75 @@
+package checklist
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Format reads and writes a checklist's items as the contents of a file
+type Format interface {
+	Read(data []byte, list *Checklist) error
+	Write(list *Checklist) ([]byte, error)
+}
+
+// NewFormat returns the format with the given name: "yaml", "todo.txt" or "markdown". Without
+// a name, the format is chosen by the file's extension, and is YAML unless it's .txt or .md
+func NewFormat(name string, filePath string) (Format, error) {
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(filePath)) {
+		case ".txt":
+			name = "todo.txt"
+		case ".md", ".markdown":
+			name = "markdown"
+		default:
+			name = "yaml"
+		}
+	}
+
+	switch strings.ToLower(name) {
+	case "yaml", "yml":
+		return &YAMLFormat{}, nil
+	case "todo.txt", "todotxt":
+		return &TodoTxtFormat{}, nil
+	case "markdown", "md":
+		return &MarkdownFormat{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown todo file format %q, expected yaml, todo.txt or markdown", name)
+}
+
+/* -------------------- YAML -------------------- */
+
+// YAMLFormat stores the whole checklist, the selected item included, as YAML
+type YAMLFormat struct{}
+
+func (format *YAMLFormat) Read(data []byte, list *Checklist) error {
+	return yaml.Unmarshal(data, list)
+}
+
+func (format *YAMLFormat) Write(list *Checklist) ([]byte, error) {
+	return yaml.Marshal(list)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// isDate returns true if the word is a date in DateFormat
+func isDate(word string) bool {
+	_, err := time.Parse(DateFormat, word)
+	return err == nil
+}
+
+// splitLines splits the data into lines, without their line endings
+func splitLines(data []byte) []string {
+	text := strings.Replace(string(data), "\r\n", "\n", -1)
+	text = strings.TrimSuffix(text, "\n")
+
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,198 @@
+package checklist
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markdownTask matches a task list item, i.e.: "  - [x] Buy milk"
+var markdownTask = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\] ?(.*)$`)
+
+// MarkdownFormat stores the checklist as a Markdown task list, with nested items indented
+// under the item they belong to. #tags, !priorities and the due: and done: dates are read
+// from the text. The first run of task items is the checklist; the lines before and after it
+// are kept as they are
+type MarkdownFormat struct {
+	before []string
+	after  []string
+}
+
+func (format *MarkdownFormat) Read(data []byte, list *Checklist) error {
+	format.before = []string{}
+	format.after = []string{}
+
+	type level struct {
+		indent int
+		item   *ChecklistItem
+	}
+
+	items := []*ChecklistItem{}
+	parents := []level{}
+	inTasks, pastTasks := false, false
+
+	// Blank lines between items are dropped, but kept if the list ends after them
+	blanks := []string{}
+
+	for _, line := range splitLines(data) {
+		match := markdownTask.FindStringSubmatch(line)
+
+		if match == nil || pastTasks {
+			switch {
+			case !inTasks:
+				format.before = append(format.before, line)
+			case !pastTasks && strings.TrimSpace(line) == "":
+				blanks = append(blanks, line)
+			case !pastTasks:
+				pastTasks = true
+				format.after = append(append(format.after, blanks...), line)
+			default:
+				format.after = append(format.after, line)
+			}
+
+			continue
+		}
+
+		inTasks = true
+		blanks = []string{}
+
+		item := parseMarkdown(match[3])
+		item.Checked = match[2] != " "
+
+		indent := markdownIndent(match[1])
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+
+		if len(parents) == 0 {
+			items = append(items, item)
+		} else {
+			parent := parents[len(parents)-1].item
+			parent.Items = append(parent.Items, item)
+		}
+
+		parents = append(parents, level{indent: indent, item: item})
+	}
+
+	list.Items = items
+
+	return nil
+}
+
+func (format *MarkdownFormat) Write(list *Checklist) ([]byte, error) {
+	lines := append([]string{}, format.before...)
+	lines = appendMarkdown(lines, list.Items, 0)
+	lines = append(lines, format.after...)
+
+	if len(lines) == 0 {
+		return []byte{}, nil
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// appendMarkdown appends a task for each of the items, and the items nested under them, indented
+// by their depth
+func appendMarkdown(lines []string, items []*ChecklistItem, depth int) []string {
+	for _, item := range items {
+		mark := " "
+		if item.Checked {
+			mark = "x"
+		}
+
+		line := strings.Repeat("  ", depth) + "- [" + mark + "] " + formatMarkdown(item)
+		lines = append(lines, strings.TrimRight(line, " "))
+
+		lines = appendMarkdown(lines, item.Items, depth+1)
+	}
+
+	return lines
+}
+
+// parseMarkdown reads an item from the text of a task, i.e.:
+//
+//	File taxes #finance !1 due:2019-04-30
+func parseMarkdown(text string) *ChecklistItem {
+	item := &ChecklistItem{}
+	words := []string{}
+
+	for _, word := range strings.Fields(text) {
+		switch {
+		case strings.HasPrefix(word, "due:") && isDate(word[4:]):
+			item.Due = word[4:]
+			continue
+		case strings.HasPrefix(word, "done:") && isDate(word[5:]):
+			item.Completed = word[5:]
+			continue
+		case markdownPriority(word) > 0:
+			item.Priority = markdownPriority(word)
+			continue
+		case len(word) > 1 && (word[0] == '#' || word[0] == '@'):
+			tag := strings.TrimPrefix(word, "#")
+			if !item.HasTag(tag) {
+				item.Tags = append(item.Tags, tag)
+			}
+		}
+
+		words = append(words, word)
+	}
+
+	item.Text = strings.Join(words, " ")
+
+	return item
+}
+
+// formatMarkdown writes the text of a task, adding the tags that aren't in the text already
+func formatMarkdown(item *ChecklistItem) string {
+	words := []string{}
+
+	if item.Text != "" {
+		words = append(words, item.Text)
+	}
+
+	for _, tag := range item.Tags {
+		if !strings.HasPrefix(tag, "@") {
+			tag = "#" + tag
+		}
+
+		if !item.Mentions(tag) {
+			words = append(words, tag)
+		}
+	}
+
+	if item.Priority > 0 {
+		words = append(words, "!"+strconv.Itoa(item.Priority))
+	}
+
+	if item.Due != "" {
+		words = append(words, "due:"+item.Due)
+	}
+
+	if item.Checked && item.Completed != "" {
+		words = append(words, "done:"+item.Completed)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// markdownIndent returns how far a task is indented, counting a tab as four spaces
+func markdownIndent(whitespace string) int {
+	return len(strings.Replace(whitespace, "\t", "    ", -1))
+}
+
+// markdownPriority returns the priority that a word like !1 stands for, or 0 if the word isn't
+// a priority
+func markdownPriority(word string) int {
+	if len(word) < 2 || word[0] != '!' {
+		return 0
+	}
+
+	priority, err := strconv.Atoi(word[1:])
+	if err != nil || priority < 1 {
+		return 0
+	}
+
+	return priority
+}
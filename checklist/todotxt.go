@@ -0,0 +1,220 @@
+package checklist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TodoTxtFormat stores the checklist in the todo.txt format (http://todotxt.org), one item per
+// line. Priorities (A) to (Z) are read as 1 to 26, +projects as tags, @contexts as tags that
+// keep their @, and the due: extension as the due date. The format can't nest items itself, so
+// items that others are nested under are given an id: extension, and the nested items a parent:
+// extension naming it. They're written out after the item they're nested under
+type TodoTxtFormat struct{}
+
+// todoTxtLine is an item as it was read, with the id: and parent: extensions that place it
+type todoTxtLine struct {
+	item   *ChecklistItem
+	id     string
+	parent string
+}
+
+func (format *TodoTxtFormat) Read(data []byte, list *Checklist) error {
+	lines := []todoTxtLine{}
+	ids := map[string]*ChecklistItem{}
+
+	for _, line := range splitLines(data) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parsed := parseTodoTxt(line)
+		if parsed.id != "" {
+			ids[parsed.id] = parsed.item
+		}
+
+		lines = append(lines, parsed)
+	}
+
+	items := []*ChecklistItem{}
+	parents := map[*ChecklistItem]*ChecklistItem{}
+
+	for _, line := range lines {
+		parent, ok := ids[line.parent]
+		if !ok || nestedUnder(parent, line.item, parents) {
+			items = append(items, line.item)
+			continue
+		}
+
+		parents[line.item] = parent
+		parent.Items = append(parent.Items, line.item)
+	}
+
+	list.Items = items
+
+	return nil
+}
+
+func (format *TodoTxtFormat) Write(list *Checklist) ([]byte, error) {
+	lines := appendTodoTxt([]string{}, list.Items, "", new(int))
+
+	if len(lines) == 0 {
+		return []byte{}, nil
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// appendTodoTxt appends a line for each of the items, followed by the lines of the items nested
+// under it. Items with nested items are numbered in the order they're written, from 1
+func appendTodoTxt(lines []string, items []*ChecklistItem, parent string, lastID *int) []string {
+	for _, item := range items {
+		line := formatTodoTxt(item)
+		id := ""
+
+		if len(item.Items) > 0 {
+			*lastID++
+			id = strconv.Itoa(*lastID)
+			line = line + " id:" + id
+		}
+
+		if parent != "" {
+			line = line + " parent:" + parent
+		}
+
+		lines = append(lines, line)
+		lines = appendTodoTxt(lines, item.Items, id, lastID)
+	}
+
+	return lines
+}
+
+// nestedUnder returns true if the item is the ancestor itself or nested under it somewhere, in
+// which case nesting the ancestor under the item would make a loop
+func nestedUnder(item, ancestor *ChecklistItem, parents map[*ChecklistItem]*ChecklistItem) bool {
+	for ; item != nil; item = parents[item] {
+		if item == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTodoTxt reads an item from a line of todo.txt, i.e.:
+//
+//	x 2019-05-02 2019-04-28 Call mom +family @phone due:2019-05-01 pri:A
+//	(B) 2019-04-28 File taxes +finance id:1
+//	Find receipts parent:1
+func parseTodoTxt(line string) todoTxtLine {
+	item := &ChecklistItem{}
+	parsed := todoTxtLine{item: item}
+	words := strings.Fields(line)
+
+	if len(words) > 0 && words[0] == "x" {
+		item.Checked = true
+		words = words[1:]
+
+		if len(words) > 0 && isDate(words[0]) {
+			item.Completed = words[0]
+			words = words[1:]
+		}
+	} else if len(words) > 0 && todoTxtPriority(words[0]) > 0 {
+		item.Priority = todoTxtPriority(words[0])
+		words = words[1:]
+	}
+
+	if len(words) > 0 && isDate(words[0]) {
+		item.Created = words[0]
+		words = words[1:]
+	}
+
+	text := []string{}
+	for _, word := range words {
+		switch {
+		case strings.HasPrefix(word, "due:") && isDate(word[4:]):
+			item.Due = word[4:]
+			continue
+		case strings.HasPrefix(word, "pri:") && todoTxtPriority("("+word[4:]+")") > 0:
+			item.Priority = todoTxtPriority("(" + word[4:] + ")")
+			continue
+		case strings.HasPrefix(word, "id:") && len(word) > 3:
+			parsed.id = word[3:]
+			continue
+		case strings.HasPrefix(word, "parent:") && len(word) > 7:
+			parsed.parent = word[7:]
+			continue
+		case len(word) > 1 && (word[0] == '+' || word[0] == '@'):
+			tag := strings.TrimPrefix(word, "+")
+			if !item.HasTag(tag) {
+				item.Tags = append(item.Tags, tag)
+			}
+		}
+
+		text = append(text, word)
+	}
+
+	item.Text = strings.Join(text, " ")
+
+	return parsed
+}
+
+// formatTodoTxt writes the item as a line of todo.txt. Tags that aren't in the text already are
+// added as +projects, unless they're @contexts. As completed items can't start with their
+// priority, it's kept in the pri: extension
+func formatTodoTxt(item *ChecklistItem) string {
+	words := []string{}
+
+	if item.Checked {
+		words = append(words, "x")
+
+		if item.Completed != "" {
+			words = append(words, item.Completed)
+		}
+	} else if item.Priority >= 1 && item.Priority <= 26 {
+		words = append(words, fmt.Sprintf("(%c)", 'A'+item.Priority-1))
+	}
+
+	// A single date after the x is the completion date, so without one the creation date is
+	// left out rather than mistaken for it
+	if item.Created != "" && (!item.Checked || item.Completed != "") {
+		words = append(words, item.Created)
+	}
+
+	if item.Text != "" {
+		words = append(words, item.Text)
+	}
+
+	for _, tag := range item.Tags {
+		if !strings.HasPrefix(tag, "@") {
+			tag = "+" + tag
+		}
+
+		if !item.Mentions(tag) {
+			words = append(words, tag)
+		}
+	}
+
+	if item.Checked && item.Priority >= 1 && item.Priority <= 26 {
+		words = append(words, fmt.Sprintf("pri:%c", 'A'+item.Priority-1))
+	}
+
+	if item.Due != "" {
+		words = append(words, "due:"+item.Due)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// todoTxtPriority returns the priority that a word like (A) stands for, from 1 for (A) to 26
+// for (Z), or 0 if the word isn't a priority
+func todoTxtPriority(word string) int {
+	if len(word) != 3 || word[0] != '(' || word[2] != ')' || word[1] < 'A' || word[1] > 'Z' {
+		return 0
+	}
+
+	return int(word[1]-'A') + 1
+}
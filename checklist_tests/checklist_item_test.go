@@ -2,6 +2,7 @@ package wtftests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/olebedev/config"
 	. "github.com/stretchr/testify/assert"
@@ -12,13 +13,25 @@ import (
 /* -------------------- CheckMark -------------------- */
 
 func TestCheckMark(t *testing.T) {
-	loadConfig()
-
 	item := ChecklistItem{}
-	Equal(t, " ", item.CheckMark())
+	Equal(t, " ", item.CheckMark("x"))
 
 	item = ChecklistItem{Checked: true}
-	Equal(t, "x", item.CheckMark())
+	Equal(t, "x", item.CheckMark("x"))
+	Equal(t, "✔", item.CheckMark("✔"))
+}
+
+/* -------------------- IsOverdue -------------------- */
+
+func TestIsOverdue(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1).Format(DateFormat)
+	today := time.Now().Format(DateFormat)
+
+	Equal(t, false, (&ChecklistItem{}).IsOverdue())
+	Equal(t, false, (&ChecklistItem{Due: today}).IsOverdue())
+	Equal(t, false, (&ChecklistItem{Due: "someday"}).IsOverdue())
+	Equal(t, false, (&ChecklistItem{Due: yesterday, Checked: true}).IsOverdue())
+	Equal(t, true, (&ChecklistItem{Due: yesterday}).IsOverdue())
 }
 
 /* -------------------- Toggle -------------------- */
@@ -31,9 +44,11 @@ func TestToggle(t *testing.T) {
 
 	item.Toggle()
 	Equal(t, true, item.Checked)
+	Equal(t, time.Now().Format(DateFormat), item.Completed)
 
 	item.Toggle()
 	Equal(t, false, item.Checked)
+	Equal(t, "", item.Completed)
 }
 
 /* -------------------- helpers -------------------- */
@@ -0,0 +1,106 @@
+package wtftests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/checklist"
+)
+
+/* -------------------- Rows -------------------- */
+
+func TestRows(t *testing.T) {
+	list := testChecklist()
+
+	Equal(t, []string{"0:groceries", "1:milk", "1:eggs", "2:free range", "0:taxes"}, rowTexts(list))
+
+	list.SetFilters(Unchecked)
+	Equal(t, []string{"0:groceries", "1:eggs", "2:free range", "0:taxes"}, rowTexts(list))
+
+	list.SetFilters(Tagged("home"))
+	Equal(t, []string{"0:groceries", "1:eggs", "2:free range"}, rowTexts(list))
+}
+
+/* -------------------- Indent and Outdent -------------------- */
+
+func TestIndentOutdent(t *testing.T) {
+	list := testChecklist()
+
+	list.Selected = 4
+	list.Indent()
+	Equal(t, []string{"0:groceries", "1:milk", "1:eggs", "2:free range", "1:taxes"}, rowTexts(list))
+	Equal(t, "taxes", list.SelectedItem().Text)
+
+	list.Selected = 3
+	list.Outdent()
+	Equal(t, []string{"0:groceries", "1:milk", "1:eggs", "1:free range", "1:taxes"}, rowTexts(list))
+
+	list.Selected = 0
+	list.Indent()
+	list.Outdent()
+	Equal(t, "0:groceries", rowTexts(list)[0])
+}
+
+/* -------------------- Insert -------------------- */
+
+func TestInsert(t *testing.T) {
+	list := testChecklist()
+
+	list.Insert(&ChecklistItem{Text: "first"})
+	Equal(t, "0:first", rowTexts(list)[0])
+
+	list.Selected = 2
+	list.Insert(&ChecklistItem{Text: "butter"})
+	Equal(t, []string{"0:first", "0:groceries", "1:milk", "1:butter", "1:eggs", "2:free range", "0:taxes"}, rowTexts(list))
+	Equal(t, "butter", list.SelectedItem().Text)
+}
+
+/* -------------------- Sort -------------------- */
+
+func TestSort(t *testing.T) {
+	list := testChecklist()
+	list.Selected = 1
+
+	list.Sort(ByOverdue, ByPriority)
+	Equal(t, []string{"0:taxes", "0:groceries", "1:eggs", "2:free range", "1:milk"}, rowTexts(list))
+	Equal(t, "milk", list.SelectedItem().Text)
+
+	list.Sort(ByChecked, ByText)
+	Equal(t, []string{"0:groceries", "1:eggs", "2:free range", "1:milk", "0:taxes"}, rowTexts(list))
+}
+
+/* -------------------- helpers -------------------- */
+
+func rowTexts(list Checklist) []string {
+	texts := []string{}
+
+	for _, row := range list.Rows() {
+		texts = append(texts, fmt.Sprintf("%d:%s", row.Depth, row.Item.Text))
+	}
+
+	return texts
+}
+
+func testChecklist() Checklist {
+	yesterday := time.Now().AddDate(0, 0, -1).Format(DateFormat)
+
+	list := NewChecklist()
+	list.Items = []*ChecklistItem{
+		{
+			Text: "groceries",
+			Items: []*ChecklistItem{
+				{Text: "milk", Checked: true},
+				{
+					Text:     "eggs",
+					Priority: 1,
+					Items:    []*ChecklistItem{{Text: "free range", Tags: []string{"home"}}},
+				},
+			},
+		},
+		{Text: "taxes", Due: yesterday, Tags: []string{"work"}},
+	}
+
+	return list
+}
@@ -0,0 +1,49 @@
+package wtftests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/checklist"
+)
+
+/* -------------------- NewFormat -------------------- */
+
+func TestNewFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		expected Format
+	}{
+		{"", "todo.yml", &YAMLFormat{}},
+		{"", "todo.txt", &TodoTxtFormat{}},
+		{"", "notes/TODO.md", &MarkdownFormat{}},
+		{"markdown", "todo.yml", &MarkdownFormat{}},
+		{"todo.txt", "todo", &TodoTxtFormat{}},
+	}
+
+	for _, test := range tests {
+		format, err := NewFormat(test.name, test.filePath)
+		Nil(t, err)
+		IsType(t, test.expected, format)
+	}
+
+	_, err := NewFormat("org", "todo.org")
+	NotNil(t, err)
+}
+
+/* -------------------- YAMLFormat -------------------- */
+
+func TestYAMLFormat(t *testing.T) {
+	list := testChecklist()
+	list.Selected = 2
+
+	data, err := (&YAMLFormat{}).Write(&list)
+	Nil(t, err)
+
+	read := NewChecklist()
+	Nil(t, (&YAMLFormat{}).Read(data, &read))
+
+	Equal(t, rowTexts(list), rowTexts(read))
+	Equal(t, 2, read.Selected)
+}
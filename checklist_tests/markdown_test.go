@@ -0,0 +1,61 @@
+package wtftests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/checklist"
+)
+
+/* -------------------- MarkdownFormat -------------------- */
+
+func TestMarkdownRead(t *testing.T) {
+	data := "# Chores\n" +
+		"\n" +
+		"- [ ] groceries #home !2\n" +
+		"  - [x] milk done:2019-05-02\n" +
+		"  * [ ] eggs due:2019-05-01\n" +
+		"\n" +
+		"- [X] taxes\n"
+
+	list := NewChecklist()
+	Nil(t, (&MarkdownFormat{}).Read([]byte(data), &list))
+
+	Equal(t, []string{"0:groceries #home", "1:milk", "1:eggs", "0:taxes"}, rowTexts(list))
+
+	groceries := list.Items[0]
+	Equal(t, 2, groceries.Priority)
+	Equal(t, []string{"home"}, groceries.Tags)
+	Equal(t, "2019-05-02", groceries.Items[0].Completed)
+	Equal(t, true, groceries.Items[0].Checked)
+	Equal(t, "2019-05-01", groceries.Items[1].Due)
+	Equal(t, true, list.Items[1].Checked)
+}
+
+func TestMarkdownWrite(t *testing.T) {
+	data := "# Chores\n" +
+		"\n" +
+		"- [ ] groceries #home !2\n" +
+		"  - [x] milk done:2019-05-02\n" +
+		"\n" +
+		"Notes about the chores\n"
+
+	format := &MarkdownFormat{}
+
+	list := NewChecklist()
+	Nil(t, format.Read([]byte(data), &list))
+
+	list.Items[0].Items = append(list.Items[0].Items, &ChecklistItem{Text: "eggs", Tags: []string{"farm"}})
+
+	written, err := format.Write(&list)
+	Nil(t, err)
+
+	expected := "# Chores\n" +
+		"\n" +
+		"- [ ] groceries #home !2\n" +
+		"  - [x] milk done:2019-05-02\n" +
+		"  - [ ] eggs #farm\n" +
+		"\n" +
+		"Notes about the chores\n"
+	Equal(t, expected, string(written))
+}
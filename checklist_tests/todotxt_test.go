@@ -0,0 +1,97 @@
+package wtftests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/checklist"
+)
+
+/* -------------------- TodoTxtFormat -------------------- */
+
+func TestTodoTxtRead(t *testing.T) {
+	data := "(A) 2019-04-28 Call mom +family @phone due:2019-05-01\n" +
+		"\n" +
+		"x 2019-05-02 2019-04-28 File taxes +finance pri:B\n" +
+		"Buy milk\n"
+
+	list := NewChecklist()
+	Nil(t, (&TodoTxtFormat{}).Read([]byte(data), &list))
+	Equal(t, 3, len(list.Items))
+
+	call := list.Items[0]
+	Equal(t, false, call.Checked)
+	Equal(t, 1, call.Priority)
+	Equal(t, "2019-04-28", call.Created)
+	Equal(t, "2019-05-01", call.Due)
+	Equal(t, "Call mom +family @phone", call.Text)
+	Equal(t, []string{"family", "@phone"}, call.Tags)
+
+	taxes := list.Items[1]
+	Equal(t, true, taxes.Checked)
+	Equal(t, 2, taxes.Priority)
+	Equal(t, "2019-05-02", taxes.Completed)
+	Equal(t, "2019-04-28", taxes.Created)
+	Equal(t, "File taxes +finance", taxes.Text)
+
+	Equal(t, "Buy milk", list.Items[2].Text)
+}
+
+func TestTodoTxtWrite(t *testing.T) {
+	list := NewChecklist()
+	list.Items = []*ChecklistItem{
+		{Text: "Call mom +family", Priority: 1, Created: "2019-04-28", Tags: []string{"family", "@phone"}},
+		{Text: "File taxes", Checked: true, Completed: "2019-05-02", Priority: 2, Due: "2019-04-30"},
+		{Text: "groceries", Tags: []string{"home"}, Items: []*ChecklistItem{{Text: "milk"}}},
+	}
+
+	data, err := (&TodoTxtFormat{}).Write(&list)
+	Nil(t, err)
+
+	expected := "(A) 2019-04-28 Call mom +family @phone\n" +
+		"x 2019-05-02 File taxes pri:B due:2019-04-30\n" +
+		"groceries +home id:1\n" +
+		"milk parent:1\n"
+	Equal(t, expected, string(data))
+
+	read := NewChecklist()
+	Nil(t, (&TodoTxtFormat{}).Read(data, &read))
+
+	written, _ := (&TodoTxtFormat{}).Write(&read)
+	Equal(t, expected, string(written))
+}
+
+func TestTodoTxtNesting(t *testing.T) {
+	data := "milk parent:1\n" +
+		"groceries id:1\n" +
+		"bread parent:1 id:2\n" +
+		"rye parent:2\n" +
+		"taxes parent:9\n" +
+		"a id:3 parent:4\n" +
+		"b id:4 parent:3\n"
+
+	list := NewChecklist()
+	Nil(t, (&TodoTxtFormat{}).Read([]byte(data), &list))
+
+	texts := func(items []*ChecklistItem) []string {
+		result := []string{}
+		for _, item := range items {
+			result = append(result, item.Text)
+		}
+		return result
+	}
+
+	Equal(t, []string{"groceries", "taxes", "b"}, texts(list.Items))
+	Equal(t, []string{"milk", "bread"}, texts(list.Items[0].Items))
+	Equal(t, []string{"rye"}, texts(list.Items[0].Items[1].Items))
+	Equal(t, []string{"a"}, texts(list.Items[2].Items))
+
+	written, _ := (&TodoTxtFormat{}).Write(&list)
+	Equal(t, "groceries id:1\n"+
+		"milk parent:1\n"+
+		"bread id:2 parent:1\n"+
+		"rye parent:2\n"+
+		"taxes\n"+
+		"b id:3\n"+
+		"a parent:3\n", string(written))
+}
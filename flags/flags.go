@@ -11,10 +11,18 @@ import (
 )
 
 type Flags struct {
-	Config  string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
-	Module  string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtf -m=todo'"`
-	Profile bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
-	Version bool   `short:"v" long:"version" description:"Show version info"`
+	Config   string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
+	Format   string `long:"format" default:"text" description:"Output format of --once: 'text' or 'json'"`
+	Module   string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtf -m=todo'. With --once, only refresh that module"`
+	Once     bool   `long:"once" description:"Refresh every enabled module once, print what they display, and exit"`
+	Profile  bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
+	Validate bool   `long:"validate" description:"Check the config file for errors and exit"`
+	Version  bool   `short:"v" long:"version" description:"Show version info"`
+	Web      bool   `long:"web" description:"With 'wtf serve', also serve a web page of the dashboard"`
+
+	// Serve is set by the "serve" command, i.e.: 'wtf serve --web', which runs the modules
+	// for the HTTP API instead of the terminal dashboard
+	Serve bool
 }
 
 func NewFlags() *Flags {
@@ -29,7 +37,7 @@ func (flags *Flags) ConfigFilePath() string {
 }
 
 func (flags *Flags) Display(version string) {
-	if flags.HasModule() {
+	if flags.HasModule() && !flags.Once {
 		help.Display(flags.Module)
 		os.Exit(0)
 	}
@@ -54,12 +62,15 @@ func (flags *Flags) HasVersion() bool {
 
 func (flags *Flags) Parse() {
 	parser := goFlags.NewParser(flags, goFlags.Default)
-	if _, err := parser.Parse(); err != nil {
+	args, err := parser.Parse()
+	if err != nil {
 		if flagsErr, ok := err.(*goFlags.Error); ok && flagsErr.Type == goFlags.ErrHelp {
 			os.Exit(0)
 		}
 	}
 
+	flags.Serve = len(args) > 0 && args[0] == "serve"
+
 	// If no config file is explicitly passed in as a param,
 	// set the flag to the default config file
 	if !flags.HasConfig() {
@@ -2,6 +2,7 @@
 package {{(Lower .Name)}}
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gdamore/tcell"
@@ -13,15 +14,25 @@ const HelpText = `
  Keyboard commands for {{(Title .Name)}}:
 `
 
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name:     "{{(Lower .Name)}}",
+		HelpText: HelpText,
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+	})
+}
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
 		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "{{(Title .Name)}}", "{{(Lower .Name)}}", true),
+		TextWidget:    wtf.NewTextWidget(app, "{{(Title .Name)}}", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
@@ -36,10 +47,12 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 
 	// The last call should always be to the display function
 	widget.display()
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -0,0 +1,138 @@
+package headless
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Formats are the output formats Display supports
+var Formats = []string{"text", "json"}
+
+// result is what one widget displayed after refreshing, as printed in JSON
+type result struct {
+	Key   string   `json:"key"`
+	Title string   `json:"title"`
+	Lines []string `json:"lines"`
+	Error string   `json:"error,omitempty"`
+
+	// Content is the widget's content model, for widgets that have one
+	Content *wtf.Content `json:"content,omitempty"`
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Display refreshes every widget once, all at the same time, and writes what each of them
+// displays to out, without colors, as "text" or "json". It returns false if any of the
+// widgets failed to refresh
+func Display(out io.Writer, widgets []wtf.Wtfable, format string) (bool, error) {
+	if !validFormat(format) {
+		return false, fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats, ", "))
+	}
+
+	results := refresh(widgets)
+
+	ok := true
+	for _, result := range results {
+		if result.Error != "" {
+			ok = false
+		}
+	}
+
+	if format == "json" {
+		return ok, writeJSON(out, results)
+	}
+
+	return ok, writeText(out, results)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// refresh refreshes the widgets in parallel and returns their results ordered by key
+func refresh(widgets []wtf.Wtfable) []result {
+	scheduler := wtf.NewScheduler(nil, nil)
+	defer scheduler.Stop()
+
+	results := make([]result, len(widgets))
+
+	var wg sync.WaitGroup
+	for idx, widget := range widgets {
+		wg.Add(1)
+
+		go func(idx int, widget wtf.Wtfable) {
+			defer wg.Done()
+
+			err := scheduler.RefreshOnce(widget)
+			results[idx] = resultFor(widget, err)
+		}(idx, widget)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Key < results[j].Key
+	})
+
+	return results
+}
+
+func resultFor(widget wtf.Wtfable, err error) result {
+	result := result{
+		Key:   widget.Key(),
+		Title: widget.Name(),
+		Lines: wtf.PlainLines(widget.Content()),
+
+		Content: widget.Model(),
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func validFormat(format string) bool {
+	for _, valid := range Formats {
+		if format == valid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeJSON(out io.Writer, results []result) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+// writeText prints each widget's content under its title. A single widget is printed on its
+// own, so that its output can go straight into a shell prompt or status line
+func writeText(out io.Writer, results []result) error {
+	for idx, result := range results {
+		if len(results) > 1 {
+			if idx > 0 {
+				fmt.Fprintln(out)
+			}
+
+			fmt.Fprintf(out, "%s\n", result.Title)
+		}
+
+		for _, line := range result.Lines {
+			fmt.Fprintln(out, line)
+		}
+
+		if result.Error != "" {
+			fmt.Fprintf(out, "error: %s\n", result.Error)
+		}
+	}
+
+	return nil
+}
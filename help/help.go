@@ -2,13 +2,10 @@ package help
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
-	"github.com/wtfutil/wtf/modules/git"
-	"github.com/wtfutil/wtf/modules/github"
-	"github.com/wtfutil/wtf/modules/textfile"
-	"github.com/wtfutil/wtf/modules/todo"
-	"github.com/wtfutil/wtf/modules/todoist"
-	"github.com/wtfutil/wtf/modules/weatherservices/weather"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func Display(moduleName string) {
@@ -20,20 +17,43 @@ func Display(moduleName string) {
 }
 
 func helpFor(moduleName string) string {
-	switch moduleName {
-	case "git":
-		return git.HelpText
-	case "github":
-		return github.HelpText
-	case "textfile":
-		return textfile.HelpText
-	case "todo":
-		return todo.HelpText
-	case "todoist":
-		return todoist.HelpText
-	case "weather":
-		return weather.HelpText
-	default:
-		return fmt.Sprintf("\n  There is no help available for '%s'", moduleName)
+	module, ok := wtf.ModuleFor(moduleName)
+	if !ok {
+		return fmt.Sprintf(
+			"\n  There is no module named '%s'. Available modules are:\n\n  %s",
+			moduleName,
+			strings.Join(wtf.ModuleNames(), ", "),
+		)
 	}
+
+	helpText := module.HelpText
+	if len(module.Keys) > 0 {
+		helpText = wtf.KeysHelp(module.Name, module.HelpText, module.Keys)
+	}
+
+	if helpText == "" {
+		helpText = fmt.Sprintf("\n  There is no help available for '%s'\n", moduleName)
+	}
+
+	return helpText + settingsFor(module)
+}
+
+func settingsFor(module wtf.Module) string {
+	if len(module.Settings) == 0 {
+		return ""
+	}
+
+	settings := []string{}
+	for setting := range module.Settings {
+		settings = append(settings, setting)
+	}
+
+	sort.Strings(settings)
+
+	str := "\n Configuration settings:\n\n"
+	for _, setting := range settings {
+		str = str + fmt.Sprintf("   %-24s %s\n", setting, module.Settings[setting])
+	}
+
+	return str
 }
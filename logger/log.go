@@ -1,7 +1,7 @@
 package logger
 
 import (
-	"fmt"
+	"context"
 	//"io/ioutil"
 	"log"
 	"os"
@@ -20,9 +20,9 @@ type Widget struct {
 	filePath string
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Logs", "logger", true),
+		TextWidget: wtf.NewTextWidget(app, "Logs", configKey, true),
 
 		filePath: logFilePath(),
 	}
@@ -47,36 +47,38 @@ func Log(msg string) {
 	log.Println(msg)
 }
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if logFileMissing() {
-		return
+		return nil
 	}
 
 	widget.View.SetTitle(widget.Name())
 
 	logLines := widget.tailFile()
-	widget.View.SetText(widget.contentFrom(logLines))
+	widget.Render(widget.contentFrom(logLines))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(logLines []string) string {
-	str := ""
+func (widget *Widget) contentFrom(logLines []string) *wtf.Content {
+	content := wtf.NewContent()
+	section := content.AddSection("")
 
 	for _, line := range logLines {
 		chunks := strings.Split(line, " ")
 
 		if len(chunks) >= 4 {
-			str = str + fmt.Sprintf(
-				"[green]%s[white] [yellow]%s[white] %s\n",
-				chunks[0],
-				chunks[1],
-				strings.Join(chunks[3:], " "),
+			section.AddRow(
+				wtf.Cell{Text: chunks[0], Color: "green"},
+				wtf.Cell{Text: chunks[1], Color: "yellow"},
+				wtf.Text(strings.Join(chunks[3:], " ")),
 			)
 		}
 	}
 
-	return str
+	return content
 }
 
 func logFileMissing() bool {
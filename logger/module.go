@@ -0,0 +1,15 @@
+package logger
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "logger",
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+	})
+}
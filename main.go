@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -16,59 +17,25 @@ import (
 	"github.com/pkg/profile"
 	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
-	"github.com/wtfutil/wtf/bargraph"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/flags"
+	"github.com/wtfutil/wtf/headless"
 	"github.com/wtfutil/wtf/logger"
-	"github.com/wtfutil/wtf/modules/bamboohr"
-	"github.com/wtfutil/wtf/modules/circleci"
-	"github.com/wtfutil/wtf/modules/clocks"
-	"github.com/wtfutil/wtf/modules/cmdrunner"
-	"github.com/wtfutil/wtf/modules/cryptoexchanges/bittrex"
-	"github.com/wtfutil/wtf/modules/cryptoexchanges/blockfolio"
-	"github.com/wtfutil/wtf/modules/cryptoexchanges/cryptolive"
-	"github.com/wtfutil/wtf/modules/datadog"
-	"github.com/wtfutil/wtf/modules/gcal"
-	"github.com/wtfutil/wtf/modules/gerrit"
-	"github.com/wtfutil/wtf/modules/git"
-	"github.com/wtfutil/wtf/modules/github"
-	"github.com/wtfutil/wtf/modules/gitlab"
-	"github.com/wtfutil/wtf/modules/gitter"
-	"github.com/wtfutil/wtf/modules/gspreadsheets"
-	"github.com/wtfutil/wtf/modules/hackernews"
-	"github.com/wtfutil/wtf/modules/ipaddresses/ipapi"
-	"github.com/wtfutil/wtf/modules/ipaddresses/ipinfo"
-	"github.com/wtfutil/wtf/modules/jenkins"
-	"github.com/wtfutil/wtf/modules/jira"
-	"github.com/wtfutil/wtf/modules/mercurial"
-	"github.com/wtfutil/wtf/modules/nbascore"
-	"github.com/wtfutil/wtf/modules/newrelic"
-	"github.com/wtfutil/wtf/modules/opsgenie"
-	"github.com/wtfutil/wtf/modules/pagerduty"
-	"github.com/wtfutil/wtf/modules/power"
-	"github.com/wtfutil/wtf/modules/resourceusage"
-	"github.com/wtfutil/wtf/modules/rollbar"
-	"github.com/wtfutil/wtf/modules/security"
-	"github.com/wtfutil/wtf/modules/spotify"
-	"github.com/wtfutil/wtf/modules/spotifyweb"
-	"github.com/wtfutil/wtf/modules/status"
 	"github.com/wtfutil/wtf/modules/system"
-	"github.com/wtfutil/wtf/modules/textfile"
-	"github.com/wtfutil/wtf/modules/todo"
-	"github.com/wtfutil/wtf/modules/todoist"
-	"github.com/wtfutil/wtf/modules/travisci"
-	"github.com/wtfutil/wtf/modules/trello"
-	"github.com/wtfutil/wtf/modules/twitter"
 	"github.com/wtfutil/wtf/modules/unknown"
-	"github.com/wtfutil/wtf/modules/victorops"
-	"github.com/wtfutil/wtf/modules/weatherservices/prettyweather"
-	"github.com/wtfutil/wtf/modules/weatherservices/weather"
-	"github.com/wtfutil/wtf/modules/zendesk"
+	"github.com/wtfutil/wtf/server"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-var focusTracker wtf.FocusTracker
+var actions *wtf.ActionRunner
+var apiServer *server.Server
+var appKeys *wtf.Keymap
+var boards *wtf.Boards
+var palette *wtf.Palette
 var runningWidgets []wtf.Wtfable
+var scheduler *wtf.Scheduler
+var search *wtf.Search
+var zoom *wtf.Zoom
 
 // Config parses the config.yml file and makes available the settings within
 var Config *config.Config
@@ -79,6 +46,31 @@ var (
 	version = "dev"
 )
 
+// dashboard gives the HTTP API access to the running widgets and boards
+type dashboard struct {
+	app *tview.Application
+}
+
+func (dashboard *dashboard) Widgets() []wtf.Wtfable {
+	return runningWidgets
+}
+
+// Focus focuses the widget from the app's own goroutine, as tview requires. There's nothing
+// to focus when serving without the terminal dashboard
+func (dashboard *dashboard) Focus(key string) bool {
+	if dashboard.app == nil {
+		return false
+	}
+
+	focused := make(chan bool, 1)
+
+	dashboard.app.QueueUpdateDraw(func() {
+		focused <- boards.Focus(key)
+	})
+
+	return <-focused
+}
+
 /* -------------------- Functions -------------------- */
 
 func disableAllWidgets(widgets []wtf.Wtfable) {
@@ -87,26 +79,69 @@ func disableAllWidgets(widgets []wtf.Wtfable) {
 	}
 }
 
-func initializeFocusTracker(app *tview.Application, widgets []wtf.Wtfable) {
-	focusTracker = wtf.FocusTracker{
-		App:     app,
-		Idx:     -1,
-		Widgets: widgets,
+// buildBoards lays the widgets out on their boards and puts the first board on screen
+func buildBoards(app *tview.Application, pages *tview.Pages, widgets []wtf.Wtfable) {
+	if zoom != nil {
+		zoom.Out()
+	}
+
+	if palette != nil {
+		palette.Close()
+	}
+
+	if search != nil {
+		search.Close()
 	}
 
-	focusTracker.AssignHotKeys()
+	boards = wtf.NewBoards(app, widgets)
+	zoom = wtf.NewZoom(app, pages, "grid")
+	actions = wtf.NewActionRunner(app, pages)
+	appKeys = wtf.NewKeymap("wtf", "", wtf.AppKeys)
+	palette = wtf.NewPalette(app, pages, paletteHistoryPath())
+	search = wtf.NewSearch(app, pages)
+
+	logKeyProblems(widgets)
+
+	pages.AddPage("grid", boards.Root, true, true)
+}
+
+// jumpToResult focuses the widget the search result is from, and scrolls it to the line. In
+// widgets that wrap long lines, the line can end up a little further down than the top
+func jumpToResult(result wtf.SearchResult) {
+	boards.Focus(result.Widget.Key())
+	result.Widget.TextView().ScrollTo(result.Line, 0)
 }
 
 func keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch event.Key() {
-	case tcell.KeyCtrlR:
-		refreshAllWidgets(runningWidgets)
-	case tcell.KeyTab:
-		focusTracker.Next()
-	case tcell.KeyBacktab:
-		focusTracker.Prev()
-	case tcell.KeyEsc:
-		focusTracker.None()
+	// The palette and search have keys of their own, and every other key is typed into them
+	if palette.Visible() || search.Visible() {
+		return event
+	}
+
+	if zoom.Active() {
+		return zoomedKeyboardIntercept(event)
+	}
+
+	focusTracker := boards.Current().FocusTracker
+
+	if binding, ok := matchAppKey(event); ok {
+		runAppCommand(binding.Action)
+
+		if binding.PassThrough {
+			return event
+		}
+		return nil
+	}
+
+	// Alt-1 through Alt-9 jump straight to a board
+	if event.Modifiers()&tcell.ModAlt != 0 && event.Rune() >= '1' && event.Rune() <= '9' {
+		if boards.SwitchTo(int(event.Rune() - '1')) {
+			return nil
+		}
+	}
+
+	if runAction(focusTracker.Focused(), event) {
+		return nil
 	}
 
 	if focusTracker.FocusOn(string(event.Rune())) {
@@ -116,17 +151,290 @@ func keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+// zoomedKeyboardIntercept handles keys while a widget is shown full screen. Moving between
+// widgets and boards is turned off until Esc puts the widget back on its board
+func zoomedKeyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	binding, _ := matchAppKey(event)
+
+	switch binding.Action {
+	case "refreshAll":
+		refreshAllWidgets(runningWidgets)
+		return nil
+	case "retryCrashed":
+		retryCrashedWidgets(runningWidgets)
+		return nil
+	case "unfocus":
+		if zoom.HasFocus() {
+			zoom.Out()
+			return nil
+		}
+	}
+
+	if runAction(boards.Current().FocusTracker.Focused(), event) {
+		return nil
+	}
+
+	return event
+}
+
+// logKeyProblems logs the keys the config binds that aren't keys, or that are bound to more
+// than one command
+func logKeyProblems(widgets []wtf.Wtfable) {
+	for _, problem := range wtf.KeyProblems(Config, "", wtf.AppKeys) {
+		logger.Log(fmt.Sprintf("wtf.keys: %s", problem))
+	}
+
+	for _, widget := range widgets {
+		module, _ := wtf.ModuleFor(Config.UString(wtf.ConfigKeyFor(widget.Key(), "type"), widget.Key()))
+
+		for _, problem := range wtf.KeyProblems(Config, widget.Key(), module.Keys) {
+			logger.Log(fmt.Sprintf("%s: %s", wtf.ConfigKeyFor(widget.Key(), "keys"), problem))
+		}
+	}
+}
+
 func loadConfigFile(filePath string) {
 	Config = cfg.LoadConfigFile(filePath)
 	wtf.Config = Config
 }
 
+// matchAppKey returns the app command the key runs. Characters typed into a text field are
+// left for the field
+func matchAppKey(event *tcell.EventKey) (wtf.KeyBinding, bool) {
+	_, typing := boards.Current().FocusTracker.App.GetFocus().(*tview.InputField)
+	if typing && event.Key() == tcell.KeyRune {
+		return wtf.KeyBinding{}, false
+	}
+
+	return appKeys.Match(event)
+}
+
+// paletteCommands returns everything the command palette can do: the app's commands, focusing
+// each widget, and each widget's own commands and actions
+func paletteCommands() []wtf.PaletteCommand {
+	commands := []wtf.PaletteCommand{}
+
+	for _, binding := range appKeys.Bindings() {
+		if binding.Action == "palette" {
+			continue
+		}
+
+		action := binding.Action
+		commands = append(commands, wtf.PaletteCommand{
+			Name: wtf.ActionWords(action),
+			Help: binding.Help,
+			Run:  func() { runAppCommand(action) },
+		})
+	}
+
+	for _, widget := range runningWidgets {
+		widget := widget
+
+		if widget.Focusable() {
+			commands = append(commands, wtf.PaletteCommand{
+				Name: widget.Key(),
+				Help: fmt.Sprintf("Focus %s", widget.Name()),
+				Run:  func() { boards.Focus(widget.Key()) },
+			})
+		}
+
+		if commandable, ok := widget.(wtf.Commandable); ok {
+			for _, binding := range commandable.Commands().Bindings() {
+				action := binding.Action
+				commands = append(commands, wtf.PaletteCommand{
+					Name: fmt.Sprintf("%s: %s", widget.Key(), wtf.ActionWords(action)),
+					Help: binding.Help,
+					Run: func() {
+						boards.Focus(widget.Key())
+						commandable.Commands().Run(action)
+					},
+				})
+			}
+		}
+
+		for _, action := range wtf.ActionsFor(widget.Key()) {
+			key := action.Key
+
+			name := action.Name
+			if name == "" {
+				name = key
+			}
+
+			commands = append(commands, wtf.PaletteCommand{
+				Name: fmt.Sprintf("%s: %s", widget.Key(), name),
+				Help: "Run on the selected item",
+				Run: func() {
+					boards.Focus(widget.Key())
+					actions.Handle(widget, key)
+				},
+			})
+		}
+	}
+
+	return commands
+}
+
+// paletteHistoryPath returns the file the command palette keeps its history in, under the
+// config directory
+func paletteHistoryPath() string {
+	configDir, err := cfg.ConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, "palette_history")
+}
+
 func refreshAllWidgets(widgets []wtf.Wtfable) {
 	for _, widget := range widgets {
-		go widget.Refresh()
+		widget.RequestRefresh()
+	}
+}
+
+// runAppCommand runs one of the app's own commands, from its key or the command palette
+func runAppCommand(action string) {
+	focusTracker := boards.Current().FocusTracker
+
+	switch action {
+	case "focusNext":
+		focusTracker.Next()
+	case "focusPrev":
+		focusTracker.Prev()
+	case "unfocus":
+		focusTracker.None()
+	case "nextBoard":
+		boards.Next()
+	case "prevBoard":
+		boards.Prev()
+	case "refreshAll":
+		refreshAllWidgets(runningWidgets)
+	case "retryCrashed":
+		retryCrashedWidgets(runningWidgets)
+	case "zoom":
+		if widget := focusTracker.Focused(); widget != nil {
+			zoom.In(widget)
+		}
+	case "palette":
+		palette.Show(paletteCommands())
+	case "search":
+		search.Show(runningWidgets, jumpToResult)
+	}
+}
+
+// runAction runs the action the widget binds to the key, if it has one. Actions take the place
+// of any key the module itself uses
+func runAction(widget wtf.Wtfable, event *tcell.EventKey) bool {
+	if widget == nil || event.Key() != tcell.KeyRune || event.Modifiers()&tcell.ModAlt != 0 {
+		return false
+	}
+
+	return actions.Handle(widget, string(event.Rune()))
+}
+
+// networkChanged redraws the offline indicator, and once the network is back, retries the
+// network-bound widgets that crashed while it was down
+func networkChanged(app *tview.Application, offline bool) {
+	if offline {
+		logger.Log("the network is unreachable, pausing network-bound widgets")
+	} else {
+		logger.Log("the network is back, resuming network-bound widgets")
+
+		for _, widget := range runningWidgets {
+			if widget.Crashed() && wtf.NetworkBound(widget.Key()) {
+				widget.RequestRefresh()
+			}
+		}
+	}
+
+	app.QueueUpdateDraw(func() {})
+}
+
+func retryCrashedWidgets(widgets []wtf.Wtfable) {
+	for _, widget := range widgets {
+		if widget.Crashed() {
+			widget.RequestRefresh()
+		}
+	}
+}
+
+// scheduleWidgets stops the refreshes of any previously-running widgets and starts
+// refreshing the given ones
+func scheduleWidgets(widgets []wtf.Wtfable) {
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+
+	scheduler = wtf.NewScheduler(logger.Log, widgetCache())
+
+	for _, widget := range widgets {
+		scheduler.Schedule(widget)
 	}
 }
 
+// widgetCache returns the cache of widget content under the config directory
+func widgetCache() *wtf.Cache {
+	configDir, err := cfg.ConfigDir()
+	if err != nil {
+		return nil
+	}
+
+	return wtf.NewCache(filepath.Join(configDir, "cache"))
+}
+
+// serveAPI starts the HTTP API on the address at "wtf.server.listen", if there is one, after
+// stopping any previously-started server. Listening on loopback, i.e.: "127.0.0.1:7788", only
+// requests for localhost are served. Listening on every interface, i.e.: ":7788", or a network
+// address, requests for any host are, unless "wtf.server.allowedHosts" lists the only ones
+// other than localhost to serve
+func serveAPI(app *tview.Application) {
+	if apiServer != nil {
+		apiServer.Stop()
+		apiServer = nil
+	}
+
+	addr := Config.UString("wtf.server.listen", "")
+	if addr == "" {
+		return
+	}
+
+	apiServer = server.NewServer(addr, &dashboard{app: app})
+	if Config.UBool("wtf.server.web", false) {
+		apiServer.EnableWeb()
+	}
+
+	if err := apiServer.Start(); err != nil {
+		logger.Log(fmt.Sprintf("could not start the API server: %v", err))
+		apiServer = nil
+	}
+}
+
+// serve runs the modules without the terminal dashboard, for the HTTP API and, with --web, a
+// web page of the dashboard. The API listens on "wtf.server.listen", or on port 7788 of the
+// local machine if that isn't set. For a screen elsewhere on the network, listen on ":7788"
+// and, optionally, list the names it's reached by in "wtf.server.allowedHosts", as serveAPI
+// describes
+func serve(web bool) {
+	widgets := makeWidgets(nil, tview.NewPages())
+	scheduleWidgets(widgets)
+
+	addr := Config.UString("wtf.server.listen", "127.0.0.1:7788")
+
+	apiServer = server.NewServer(addr, &dashboard{})
+	if web {
+		apiServer.EnableWeb()
+	}
+
+	if err := apiServer.Start(); err != nil {
+		fmt.Printf("Could not listen on %s: %v\n", addr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Serving on http://%s\n", addr)
+
+	// Serve until interrupted
+	select {}
+}
+
 func setTerm() {
 	err := os.Setenv("TERM", Config.UString("wtf.term", os.Getenv("TERM")))
 	if err != nil {
@@ -134,29 +442,37 @@ func setTerm() {
 	}
 }
 
-func watchForConfigChanges(app *tview.Application, configFilePath string, grid *tview.Grid, pages *tview.Pages) {
+func watchForConfigChanges(app *tview.Application, configFilePath string, pages *tview.Pages) {
 	watch := watcher.New()
 	absPath, _ := wtf.ExpandHomeDir(configFilePath)
 
-	// Notify write events
-	watch.FilterOps(watcher.Write)
+	// Notify write events, and files being added to or removed from the mods.d directory
+	watch.FilterOps(watcher.Write, watcher.Create, watcher.Remove, watcher.Rename)
 
 	go func() {
 		for {
 			select {
 			case <-watch.Event:
-				// Disable all widgets to stop scheduler goroutines and rmeove widgets from memory.
+				// Disable all widgets and stop their refreshes to remove them from memory
 				disableAllWidgets(runningWidgets)
+				scheduler.Stop()
 
 				loadConfigFile(absPath)
 
+				// Credential references may now point somewhere else, or the secrets they
+				// point to may have been rotated
+				wtf.ForgetCredentials()
+
+				// The reloaded config may include files that weren't included before
+				watchConfigPaths(watch, absPath)
+
 				widgets := makeWidgets(app, pages)
 				validateWidgets(widgets)
 
-				initializeFocusTracker(app, widgets)
+				buildBoards(app, pages, widgets)
 
-				display := wtf.NewDisplay(widgets)
-				pages.AddPage("grid", display.Grid, true, true)
+				scheduleWidgets(widgets)
+				serveAPI(app)
 			case err := <-watch.Error:
 				log.Fatalln(err)
 			case <-watch.Closed:
@@ -165,10 +481,8 @@ func watchForConfigChanges(app *tview.Application, configFilePath string, grid *
 		}
 	}()
 
-	// Watch config file for changes.
-	if err := watch.Add(absPath); err != nil {
-		log.Fatalln(err)
-	}
+	// Watch config file, and every file it includes, for changes.
+	watchConfigPaths(watch, absPath)
 
 	// Start the watching process - it'll check for changes every 100ms.
 	if err := watch.Start(time.Millisecond * 100); err != nil {
@@ -176,106 +490,31 @@ func watchForConfigChanges(app *tview.Application, configFilePath string, grid *
 	}
 }
 
-func makeWidget(app *tview.Application, pages *tview.Pages, widgetName string) wtf.Wtfable {
-	var widget wtf.Wtfable
-
-	// Always in alphabetical order
-	switch widgetName {
-	case "bamboohr":
-		widget = bamboohr.NewWidget(app)
-	case "bargraph":
-		widget = bargraph.NewWidget(app)
-	case "bittrex":
-		widget = bittrex.NewWidget(app)
-	case "blockfolio":
-		widget = blockfolio.NewWidget(app)
-	case "circleci":
-		widget = circleci.NewWidget(app)
-	case "clocks":
-		widget = clocks.NewWidget(app)
-	case "cmdrunner":
-		widget = cmdrunner.NewWidget(app)
-	case "cryptolive":
-		widget = cryptolive.NewWidget(app)
-	case "datadog":
-		widget = datadog.NewWidget(app)
-	case "gcal":
-		widget = gcal.NewWidget(app)
-	case "gerrit":
-		widget = gerrit.NewWidget(app, pages)
-	case "git":
-		widget = git.NewWidget(app, pages)
-	case "github":
-		widget = github.NewWidget(app, pages)
-	case "gitlab":
-		widget = gitlab.NewWidget(app, pages)
-	case "gitter":
-		widget = gitter.NewWidget(app, pages)
-	case "gspreadsheets":
-		widget = gspreadsheets.NewWidget(app)
-	case "hackernews":
-		widget = hackernews.NewWidget(app, pages)
-	case "ipapi":
-		widget = ipapi.NewWidget(app)
-	case "ipinfo":
-		widget = ipinfo.NewWidget(app)
-	case "jenkins":
-		widget = jenkins.NewWidget(app, pages)
-	case "jira":
-		widget = jira.NewWidget(app, pages)
-	case "logger":
-		widget = logger.NewWidget(app)
-	case "mercurial":
-		widget = mercurial.NewWidget(app, pages)
-	case "nbascore":
-		widget = nbascore.NewWidget(app, pages)
-	case "newrelic":
-		widget = newrelic.NewWidget(app)
-	case "opsgenie":
-		widget = opsgenie.NewWidget(app)
-	case "pagerduty":
-		widget = pagerduty.NewWidget(app)
-	case "power":
-		widget = power.NewWidget(app)
-	case "prettyweather":
-		widget = prettyweather.NewWidget(app)
-	case "resourceusage":
-		widget = resourceusage.NewWidget(app)
-	case "security":
-		widget = security.NewWidget(app)
-	case "status":
-		widget = status.NewWidget(app)
-	case "system":
-		widget = system.NewWidget(app, date, version)
-	case "spotify":
-		widget = spotify.NewWidget(app, pages)
-	case "spotifyweb":
-		widget = spotifyweb.NewWidget(app, pages)
-	case "textfile":
-		widget = textfile.NewWidget(app, pages)
-	case "todo":
-		widget = todo.NewWidget(app, pages)
-	case "todoist":
-		widget = todoist.NewWidget(app, pages)
-	case "travisci":
-		widget = travisci.NewWidget(app, pages)
-	case "rollbar":
-		widget = rollbar.NewWidget(app, pages)
-	case "trello":
-		widget = trello.NewWidget(app)
-	case "twitter":
-		widget = twitter.NewWidget(app, pages)
-	case "victorops":
-		widget = victorops.NewWidget(app)
-	case "weather":
-		widget = weather.NewWidget(app, pages)
-	case "zendesk":
-		widget = zendesk.NewWidget(app)
-	default:
-		widget = unknown.NewWidget(app, widgetName)
-	}
-
-	return widget
+// watchConfigPaths adds the config file, and every file it includes, to the watcher
+func watchConfigPaths(watch *watcher.Watcher, configFilePath string) {
+	paths, err := cfg.ConfigPaths(configFilePath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	for _, path := range paths {
+		if err := watch.Add(path); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+func makeWidget(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+	// The module type defaults to the config key, so existing configs that only ever
+	// define one instance of each module keep working without a "type" attribute
+	widgetType := Config.UString(wtf.ConfigKeyFor(configKey, "type"), configKey)
+
+	module, ok := wtf.ModuleFor(widgetType)
+	if !ok {
+		return unknown.NewWidget(app, configKey)
+	}
+
+	return module.Factory(app, pages, configKey)
 }
 
 func makeWidgets(app *tview.Application, pages *tview.Pages) []wtf.Wtfable {
@@ -297,6 +536,54 @@ func makeWidgets(app *tview.Application, pages *tview.Pages) []wtf.Wtfable {
 	return widgets
 }
 
+// renderOnce refreshes the enabled widgets, or just the one named by --module, once and prints
+// what they display instead of starting the dashboard. It exits non-zero if any of them failed
+func renderOnce(moduleName, format string) {
+	widgets := makeWidgets(nil, tview.NewPages())
+
+	if moduleName != "" {
+		widgets = widgetsNamed(widgets, moduleName)
+
+		if len(widgets) == 0 {
+			fmt.Printf("There is no enabled module named '%s'\n", moduleName)
+			os.Exit(1)
+		}
+	}
+
+	ok, err := headless.Display(os.Stdout, widgets, format)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if !ok {
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+// validateConfigFile prints every problem found in the config file and exits, non-zero if
+// there were any
+func validateConfigFile(filePath string) {
+	errs, err := cfg.ValidateConfigFile(filePath)
+	if err != nil {
+		fmt.Printf("%s: %v\n", filePath, err)
+		os.Exit(1)
+	}
+
+	for _, validationErr := range errs {
+		fmt.Println(validationErr.Error())
+	}
+
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s is valid\n", filePath)
+	os.Exit(0)
+}
+
 // Check that all the loaded widgets are valid for display
 func validateWidgets(widgets []wtf.Wtfable) {
 	for _, widget := range widgets {
@@ -307,20 +594,55 @@ func validateWidgets(widgets []wtf.Wtfable) {
 	}
 }
 
+// widgetsNamed returns the widgets configured under the given key or, failing that, every
+// widget of the given module type
+func widgetsNamed(widgets []wtf.Wtfable, name string) []wtf.Wtfable {
+	named := []wtf.Wtfable{}
+
+	for _, widget := range widgets {
+		if widget.Key() == name {
+			return []wtf.Wtfable{widget}
+		}
+
+		if Config.UString(wtf.ConfigKeyFor(widget.Key(), "type"), widget.Key()) == name {
+			named = append(named, widget)
+		}
+	}
+
+	return named
+}
+
 /* -------------------- Main -------------------- */
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	wtf.NotificationLog = logger.Log
+
 	flags := flags.NewFlags()
 	flags.Parse()
 	flags.Display(version)
 
+	system.BuildDate = date
+	system.BuildVersion = version
+
+	if flags.Validate {
+		validateConfigFile(flags.ConfigFilePath())
+	}
+
 	cfg.MigrateOldConfig()
 	cfg.CreateConfigDir()
 	cfg.CreateConfigFile()
 	loadConfigFile(flags.ConfigFilePath())
 
+	if flags.Once {
+		renderOnce(flags.Module, flags.Format)
+	}
+
+	if flags.Serve {
+		serve(flags.Web)
+	}
+
 	if flags.Profile {
 		defer profile.Start(profile.MemProfile).Stop()
 	}
@@ -333,17 +655,24 @@ func main() {
 	widgets := makeWidgets(app, pages)
 	validateWidgets(widgets)
 
-	initializeFocusTracker(app, widgets)
+	buildBoards(app, pages, widgets)
 
-	display := wtf.NewDisplay(widgets)
-	pages.AddPage("grid", display.Grid, true, true)
+	scheduleWidgets(widgets)
+	serveAPI(app)
 
 	app.SetInputCapture(keyboardIntercept)
+	app.SetAfterDrawFunc(wtf.DrawOffline)
 
-	go watchForConfigChanges(app, flags.Config, display.Grid, pages)
+	wtf.Network.Changed = func(offline bool) {
+		networkChanged(app, offline)
+	}
+
+	go watchForConfigChanges(app, flags.Config, pages)
 
 	if err := app.SetRoot(pages, true).Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	scheduler.Stop()
 }
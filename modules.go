@@ -0,0 +1,62 @@
+package main
+
+// Every module compiled into wtf registers itself with the module registry from its init()
+// function, so it only needs to be imported here to become available in config.yml.
+//
+// Modules that live outside this repository can be compiled in without touching this file
+// by adding another file to this package that imports them behind a build tag, i.e.:
+//
+//     // +build mymodule
+//
+//     package main
+//
+//     import _ "github.com/someone/mymodule"
+//
+// and then building with 'go build -tags mymodule'.
+
+import (
+	_ "github.com/wtfutil/wtf/bargraph"
+	_ "github.com/wtfutil/wtf/logger"
+	_ "github.com/wtfutil/wtf/modules/bamboohr"
+	_ "github.com/wtfutil/wtf/modules/circleci"
+	_ "github.com/wtfutil/wtf/modules/clocks"
+	_ "github.com/wtfutil/wtf/modules/cmdrunner"
+	_ "github.com/wtfutil/wtf/modules/cryptoexchanges/bittrex"
+	_ "github.com/wtfutil/wtf/modules/cryptoexchanges/blockfolio"
+	_ "github.com/wtfutil/wtf/modules/cryptoexchanges/cryptolive"
+	_ "github.com/wtfutil/wtf/modules/datadog"
+	_ "github.com/wtfutil/wtf/modules/gcal"
+	_ "github.com/wtfutil/wtf/modules/gerrit"
+	_ "github.com/wtfutil/wtf/modules/git"
+	_ "github.com/wtfutil/wtf/modules/github"
+	_ "github.com/wtfutil/wtf/modules/gitlab"
+	_ "github.com/wtfutil/wtf/modules/gitter"
+	_ "github.com/wtfutil/wtf/modules/gspreadsheets"
+	_ "github.com/wtfutil/wtf/modules/hackernews"
+	_ "github.com/wtfutil/wtf/modules/ipaddresses/ipapi"
+	_ "github.com/wtfutil/wtf/modules/ipaddresses/ipinfo"
+	_ "github.com/wtfutil/wtf/modules/jenkins"
+	_ "github.com/wtfutil/wtf/modules/jira"
+	_ "github.com/wtfutil/wtf/modules/mercurial"
+	_ "github.com/wtfutil/wtf/modules/nbascore"
+	_ "github.com/wtfutil/wtf/modules/newrelic"
+	_ "github.com/wtfutil/wtf/modules/opsgenie"
+	_ "github.com/wtfutil/wtf/modules/pagerduty"
+	_ "github.com/wtfutil/wtf/modules/power"
+	_ "github.com/wtfutil/wtf/modules/resourceusage"
+	_ "github.com/wtfutil/wtf/modules/rollbar"
+	_ "github.com/wtfutil/wtf/modules/security"
+	_ "github.com/wtfutil/wtf/modules/spotify"
+	_ "github.com/wtfutil/wtf/modules/spotifyweb"
+	_ "github.com/wtfutil/wtf/modules/status"
+	_ "github.com/wtfutil/wtf/modules/textfile"
+	_ "github.com/wtfutil/wtf/modules/todo"
+	_ "github.com/wtfutil/wtf/modules/todoist"
+	_ "github.com/wtfutil/wtf/modules/travisci"
+	_ "github.com/wtfutil/wtf/modules/trello"
+	_ "github.com/wtfutil/wtf/modules/twitter"
+	_ "github.com/wtfutil/wtf/modules/victorops"
+	_ "github.com/wtfutil/wtf/modules/weatherservices/prettyweather"
+	_ "github.com/wtfutil/wtf/modules/weatherservices/weather"
+	_ "github.com/wtfutil/wtf/modules/zendesk"
+)
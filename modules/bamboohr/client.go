@@ -1,6 +1,7 @@
 package bamboohr
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -9,14 +10,16 @@ import (
 type Client struct {
 	apiBase   string
 	apiKey    string
+	configKey string
 	subdomain string
 }
 
 // NewClient creates and returns a new BambooHR client
-func NewClient(url string, apiKey string, subdomain string) *Client {
+func NewClient(configKey string, url string, apiKey string, subdomain string) *Client {
 	client := Client{
 		apiBase:   url,
 		apiKey:    apiKey,
+		configKey: configKey,
 		subdomain: subdomain,
 	}
 
@@ -26,15 +29,15 @@ func NewClient(url string, apiKey string, subdomain string) *Client {
 /* -------------------- Public Functions -------------------- */
 
 // Away returns a string representation of the people who are out of the office during the defined period
-func (client *Client) Away(itemType, startDate, endDate string) []Item {
-	calendar, err := client.away(startDate, endDate)
+func (client *Client) Away(ctx context.Context, itemType, startDate, endDate string) ([]Item, error) {
+	calendar, err := client.away(ctx, startDate, endDate)
 	if err != nil {
-		return []Item{}
+		return []Item{}, err
 	}
 
 	items := calendar.ItemsByType(itemType)
 
-	return items
+	return items, nil
 }
 
 /* -------------------- Private Functions -------------------- */
@@ -42,7 +45,7 @@ func (client *Client) Away(itemType, startDate, endDate string) []Item {
 // away is the private interface for retrieving structural data about who will be out of the office
 // This method does the actual communication with BambooHR and returns the raw Go
 // data structures used by the public interface
-func (client *Client) away(startDate, endDate string) (cal Calendar, err error) {
+func (client *Client) away(ctx context.Context, startDate, endDate string) (cal Calendar, err error) {
 	apiURL := fmt.Sprintf(
 		"%s/%s/v1/time_off/whos_out?start=%s&end=%s",
 		client.apiBase,
@@ -51,7 +54,7 @@ func (client *Client) away(startDate, endDate string) (cal Calendar, err error)
 		endDate,
 	)
 
-	data, err := Request(client.apiKey, apiURL)
+	data, err := Request(ctx, client.configKey, client.apiKey, apiURL)
 	if err != nil {
 		return cal, err
 	}
@@ -0,0 +1,20 @@
+package bamboohr
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "bamboohr",
+		Settings: wtf.ConfigSchema{
+			"apiKey":    wtf.StringSetting,
+			"subdomain": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -2,18 +2,26 @@ package bamboohr
 
 import (
 	"bytes"
+	"context"
 	"net/http"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
-func Request(apiKey string, apiURL string) ([]byte, error) {
+func Request(ctx context.Context, configKey string, apiKey string, apiURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	req.SetBasicAuth(apiKey, "x")
 
-	client := &http.Client{}
+	client, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
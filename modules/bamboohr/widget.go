@@ -1,6 +1,7 @@
 package bamboohr
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -12,9 +13,9 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "BambooHR", "bamboohr", false),
+		TextWidget: wtf.NewTextWidget(app, "BambooHR", configKey, false),
 	}
 
 	return &widget
@@ -22,52 +23,58 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	apiKey := wtf.Config.UString(
-		"wtf.mods.bamboohr.apiKey",
-		os.Getenv("WTF_BAMBOO_HR_TOKEN"),
-	)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	apiKey, err := wtf.Credential(widget.ConfigKey("apiKey"), "WTF_BAMBOO_HR_TOKEN")
+	if err != nil {
+		return err
+	}
 
 	subdomain := wtf.Config.UString(
-		"wtf.mods.bamboohr.subdomain",
+		widget.ConfigKey("subdomain"),
 		os.Getenv("WTF_BAMBOO_HR_SUBDOMAIN"),
 	)
 
-	client := NewClient("https://api.bamboohr.com/api/gateway.php", apiKey, subdomain)
-	todayItems := client.Away(
+	client := NewClient(widget.Key(), "https://api.bamboohr.com/api/gateway.php", apiKey, subdomain)
+	todayItems, err := client.Away(
+		ctx,
 		"timeOff",
 		wtf.Now().Format(wtf.DateFormat),
 		wtf.Now().Format(wtf.DateFormat),
 	)
+	if err != nil {
+		return err
+	}
 
 	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
 
-	widget.View.SetText(widget.contentFrom(todayItems))
+	widget.Render(widget.contentFrom(todayItems))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(items []Item) string {
+func (widget *Widget) contentFrom(items []Item) *wtf.Content {
+	content := wtf.NewContent()
+
 	if len(items) == 0 {
-		return fmt.Sprintf("\n\n\n\n\n\n\n\n%s", wtf.CenterText("[grey]no one[white]", 50))
+		content.AddSection("").AddRow(wtf.Cell{Text: "no one", Color: "grey"})
+		return content
 	}
 
-	str := ""
 	for _, item := range items {
-		str = str + widget.format(item)
+		widget.addItem(content.AddSection(""), item)
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) format(item Item) string {
-	var str string
+func (widget *Widget) addItem(section *wtf.Section, item Item) {
+	section.AddRow(wtf.Cell{Text: item.Name(), Color: "green"})
 
 	if item.IsOneDay() {
-		str = fmt.Sprintf(" [green]%s[white]\n %s\n\n", item.Name(), item.PrettyEnd())
+		section.AddRow(wtf.Text(item.PrettyEnd()))
 	} else {
-		str = fmt.Sprintf(" [green]%s[white]\n %s - %s\n\n", item.Name(), item.PrettyStart(), item.PrettyEnd())
+		section.AddRow(wtf.Text(fmt.Sprintf("%s - %s", item.PrettyStart(), item.PrettyEnd())))
 	}
-
-	return str
 }
@@ -2,35 +2,42 @@ package circleci
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
 type Client struct {
-	apiKey string
+	apiKey    string
+	configKey string
 }
 
-func NewClient(apiKey string) *Client {
+func NewClient(configKey string, apiKey string) *Client {
 	client := Client{
-		apiKey: apiKey,
+		apiKey:    apiKey,
+		configKey: configKey,
 	}
 
 	return &client
 }
 
-func (client *Client) BuildsFor() ([]*Build, error) {
+func (client *Client) BuildsFor(ctx context.Context) ([]*Build, error) {
 	builds := []*Build{}
 
-	resp, err := client.circleRequest("recent-builds")
+	resp, err := client.circleRequest(ctx, "recent-builds")
 	if err != nil {
 		return builds, err
 	}
 
-	parseJson(&builds, resp.Body)
+	if err := parseJson(&builds, resp.Body); err != nil {
+		return nil, err
+	}
 
 	return builds, nil
 }
@@ -41,20 +48,25 @@ var (
 	circleAPIURL = &url.URL{Scheme: "https", Host: "circleci.com", Path: "/api/v1/"}
 )
 
-func (client *Client) circleRequest(path string) (*http.Response, error) {
+func (client *Client) circleRequest(ctx context.Context, path string) (*http.Response, error) {
 	params := url.Values{}
 	params.Add("circle-token", client.apiKey)
 
 	url := circleAPIURL.ResolveReference(&url.URL{Path: path, RawQuery: params.Encode()})
 
 	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
+
+	httpClient, err := wtf.HTTPClient(client.configKey)
 	if err != nil {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -67,10 +79,10 @@ func (client *Client) circleRequest(path string) (*http.Response, error) {
 	return resp, nil
 }
 
-func parseJson(obj interface{}, text io.Reader) {
+func parseJson(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -79,7 +91,9 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
+
+	return nil
 }
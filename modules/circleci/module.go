@@ -0,0 +1,19 @@
+package circleci
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "circleci",
+		Settings: wtf.ConfigSchema{
+			"apiKey": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,8 +1,8 @@
 package circleci
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -15,15 +15,9 @@ type Widget struct {
 
 const apiEnvKey = "WTF_CIRCLE_API_KEY"
 
-func NewWidget(app *tview.Application) *Widget {
-	apiKey := wtf.Config.UString(
-		"wtf.mods.circleci.apiKey",
-		os.Getenv(apiEnvKey),
-	)
-
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "CircleCI", "circleci", false),
-		Client:     NewClient(apiKey),
+		TextWidget: wtf.NewTextWidget(app, "CircleCI", configKey, false),
 	}
 
 	return &widget
@@ -31,60 +25,74 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
-	builds, err := widget.Client.BuildsFor()
+	apiKey, err := wtf.Credential(widget.ConfigKey("apiKey"), apiEnvKey)
+	if err != nil {
+		return err
+	}
 
-	widget.View.SetTitle(fmt.Sprintf("%s - Builds", widget.Name()))
+	widget.Client = NewClient(widget.Key(), apiKey)
 
-	var content string
+	builds, err := widget.Client.BuildsFor(ctx)
 	if err != nil {
-		widget.View.SetWrap(true)
-		content = err.Error()
-	} else {
-		widget.View.SetWrap(false)
-		content = widget.contentFrom(builds)
+		return err
+	}
+
+	widget.View.SetTitle(fmt.Sprintf("%s - Builds", widget.Name()))
+
+	widget.View.SetWrap(false)
+	widget.Render(widget.contentFrom(builds))
+
+	notifications := []wtf.Notification{}
+	for _, build := range builds {
+		if build.Status == "failed" {
+			notifications = append(notifications, wtf.Notification{
+				ID:      fmt.Sprintf("%s-%d", build.Reponame, build.BuildNum),
+				Title:   fmt.Sprintf("CircleCI build %s-%d failed", build.Reponame, build.BuildNum),
+				Message: fmt.Sprintf("%s, by %s", build.Branch, build.AuthorName),
+			})
+		}
 	}
+	widget.NotifyChanges(notifications)
 
-	widget.View.SetText(content)
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(builds []*Build) string {
-	var str string
+func (widget *Widget) contentFrom(builds []*Build) *wtf.Content {
+	content := wtf.NewContent()
+	section := content.AddSection("")
+
 	for idx, build := range builds {
 		if idx > 10 {
-			return str
+			break
 		}
 
-		str = str + fmt.Sprintf(
-			"[%s] %s-%d (%s) [white]%s\n",
-			buildColor(build),
-			build.Reponame,
-			build.BuildNum,
-			build.Branch,
-			build.AuthorName,
+		section.AddRow(
+			wtf.Cell{Text: fmt.Sprintf("%s-%d (%s)", build.Reponame, build.BuildNum, build.Branch), Status: buildStatus(build)},
+			wtf.Text(build.AuthorName),
 		)
 	}
 
-	return str
+	return content
 }
 
-func buildColor(build *Build) string {
+func buildStatus(build *Build) wtf.Status {
 	switch build.Status {
 	case "failed":
-		return "red"
+		return wtf.StatusError
 	case "running":
-		return "yellow"
+		return wtf.StatusWarning
 	case "success":
-		return "green"
+		return wtf.StatusOK
 	case "fixed":
-		return "green"
+		return wtf.StatusOK
 	default:
-		return "white"
+		return wtf.StatusNone
 	}
 }
@@ -1,27 +1,29 @@
 package clocks
 
 import (
-	"fmt"
-
+	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
 func (widget *Widget) display(clocks []Clock, dateFormat string, timeFormat string) {
+	content := wtf.NewContent()
+	section := content.AddSection("")
+
 	if len(clocks) == 0 {
-		widget.View.SetText(fmt.Sprintf("\n%s", " no timezone data available"))
+		section.AddRow(wtf.Text("no timezone data available"))
+		widget.Render(content)
 		return
 	}
 
-	str := ""
 	for idx, clock := range clocks {
-		str = str + fmt.Sprintf(
-			" [%s]%-12s %-10s %7s[white]\n",
-			wtf.RowColor("clocks", idx),
-			clock.Label,
-			clock.Time(timeFormat),
-			clock.Date(dateFormat),
+		color := wtf.RowColor(widget.Key(), idx)
+
+		section.AddRow(
+			wtf.Cell{Text: clock.Label, Color: color},
+			wtf.Cell{Text: clock.Time(timeFormat), Color: color},
+			wtf.Cell{Text: clock.Date(dateFormat), Align: tview.AlignRight, Color: color},
 		)
 	}
 
-	widget.View.SetText(str)
+	widget.Render(content)
 }
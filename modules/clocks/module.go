@@ -0,0 +1,21 @@
+package clocks
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "clocks",
+		Settings: wtf.ConfigSchema{
+			"dateFormat": wtf.StringSetting,
+			"locations":  wtf.MapSetting,
+			"sort":       wtf.StringSetting,
+			"timeFormat": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package clocks
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -13,26 +14,30 @@ type Widget struct {
 
 	clockColl  ClockCollection
 	dateFormat string
+	sortOrder  string
 	timeFormat string
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "World Clocks", "clocks", false),
+		TextWidget: wtf.NewTextWidget(app, "World Clocks", configKey, false),
 	}
 
-	widget.clockColl = widget.buildClockCollection(wtf.Config.UMap("wtf.mods.clocks.locations"))
+	widget.clockColl = widget.buildClockCollection(wtf.Config.UMap(widget.ConfigKey("locations")))
 
-	widget.dateFormat = wtf.Config.UString("wtf.mods.clocks.dateFormat", wtf.SimpleDateFormat)
-	widget.timeFormat = wtf.Config.UString("wtf.mods.clocks.timeFormat", wtf.SimpleTimeFormat)
+	widget.dateFormat = wtf.Config.UString(widget.ConfigKey("dateFormat"), wtf.SimpleDateFormat)
+	widget.timeFormat = wtf.Config.UString(widget.ConfigKey("timeFormat"), wtf.SimpleTimeFormat)
+	widget.sortOrder = wtf.Config.UString(widget.ConfigKey("sort"), "alphabetical")
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	widget.display(widget.clockColl.Sorted(), widget.dateFormat, widget.timeFormat)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	widget.display(widget.clockColl.Sorted(widget.sortOrder), widget.dateFormat, widget.timeFormat)
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
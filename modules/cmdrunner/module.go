@@ -0,0 +1,19 @@
+package cmdrunner
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "cmdrunner",
+		Settings: wtf.ConfigSchema{
+			"args": wtf.ListSetting,
+			"cmd":  wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+	})
+}
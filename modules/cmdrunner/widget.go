@@ -1,6 +1,7 @@
 package cmdrunner
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -17,26 +18,28 @@ type Widget struct {
 	result string
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "CmdRunner", "cmdrunner", false),
-
-		args: wtf.ToStrs(wtf.Config.UList("wtf.mods.cmdrunner.args")),
-		cmd:  wtf.Config.UString("wtf.mods.cmdrunner.cmd"),
+		TextWidget: wtf.NewTextWidget(app, "CmdRunner", configKey, false),
 	}
 
+	widget.args = wtf.ToStrs(wtf.Config.UList(widget.ConfigKey("args")))
+	widget.cmd = wtf.Config.UString(widget.ConfigKey("cmd"))
+
 	widget.View.SetWrap(true)
 
 	return &widget
 }
 
-func (widget *Widget) Refresh() {
-	widget.execute()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	widget.execute(ctx)
 
-	title := tview.TranslateANSI(wtf.Config.UString("wtf.mods.cmdrunner.title", widget.String()))
+	title := tview.TranslateANSI(wtf.Config.UString(widget.ConfigKey("title"), widget.String()))
 	widget.View.SetTitle(title)
 
-	widget.View.SetText(widget.result)
+	widget.SetContent(widget.result)
+
+	return nil
 }
 
 func (widget *Widget) String() string {
@@ -49,7 +52,7 @@ func (widget *Widget) String() string {
 	return fmt.Sprintf(" %s ", widget.cmd)
 }
 
-func (widget *Widget) execute() {
-	cmd := exec.Command(widget.cmd, widget.args...)
+func (widget *Widget) execute(ctx context.Context) {
+	cmd := exec.CommandContext(ctx, widget.cmd, widget.args...)
 	widget.result = tview.TranslateANSI(wtf.ExecuteCommand(cmd))
 }
@@ -1,64 +1,45 @@
 package bittrex
 
 import (
-	"bytes"
-	"fmt"
-	"text/template"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func (widget *Widget) display() {
-	if ok == false {
-		widget.View.SetText(errorText)
-		return
-	}
-
-	widget.View.SetText(summaryText(&widget.summaryList, &widget.TextColors))
+	widget.Render(summaryContent(&widget.summaryList, &widget.TextColors))
 }
 
-func summaryText(list *summaryList, colors *TextColors) string {
-	str := ""
+func summaryContent(list *summaryList, colors *TextColors) *wtf.Content {
+	content := wtf.NewContent()
 
 	for _, baseCurrency := range list.items {
-		str += fmt.Sprintf(" [%s]%s[%s] (%s)\n\n", colors.base.displayName, baseCurrency.displayName, colors.base.name, baseCurrency.name)
-
-		resultTemplate := template.New("bittrex")
+		content.AddSection("").AddRow(
+			wtf.Cell{Text: baseCurrency.displayName, Color: colors.base.displayName},
+			wtf.Cell{Text: "(" + baseCurrency.name + ")", Color: colors.base.name},
+		)
 
 		for _, marketCurrency := range baseCurrency.markets {
-			writer := new(bytes.Buffer)
-
-			strTemplate, _ := resultTemplate.Parse(
-				"  [{{.nameColor}}]{{.mName}}\n" +
-					formatableText("High", "High") +
-					formatableText("Low", "Low") +
-					formatableText("Last", "Last") +
-					formatableText("Volume", "Volume") +
-					"\n" +
-					formatableText("Open Buy", "OpenBuyOrders") +
-					formatableText("Open Sell", "OpenSellOrders"),
-			)
-
-			strTemplate.Execute(writer, map[string]string{
-				"nameColor":      colors.market.name,
-				"fieldColor":     colors.market.field,
-				"valueColor":     colors.market.value,
-				"mName":          marketCurrency.name,
-				"High":           marketCurrency.High,
-				"Low":            marketCurrency.Low,
-				"Last":           marketCurrency.Last,
-				"Volume":         marketCurrency.Volume,
-				"OpenBuyOrders":  marketCurrency.OpenBuyOrders,
-				"OpenSellOrders": marketCurrency.OpenSellOrders,
-			})
-
-			str += writer.String() + "\n"
+			prices := content.AddSection("")
+			prices.AddRow(wtf.Cell{Text: marketCurrency.name, Color: colors.market.name})
+			prices.AddRow(field("High", colors), value(marketCurrency.High, colors))
+			prices.AddRow(field("Low", colors), value(marketCurrency.Low, colors))
+			prices.AddRow(field("Last", colors), value(marketCurrency.Last, colors))
+			prices.AddRow(field("Volume", colors), value(marketCurrency.Volume, colors))
+
+			orders := content.AddSection("")
+			orders.AddRow(field("Open Buy", colors), value(marketCurrency.OpenBuyOrders, colors))
+			orders.AddRow(field("Open Sell", colors), value(marketCurrency.OpenSellOrders, colors))
 		}
-
 	}
 
-	return str
+	return content
+}
 
+// field returns a market field's label, right-aligned so that the values beside it line up
+func field(label string, colors *TextColors) wtf.Cell {
+	return wtf.Cell{Text: label + ":", Align: tview.AlignRight, Color: colors.market.field}
 }
 
-func formatableText(key, value string) string {
-	return fmt.Sprintf("[{{.fieldColor}}]%12s: [{{.valueColor}}]{{.%s}}\n", key, value)
+func value(text string, colors *TextColors) wtf.Cell {
+	return wtf.Cell{Text: text, Color: colors.market.value}
 }
@@ -0,0 +1,24 @@
+package bittrex
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "bittrex",
+		Settings: wtf.ConfigSchema{
+			"colors.base.displayName": wtf.StringSetting,
+			"colors.base.name":        wtf.StringSetting,
+			"colors.market.field":     wtf.StringSetting,
+			"colors.market.name":      wtf.StringSetting,
+			"colors.market.value":     wtf.StringSetting,
+			"summary":                 wtf.MapSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
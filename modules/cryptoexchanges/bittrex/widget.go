@@ -1,9 +1,10 @@
 package bittrex
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
 
 	"net/http"
 
@@ -36,9 +37,9 @@ type Widget struct {
 }
 
 // NewWidget Make new instance of widget
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget:  wtf.NewTextWidget(app, "Bittrex", "bittrex", false),
+		TextWidget:  wtf.NewTextWidget(app, "Bittrex", configKey, false),
 		summaryList: summaryList{},
 	}
 
@@ -52,26 +53,26 @@ func NewWidget(app *tview.Application) *Widget {
 }
 
 func (widget *Widget) config() {
-	widget.TextColors.base.name = wtf.Config.UString("wtf.mods.bittrex.colors.base.name", "red")
-	widget.TextColors.base.displayName = wtf.Config.UString("wtf.mods.bittrex.colors.base.displayName", "grey")
-	widget.TextColors.market.name = wtf.Config.UString("wtf.mods.bittrex.colors.market.name", "red")
-	widget.TextColors.market.field = wtf.Config.UString("wtf.mods.bittrex.colors.market.field", "coral")
-	widget.TextColors.market.value = wtf.Config.UString("wtf.mods.bittrex.colors.market.value", "white")
+	widget.TextColors.base.name = wtf.Config.UString(widget.ConfigKey("colors.base.name"), "red")
+	widget.TextColors.base.displayName = wtf.Config.UString(widget.ConfigKey("colors.base.displayName"), "grey")
+	widget.TextColors.market.name = wtf.Config.UString(widget.ConfigKey("colors.market.name"), "red")
+	widget.TextColors.market.field = wtf.Config.UString(widget.ConfigKey("colors.market.field"), "coral")
+	widget.TextColors.market.value = wtf.Config.UString(widget.ConfigKey("colors.market.value"), "white")
 }
 
 func (widget *Widget) setSummaryList() {
-	sCurrencies, _ := wtf.Config.Map("wtf.mods.bittrex.summary")
+	sCurrencies, _ := wtf.Config.Map(widget.ConfigKey("summary"))
 	for baseCurrencyName := range sCurrencies {
-		displayName, _ := wtf.Config.String("wtf.mods.bittrex.summary." + baseCurrencyName + ".displayName")
-		mCurrencyList := makeSummaryMarketList(baseCurrencyName)
+		displayName, _ := wtf.Config.String(widget.ConfigKey("summary." + baseCurrencyName + ".displayName"))
+		mCurrencyList := widget.makeSummaryMarketList(baseCurrencyName)
 		widget.summaryList.addSummaryItem(baseCurrencyName, displayName, mCurrencyList)
 	}
 }
 
-func makeSummaryMarketList(currencyName string) []*mCurrency {
+func (widget *Widget) makeSummaryMarketList(currencyName string) []*mCurrency {
 	mCurrencyList := []*mCurrency{}
 
-	configMarketList, _ := wtf.Config.List("wtf.mods.bittrex.summary." + currencyName + ".market")
+	configMarketList, _ := wtf.Config.List(widget.ConfigKey("summary." + currencyName + ".market"))
 	for _, mCurrencyName := range configMarketList {
 		mCurrencyList = append(mCurrencyList, makeMarketCurrency(mCurrencyName.(string)))
 	}
@@ -96,26 +97,36 @@ func makeMarketCurrency(name string) *mCurrency {
 /* -------------------- Exported Functions -------------------- */
 
 // Refresh & update after interval time
-func (widget *Widget) Refresh() {
-	widget.updateSummary()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	widget.updateSummary(ctx)
+
+	if !ok {
+		return errors.New(errorText)
+	}
+
 	widget.display()
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) updateSummary() {
+func (widget *Widget) updateSummary(ctx context.Context) {
 	// In case if anything bad happened!
 	defer func() {
 		recover()
 	}()
 
-	client := &http.Client{
-		Timeout: time.Duration(5 * time.Second),
+	client, err := wtf.HTTPClient(widget.Key())
+	if err != nil {
+		ok = false
+		errorText = err.Error()
+		return
 	}
 
 	for _, baseCurrency := range widget.summaryList.items {
 		for _, mCurrency := range baseCurrency.markets {
-			request := makeRequest(baseCurrency.name, mCurrency.name)
+			request := makeRequest(ctx, baseCurrency.name, mCurrency.name)
 			response, err := client.Do(request)
 
 			if err != nil {
@@ -161,9 +172,9 @@ func (widget *Widget) updateSummary() {
 	widget.display()
 }
 
-func makeRequest(baseName, marketName string) *http.Request {
+func makeRequest(ctx context.Context, baseName, marketName string) *http.Request {
 	url := fmt.Sprintf("%s?market=%s-%s", baseURL, baseName, marketName)
 	request, _ := http.NewRequest("GET", url, nil)
 
-	return request
+	return request.WithContext(ctx)
 }
@@ -0,0 +1,23 @@
+package blockfolio
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "blockfolio",
+		Settings: wtf.ConfigSchema{
+			"colors.drop":     wtf.StringSetting,
+			"colors.grows":    wtf.StringSetting,
+			"colors.name":     wtf.StringSetting,
+			"device_token":    wtf.StringSetting,
+			"displayHoldings": wtf.BoolSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,6 +1,7 @@
 package blockfolio
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -17,35 +18,40 @@ type Widget struct {
 	device_token string
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget:   wtf.NewTextWidget(app, "Blockfolio", "blockfolio", false),
-		device_token: wtf.Config.UString("wtf.mods.blockfolio.device_token"),
+		TextWidget: wtf.NewTextWidget(app, "Blockfolio", configKey, false),
 	}
 
+	widget.device_token = wtf.Config.UString(widget.ConfigKey("device_token"))
+
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	widget.View.SetTitle(" Blockfolio ")
 
-	positions, err := Fetch(widget.device_token)
+	positions, err := Fetch(ctx, widget.Key(), widget.device_token)
 	if err != nil {
-		return
+		return err
 	}
 
-	widget.View.SetText(contentFrom(positions))
+	widget.Render(widget.contentFrom(positions))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
-func contentFrom(positions *AllPositionsResponse) string {
-	res := ""
-	colorName := wtf.Config.UString("wtf.mods.blockfolio.colors.name")
-	colorGrows := wtf.Config.UString("wtf.mods.blockfolio.colors.grows")
-	colorDrop := wtf.Config.UString("wtf.mods.blockfolio.colors.drop")
-	displayHoldings := wtf.Config.UBool("wtf.mods.blockfolio.displayHoldings")
+func (widget *Widget) contentFrom(positions *AllPositionsResponse) *wtf.Content {
+	content := wtf.NewContent()
+	section := content.AddSection("")
+
+	colorName := wtf.Config.UString(widget.ConfigKey("colors.name"))
+	colorGrows := wtf.Config.UString(widget.ConfigKey("colors.grows"))
+	colorDrop := wtf.Config.UString(widget.ConfigKey("colors.drop"))
+	displayHoldings := wtf.Config.UBool(widget.ConfigKey("displayHoldings"))
 	var totalFiat float32
 	totalFiat = 0.0
 	for i := 0; i < len(positions.PositionList); i++ {
@@ -54,17 +60,24 @@ func contentFrom(positions *AllPositionsResponse) string {
 			colorForChange = colorDrop
 		}
 		totalFiat += positions.PositionList[i].HoldingValueFiat
+
+		change := fmt.Sprintf("(%.2f%%)", positions.PositionList[i].TwentyFourHourPercentChangeFiat)
 		if displayHoldings {
-			res = res + fmt.Sprintf("[%s]%-6s - %5.2f ([%s]%.3fk [%s]%.2f%s)\n", colorName, positions.PositionList[i].Coin, positions.PositionList[i].Quantity, colorForChange, positions.PositionList[i].HoldingValueFiat/1000, colorForChange, positions.PositionList[i].TwentyFourHourPercentChangeFiat, "%")
-		} else {
-			res = res + fmt.Sprintf("[%s]%-6s - %5.2f ([%s]%.2f%s)\n", colorName, positions.PositionList[i].Coin, positions.PositionList[i].Quantity, colorForChange, positions.PositionList[i].TwentyFourHourPercentChangeFiat, "%")
+			change = fmt.Sprintf("(%.3fk %.2f%%)", positions.PositionList[i].HoldingValueFiat/1000, positions.PositionList[i].TwentyFourHourPercentChangeFiat)
 		}
+
+		section.AddRow(
+			wtf.Cell{Text: positions.PositionList[i].Coin, Color: colorName},
+			wtf.Cell{Text: "-", Color: colorName},
+			wtf.Cell{Text: fmt.Sprintf("%.2f", positions.PositionList[i].Quantity), Align: tview.AlignRight, Color: colorName},
+			wtf.Cell{Text: change, Color: colorForChange},
+		)
 	}
 	if displayHoldings {
-		res = res + fmt.Sprintf("\n[%s]Total value: $%.3fk", "green", totalFiat/1000)
+		content.AddSection("").AddRow(wtf.Text(fmt.Sprintf("Total value: $%.3fk", totalFiat/1000))).Status = wtf.StatusOK
 	}
 
-	return res
+	return content
 }
 
 //always the same
@@ -82,13 +95,18 @@ type AllPositionsResponse struct {
 	PositionList []Position `json:"positionList"`
 }
 
-func MakeApiRequest(token string, method string) ([]byte, error) {
-	client := &http.Client{}
+func MakeApiRequest(ctx context.Context, configKey string, token string, method string) ([]byte, error) {
+	client, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	url := "https://api-v0.blockfolio.com/rest/" + method + "/" + token + "?use_alias=true&fiat_currency=USD"
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Add("magic", magic)
 	resp, err := client.Do(req)
 	if err != nil {
@@ -102,11 +120,15 @@ func MakeApiRequest(token string, method string) ([]byte, error) {
 	return body, err
 }
 
-func GetAllPositions(token string) (*AllPositionsResponse, error) {
-	jsn, _ := MakeApiRequest(token, "get_all_positions")
+func GetAllPositions(ctx context.Context, configKey string, token string) (*AllPositionsResponse, error) {
+	jsn, err := MakeApiRequest(ctx, configKey, token, "get_all_positions")
+	if err != nil {
+		return nil, err
+	}
+
 	var parsed AllPositionsResponse
 
-	err := json.Unmarshal(jsn, &parsed)
+	err = json.Unmarshal(jsn, &parsed)
 	if err != nil {
 		log.Fatalf("Failed to parse json %v", err)
 		return nil, err
@@ -114,6 +136,6 @@ func GetAllPositions(token string) (*AllPositionsResponse, error) {
 	return &parsed, err
 }
 
-func Fetch(token string) (*AllPositionsResponse, error) {
-	return GetAllPositions(token)
+func Fetch(ctx context.Context, configKey string, token string) (*AllPositionsResponse, error) {
+	return GetAllPositions(ctx, configKey, token)
 }
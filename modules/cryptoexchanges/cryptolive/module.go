@@ -0,0 +1,29 @@
+package cryptolive
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "cryptolive",
+		Settings: wtf.ConfigSchema{
+			"colors.from.displayName":     wtf.StringSetting,
+			"colors.from.name":            wtf.StringSetting,
+			"colors.to.name":              wtf.StringSetting,
+			"colors.to.price":             wtf.StringSetting,
+			"colors.top.from.displayName": wtf.StringSetting,
+			"colors.top.from.name":        wtf.StringSetting,
+			"colors.top.to.field":         wtf.StringSetting,
+			"colors.top.to.name":          wtf.StringSetting,
+			"colors.top.to.value":         wtf.StringSetting,
+			"currencies":                  wtf.MapSetting,
+			"top":                         wtf.MapSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,12 +1,13 @@
 package price
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sync"
-	"time"
 
+	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -17,14 +18,18 @@ var ok = true
 type Widget struct {
 	*list
 
-	Result string
+	configKey string
+
+	Result *wtf.Content
 
 	RefreshInterval int
 }
 
 // NewWidget Make new instance of widget
-func NewWidget() *Widget {
-	widget := Widget{}
+func NewWidget(configKey string) *Widget {
+	widget := Widget{
+		configKey: configKey,
+	}
 
 	widget.setList()
 
@@ -32,13 +37,13 @@ func NewWidget() *Widget {
 }
 
 func (widget *Widget) setList() {
-	currenciesMap, _ := wtf.Config.Map("wtf.mods.cryptolive.currencies")
+	currenciesMap, _ := wtf.Config.Map(wtf.ConfigKeyFor(widget.configKey, "currencies"))
 
 	widget.list = &list{}
 
 	for currency := range currenciesMap {
-		displayName, _ := wtf.Config.String("wtf.mods.cryptolive.currencies." + currency + ".displayName")
-		toList := getToList(currency)
+		displayName, _ := wtf.Config.String(wtf.ConfigKeyFor(widget.configKey, "currencies."+currency+".displayName"))
+		toList := widget.getToList(currency)
 		widget.list.addItem(currency, displayName, toList)
 	}
 
@@ -47,44 +52,51 @@ func (widget *Widget) setList() {
 /* -------------------- Exported Functions -------------------- */
 
 // Refresh & update after interval time
-func (widget *Widget) Refresh(wg *sync.WaitGroup) {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if len(widget.list.items) == 0 {
-		return
+		return nil
 	}
 
-	widget.updateCurrencies()
+	widget.updateCurrencies(ctx)
 
 	if !ok {
-		widget.Result = fmt.Sprint("Please check your internet connection!")
-		return
+		return errors.New("Please check your internet connection!")
 	}
+
 	widget.display()
-	wg.Done()
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
-	str := ""
+	content := wtf.NewContent()
 	var (
-		fromNameColor        = wtf.Config.UString("wtf.mods.cryptolive.colors.from.name", "coral")
-		fromDisplayNameColor = wtf.Config.UString("wtf.mods.cryptolive.colors.from.displayName", "grey")
-		toNameColor          = wtf.Config.UString("wtf.mods.cryptolive.colors.to.name", "white")
-		toPriceColor         = wtf.Config.UString("wtf.mods.cryptolive.colors.to.price", "green")
+		fromNameColor        = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.from.name"), "coral")
+		fromDisplayNameColor = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.from.displayName"), "grey")
+		toNameColor          = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.to.name"), "white")
+		toPriceColor         = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.to.price"), "green")
 	)
 	for _, item := range widget.list.items {
-		str += fmt.Sprintf(" [%s]%s[%s] (%s)\n", fromNameColor, item.displayName, fromDisplayNameColor, item.name)
+		section := content.AddSection("")
+		section.AddRow(
+			wtf.Cell{Text: item.displayName, Color: fromNameColor},
+			wtf.Cell{Text: "(" + item.name + ")", Color: fromDisplayNameColor},
+		)
 		for _, toItem := range item.to {
-			str += fmt.Sprintf("\t[%s]%s: [%s]%f\n", toNameColor, toItem.name, toPriceColor, toItem.price)
+			section.AddRow(
+				wtf.Cell{Text: toItem.name + ":", Align: tview.AlignRight, Color: toNameColor},
+				wtf.Cell{Text: fmt.Sprintf("%f", toItem.price), Color: toPriceColor},
+			)
 		}
-		str += "\n"
 	}
 
-	widget.Result = fmt.Sprintf("\n%s", str)
+	widget.Result = content
 }
 
-func getToList(fromName string) []*toCurrency {
-	toNames, _ := wtf.Config.List("wtf.mods.cryptolive.currencies." + fromName + ".to")
+func (widget *Widget) getToList(fromName string) []*toCurrency {
+	toNames, _ := wtf.Config.List(wtf.ConfigKeyFor(widget.configKey, "currencies."+fromName+".to"))
 
 	var toList []*toCurrency
 
@@ -98,22 +110,21 @@ func getToList(fromName string) []*toCurrency {
 	return toList
 }
 
-func (widget *Widget) updateCurrencies() {
+func (widget *Widget) updateCurrencies(ctx context.Context) {
 	defer func() {
 		recover()
 	}()
-	for _, fromCurrency := range widget.list.items {
 
-		var (
-			client       http.Client
-			jsonResponse cResponse
-		)
+	client, err := wtf.HTTPClient(widget.configKey)
+	if err != nil {
+		ok = false
+		return
+	}
 
-		client = http.Client{
-			Timeout: time.Duration(5 * time.Second),
-		}
+	for _, fromCurrency := range widget.list.items {
+		var jsonResponse cResponse
 
-		request := makeRequest(fromCurrency)
+		request := makeRequest(ctx, fromCurrency)
 		response, err := client.Do(request)
 
 		if err != nil {
@@ -131,7 +142,7 @@ func (widget *Widget) updateCurrencies() {
 
 }
 
-func makeRequest(currency *fromCurrency) *http.Request {
+func makeRequest(ctx context.Context, currency *fromCurrency) *http.Request {
 	fsym := currency.name
 	tsyms := ""
 	for _, to := range currency.to {
@@ -144,7 +155,7 @@ func makeRequest(currency *fromCurrency) *http.Request {
 	if err != nil {
 	}
 
-	return request
+	return request.WithContext(ctx)
 }
 
 func setPrices(response *cResponse, currencry *fromCurrency) {
@@ -1,55 +1,45 @@
 package toplist
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
 
 func (widget *Widget) display() {
-	str := ""
+	content := wtf.NewContent()
 	for _, fromCurrency := range widget.list.items {
-		str += fmt.Sprintf(
-			"[%s]%s [%s](%s)\n",
-			widget.colors.from.displayName,
-			fromCurrency.displayName,
-			widget.colors.from.name,
-			fromCurrency.name,
+		content.AddSection("").AddRow(
+			wtf.Cell{Text: fromCurrency.displayName, Color: widget.colors.from.displayName},
+			wtf.Cell{Text: "(" + fromCurrency.name + ")", Color: widget.colors.from.name},
 		)
-		str += makeToListText(fromCurrency.to, widget.colors)
+		addToList(content, fromCurrency.to, widget.colors)
 	}
 
-	widget.Result = str
+	widget.Result = content
 }
 
-func makeToListText(toList []*tCurrency, colors textColors) string {
-	str := ""
+// addToList adds a section for each exchange of each currency, under the currency's name
+func addToList(content *wtf.Content, toList []*tCurrency, colors textColors) {
 	for _, toCurrency := range toList {
-		str += makeToText(toCurrency, colors)
-	}
-
-	return str
-}
-
-func makeToText(toCurrency *tCurrency, colors textColors) string {
-	str := ""
-	str += fmt.Sprintf("  [%s]%s\n", colors.to.name, toCurrency.name)
-	for _, info := range toCurrency.info {
-		str += makeInfoText(info, colors)
-		str += "\n\n"
+		for idx, info := range toCurrency.info {
+			section := content.AddSection("")
+			if idx == 0 {
+				section.AddRow(wtf.Cell{Text: toCurrency.name, Color: colors.to.name})
+			}
+			addInfo(section, info, colors)
+		}
 	}
-	return str
 }
 
-func makeInfoText(info tInfo, colors textColors) string {
-	return fmt.Sprintf(
-		"    [%s]Exchange: [%s]%s\n",
-		colors.to.field,
-		colors.to.value,
-		info.exchange,
-	) +
-		fmt.Sprintf(
-			"    [%s]Volume(24h): [%s]%f-[%s]%f",
-			colors.to.field,
-			colors.to.value,
-			info.volume24h,
-			colors.to.value,
-			info.volume24hTo,
-		)
+func addInfo(section *wtf.Section, info tInfo, colors textColors) {
+	section.AddRow(
+		wtf.Cell{Text: "Exchange:", Align: tview.AlignRight, Color: colors.to.field},
+		wtf.Cell{Text: info.exchange, Color: colors.to.value},
+	)
+	section.AddRow(
+		wtf.Cell{Text: "Volume(24h):", Align: tview.AlignRight, Color: colors.to.field},
+		wtf.Cell{Text: fmt.Sprintf("%f-%f", info.volume24h, info.volume24hTo), Color: colors.to.value},
+	)
 }
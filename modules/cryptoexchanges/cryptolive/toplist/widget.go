@@ -1,12 +1,11 @@
 package toplist
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
-	"sync"
-	"time"
 
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -27,18 +26,21 @@ type textColors struct {
 
 // Widget Toplist Widget
 type Widget struct {
-	Result string
+	Result *wtf.Content
 
 	RefreshInterval int
 
-	list *cList
+	configKey string
+	list      *cList
 
 	colors textColors
 }
 
 // NewWidget Make new toplist widget
-func NewWidget() *Widget {
-	widget := Widget{}
+func NewWidget(configKey string) *Widget {
+	widget := Widget{
+		configKey: configKey,
+	}
 
 	widget.list = &cList{}
 	widget.setList()
@@ -48,17 +50,17 @@ func NewWidget() *Widget {
 }
 
 func (widget *Widget) setList() {
-	currenciesMap, _ := wtf.Config.Map("wtf.mods.cryptolive.top")
+	currenciesMap, _ := wtf.Config.Map(wtf.ConfigKeyFor(widget.configKey, "top"))
 
 	for fromCurrency := range currenciesMap {
-		displayName := wtf.Config.UString("wtf.mods.cryptolive.top."+fromCurrency+".displayName", "")
-		limit := wtf.Config.UInt("wtf.mods.cryptolive.top."+fromCurrency+".limit", 1)
-		widget.list.addItem(fromCurrency, displayName, limit, makeToList(fromCurrency, limit))
+		displayName := wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "top."+fromCurrency+".displayName"), "")
+		limit := wtf.Config.UInt(wtf.ConfigKeyFor(widget.configKey, "top."+fromCurrency+".limit"), 1)
+		widget.list.addItem(fromCurrency, displayName, limit, widget.makeToList(fromCurrency, limit))
 	}
 }
 
-func makeToList(fCurrencyName string, limit int) (list []*tCurrency) {
-	toList, _ := wtf.Config.List("wtf.mods.cryptolive.top." + fCurrencyName + ".to")
+func (widget *Widget) makeToList(fCurrencyName string, limit int) (list []*tCurrency) {
+	toList, _ := wtf.Config.List(wtf.ConfigKeyFor(widget.configKey, "top."+fCurrencyName+".to"))
 
 	for _, toCurrency := range toList {
 		list = append(list, &tCurrency{
@@ -72,42 +74,42 @@ func makeToList(fCurrencyName string, limit int) (list []*tCurrency) {
 
 func (widget *Widget) config() {
 	// set colors
-	widget.colors.from.name = wtf.Config.UString("wtf.mods.cryptolive.colors.top.from.name", "coral")
-	widget.colors.from.displayName = wtf.Config.UString("wtf.mods.cryptolive.colors.top.from.displayName", "grey")
-	widget.colors.to.name = wtf.Config.UString("wtf.mods.cryptolive.colors.top.to.name", "red")
-	widget.colors.to.field = wtf.Config.UString("wtf.mods.cryptolive.colors.top.to.field", "white")
-	widget.colors.to.value = wtf.Config.UString("wtf.mods.cryptolive.colors.top.to.value", "value")
+	widget.colors.from.name = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.top.from.name"), "coral")
+	widget.colors.from.displayName = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.top.from.displayName"), "grey")
+	widget.colors.to.name = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.top.to.name"), "red")
+	widget.colors.to.field = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.top.to.field"), "white")
+	widget.colors.to.value = wtf.Config.UString(wtf.ConfigKeyFor(widget.configKey, "colors.top.to.value"), "value")
 }
 
 /* -------------------- Exported Functions -------------------- */
 
 // Refresh & update after interval time
-func (widget *Widget) Refresh(wg *sync.WaitGroup) {
+func (widget *Widget) Refresh(ctx context.Context) {
 	if len(widget.list.items) == 0 {
 		return
 	}
 
-	widget.updateData()
+	widget.updateData(ctx)
 
 	widget.display()
-	wg.Done()
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) updateData() {
+func (widget *Widget) updateData(ctx context.Context) {
 	defer func() {
 		recover()
 	}()
 
-	client := &http.Client{
-		Timeout: time.Duration(5 * time.Second),
+	client, err := wtf.HTTPClient(widget.configKey)
+	if err != nil {
+		return
 	}
 
 	for _, fromCurrency := range widget.list.items {
 		for _, toCurrency := range fromCurrency.to {
 
-			request := makeRequest(fromCurrency.name, toCurrency.name, fromCurrency.limit)
+			request := makeRequest(ctx, fromCurrency.name, toCurrency.name, fromCurrency.limit)
 			response, _ := client.Do(request)
 
 			var jsonResponse responseInterface
@@ -130,8 +132,8 @@ func (widget *Widget) updateData() {
 	}
 }
 
-func makeRequest(fsym, tsym string, limit int) *http.Request {
+func makeRequest(ctx context.Context, fsym, tsym string, limit int) *http.Request {
 	url := fmt.Sprintf("%s?fsym=%s&tsym=%s&limit=%d", baseURL, fsym, tsym, limit)
 	request, _ := http.NewRequest("GET", url, nil)
-	return request
+	return request.WithContext(ctx)
 }
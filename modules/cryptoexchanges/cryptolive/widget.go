@@ -1,8 +1,7 @@
 package cryptolive
 
 import (
-	"fmt"
-	"sync"
+	"context"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/modules/cryptoexchanges/cryptolive/price"
@@ -18,11 +17,11 @@ type Widget struct {
 }
 
 // NewWidget Make new instance of widget
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget:    wtf.NewTextWidget(app, "CryptoLive", "cryptolive", false),
-		priceWidget:   price.NewWidget(),
-		toplistWidget: toplist.NewWidget(),
+		TextWidget:    wtf.NewTextWidget(app, "CryptoLive", configKey, false),
+		priceWidget:   price.NewWidget(configKey),
+		toplistWidget: toplist.NewWidget(configKey),
 	}
 
 	widget.priceWidget.RefreshInterval = widget.RefreshInterval()
@@ -34,22 +33,26 @@ func NewWidget(app *tview.Application) *Widget {
 /* -------------------- Exported Functions -------------------- */
 
 // Refresh & update after interval time
-func (widget *Widget) Refresh() {
-	var wg sync.WaitGroup
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if err := widget.priceWidget.Refresh(ctx); err != nil {
+		return err
+	}
 
-	wg.Add(2)
-	widget.priceWidget.Refresh(&wg)
-	widget.toplistWidget.Refresh(&wg)
-	wg.Wait()
+	widget.toplistWidget.Refresh(ctx)
 
 	display(widget)
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
 func display(widget *Widget) {
-	str := ""
-	str += widget.priceWidget.Result
-	str += widget.toplistWidget.Result
-	widget.View.SetText(fmt.Sprintf("\n%s", str))
+	content := wtf.NewContent()
+	for _, result := range []*wtf.Content{widget.priceWidget.Result, widget.toplistWidget.Result} {
+		if result != nil {
+			content.Sections = append(content.Sections, result.Sections...)
+		}
+	}
+	widget.Render(content)
 }
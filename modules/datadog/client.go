@@ -1,34 +1,36 @@
 package datadog
 
 import (
-	"os"
+	"context"
 
 	"github.com/wtfutil/wtf/wtf"
 	datadog "github.com/zorkian/go-datadog-api"
 )
 
 // Monitors returns a list of newrelic monitors
-func Monitors() ([]datadog.Monitor, error) {
-	client := datadog.NewClient(apiKey(), applicationKey())
+func Monitors(ctx context.Context, configKey string) ([]datadog.Monitor, error) {
+	apiKey, err := wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "WTF_DATADOG_API_KEY")
+	if err != nil {
+		return nil, err
+	}
 
-	monitors, err := client.GetMonitorsByTags(wtf.ToStrs(wtf.Config.UList("wtf.mods.datadog.monitors.tags")))
+	applicationKey, err := wtf.Credential(wtf.ConfigKeyFor(configKey, "applicationKey"), "WTF_DATADOG_APPLICATION_KEY")
 	if err != nil {
 		return nil, err
 	}
 
-	return monitors, nil
-}
+	httpClient, err := wtf.HTTPClientWithContext(ctx, configKey)
+	if err != nil {
+		return nil, err
+	}
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.datadog.apiKey",
-		os.Getenv("WTF_DATADOG_API_KEY"),
-	)
-}
+	client := datadog.NewClient(apiKey, applicationKey)
+	client.HttpClient = httpClient
 
-func applicationKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.datadog.applicationKey",
-		os.Getenv("WTF_DATADOG_APPLICATION_KEY"),
-	)
+	monitors, err := client.GetMonitorsByTags(wtf.ToStrs(wtf.Config.UList(wtf.ConfigKeyFor(configKey, "monitors.tags"))))
+	if err != nil {
+		return nil, err
+	}
+
+	return monitors, nil
 }
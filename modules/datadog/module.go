@@ -0,0 +1,21 @@
+package datadog
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "datadog",
+		Settings: wtf.ConfigSchema{
+			"apiKey":         wtf.StringSetting,
+			"applicationKey": wtf.StringSetting,
+			"monitors.tags":  wtf.ListSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,6 +1,7 @@
 package datadog
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/rivo/tview"
@@ -12,9 +13,9 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Datadog", "datadog", false),
+		TextWidget: wtf.NewTextWidget(app, "Datadog", configKey, false),
 	}
 
 	return &widget
@@ -22,28 +23,37 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	monitors, monitorErr := Monitors()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	monitors, err := Monitors(ctx, widget.Key())
+	if err != nil {
+		return err
+	}
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s", widget.Name())))
 	widget.View.Clear()
 
-	var content string
-	if monitorErr != nil {
-		widget.View.SetWrap(true)
-		content = monitorErr.Error()
-	} else {
-		widget.View.SetWrap(false)
-		content = widget.contentFrom(monitors)
+	widget.View.SetWrap(false)
+	widget.Render(widget.contentFrom(monitors))
+
+	notifications := []wtf.Notification{}
+	for _, monitor := range monitors {
+		if monitor.GetOverallState() == "Alert" {
+			notifications = append(notifications, wtf.Notification{
+				ID:      fmt.Sprintf("%d", monitor.GetId()),
+				Title:   "Datadog monitor triggered",
+				Message: monitor.GetName(),
+			})
+		}
 	}
+	widget.NotifyChanges(notifications)
 
-	widget.View.SetText(content)
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(monitors []datadog.Monitor) string {
-	var str string
+func (widget *Widget) contentFrom(monitors []datadog.Monitor) *wtf.Content {
+	content := wtf.NewContent()
 
 	triggeredMonitors := []datadog.Monitor{}
 
@@ -55,19 +65,13 @@ func (widget *Widget) contentFrom(monitors []datadog.Monitor) string {
 		}
 	}
 	if len(triggeredMonitors) > 0 {
-		str = str + fmt.Sprintf(
-			" %s\n",
-			"[red]Triggered Monitors[white]",
-		)
+		section := content.AddSection("Triggered Monitors")
 		for _, triggeredMonitor := range triggeredMonitors {
-			str = str + fmt.Sprintf("[red] %s\n", *triggeredMonitor.Name)
+			section.AddRow(wtf.Text(*triggeredMonitor.Name)).Status = wtf.StatusError
 		}
 	} else {
-		str = str + fmt.Sprintf(
-			" %s\n",
-			"[green]No Triggered Monitors[white]",
-		)
+		content.AddSection("").AddRow(wtf.Text("No Triggered Monitors")).Status = wtf.StatusOK
 	}
 
-	return str
+	return content
 }
@@ -27,10 +27,16 @@ import (
 
 /* -------------------- Exported Functions -------------------- */
 
-func Fetch() ([]*CalEvent, error) {
-	ctx := context.Background()
+func Fetch(ctx context.Context, configKey string) ([]*CalEvent, error) {
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The OAuth client makes its requests through the HTTP client in the context
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
 
-	secretPath, _ := wtf.ExpandHomeDir(wtf.Config.UString("wtf.mods.gcal.secretFile"))
+	secretPath, _ := wtf.ExpandHomeDir(wtf.Config.UString(wtf.ConfigKeyFor(configKey, "secretFile")))
 
 	b, err := ioutil.ReadFile(secretPath)
 	if err != nil {
@@ -48,16 +54,16 @@ func Fetch() ([]*CalEvent, error) {
 		return nil, err
 	}
 
-	calendarIds, err := getCalendarIdList(srv)
+	calendarIds, err := getCalendarIdList(ctx, srv, configKey)
 
 	// Get calendar events
 	var events calendar.Events
 
 	startTime := fromMidnight().Format(time.RFC3339)
-	eventLimit := int64(wtf.Config.UInt("wtf.mods.gcal.eventCount", 10))
+	eventLimit := int64(wtf.Config.UInt(wtf.ConfigKeyFor(configKey, "eventCount"), 10))
 
 	for _, calendarId := range calendarIds {
-		calendarEvents, err := srv.Events.List(calendarId).ShowDeleted(false).TimeMin(startTime).MaxResults(eventLimit).SingleEvents(true).OrderBy("startTime").Do()
+		calendarEvents, err := srv.Events.List(calendarId).ShowDeleted(false).TimeMin(startTime).MaxResults(eventLimit).SingleEvents(true).OrderBy("startTime").Context(ctx).Do()
 		if err != nil {
 			break
 		}
@@ -122,8 +128,8 @@ func isAuthenticated() bool {
 	return err == nil
 }
 
-func authenticate() {
-	filename := wtf.Config.UString("wtf.mods.gcal.secretFile")
+func authenticate(configKey string) {
+	filename := wtf.Config.UString(wtf.ConfigKeyFor(configKey, "secretFile"))
 	secretPath, _ := wtf.ExpandHomeDir(filename)
 
 	b, err := ioutil.ReadFile(secretPath)
@@ -188,10 +194,10 @@ func saveToken(file string, token *oauth2.Token) {
 	json.NewEncoder(f).Encode(token)
 }
 
-func getCalendarIdList(srv *calendar.Service) ([]string, error) {
+func getCalendarIdList(ctx context.Context, srv *calendar.Service, configKey string) ([]string, error) {
 	// Return single calendar if settings specify we should
-	if !wtf.Config.UBool("wtf.mods.gcal.multiCalendar", false) {
-		id, err := srv.CalendarList.Get("primary").Do()
+	if !wtf.Config.UBool(wtf.ConfigKeyFor(configKey, "multiCalendar"), false) {
+		id, err := srv.CalendarList.Get("primary").Context(ctx).Do()
 		if err != nil {
 			return nil, err
 		}
@@ -202,7 +208,7 @@ func getCalendarIdList(srv *calendar.Service) ([]string, error) {
 	var calendarIds []string
 	var pageToken string
 	for {
-		calendarList, err := srv.CalendarList.List().ShowHidden(false).MinAccessRole("writer").PageToken(pageToken).Do()
+		calendarList, err := srv.CalendarList.List().ShowHidden(false).MinAccessRole("writer").PageToken(pageToken).Context(ctx).Do()
 		if err != nil {
 			return nil, err
 		}
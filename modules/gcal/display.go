@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -33,57 +34,58 @@ func (widget *Widget) display() {
 	defer widget.mutex.Unlock()
 
 	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
-	widget.View.SetText(widget.contentFrom(widget.calEvents))
+	widget.Render(widget.contentFrom(widget.calEvents))
 }
 
-func (widget *Widget) contentFrom(calEvents []*CalEvent) string {
+// contentFrom lists the events under the day they're on. The time until each event goes before
+// its title, and its location, if it has one, underneath
+func (widget *Widget) contentFrom(calEvents []*CalEvent) *wtf.Content {
+	content := wtf.NewContent()
+
 	if (calEvents == nil) || (len(calEvents) == 0) {
-		return ""
+		return content
 	}
 
-	var str string
+	var day *wtf.Section
 	var prevEvent *CalEvent
 
-	if !wtf.Config.UBool("wtf.mods.gcal.showDeclined", false) {
-		calEvents = removeDeclined(calEvents)
+	if !wtf.Config.UBool(widget.ConfigKey("showDeclined"), false) {
+		calEvents = widget.removeDeclined(calEvents)
 	}
 
 	for _, calEvent := range calEvents {
-		timestamp := fmt.Sprintf("[%s]%s", widget.descriptionColor(calEvent), calEvent.Timestamp())
-		if calEvent.AllDay() {
-			timestamp = ""
+		if widget.isNewDay(calEvent, prevEvent) {
+			day = content.AddSection(calEvent.Start().Format(wtf.FullDateFormat))
+			day.TitleColor = wtf.Config.UString(widget.ConfigKey("colors.day"), "forestgreen")
 		}
 
-		title := fmt.Sprintf("[%s]%s",
-			widget.titleColor(calEvent),
-			widget.eventSummary(calEvent, calEvent.ConflictsWith(calEvents)),
-		)
+		timestamp := wtf.Cell{Text: calEvent.Timestamp(), Color: widget.descriptionColor(calEvent)}
+		if calEvent.AllDay() {
+			timestamp.Text = ""
+		}
 
-		lineOne := fmt.Sprintf(
-			"%s %s %s %s[white]\n",
-			widget.dayDivider(calEvent, prevEvent),
+		day.AddRow(
 			widget.responseIcon(calEvent),
 			timestamp,
-			title,
-		)
-
-		str = str + fmt.Sprintf("%s   %s%s\n",
-			lineOne,
-			widget.location(calEvent),
 			widget.timeUntil(calEvent),
+			wtf.Cell{
+				Text:  widget.eventSummary(calEvent, calEvent.ConflictsWith(calEvents)),
+				Color: widget.titleColor(calEvent),
+			},
 		)
 
-		if (widget.location(calEvent) != "") || (widget.timeUntil(calEvent) != "") {
-			str = str + "\n"
+		if location := widget.location(calEvent); location != "" {
+			day.AddRow(wtf.Text(""), wtf.Text(""), wtf.Text(""), wtf.Cell{Text: location, Color: widget.descriptionColor(calEvent)})
 		}
 
 		prevEvent = calEvent
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) dayDivider(event, prevEvent *CalEvent) string {
+// isNewDay returns true if the event starts on a different day to the one before it
+func (widget *Widget) isNewDay(event, prevEvent *CalEvent) bool {
 	var prevStartTime time.Time
 
 	if prevEvent != nil {
@@ -98,23 +100,15 @@ func (widget *Widget) dayDivider(event, prevEvent *CalEvent) string {
 	prevStartDay := toMidnight(prevStartTime)
 	eventStartDay := toMidnight(event.Start())
 
-	if !eventStartDay.Equal(prevStartDay) {
-
-		return fmt.Sprintf("[%s::b]",
-			wtf.Config.UString("wtf.mods.gcal.colors.day", "forestgreen")) +
-			event.Start().Format(wtf.FullDateFormat) +
-			"\n"
-	}
-
-	return ""
+	return !eventStartDay.Equal(prevStartDay)
 }
 
 func (widget *Widget) descriptionColor(calEvent *CalEvent) string {
 	if calEvent.Past() {
-		return wtf.Config.UString("wtf.mods.gcal.colors.past", "gray")
+		return wtf.Config.UString(widget.ConfigKey("colors.past"), "gray")
 	}
 
-	return wtf.Config.UString("wtf.mods.gcal.colors.description", "white")
+	return wtf.Config.UString(widget.ConfigKey("colors.description"), "white")
 }
 
 func (widget *Widget) eventSummary(calEvent *CalEvent, conflict bool) string {
@@ -123,25 +117,25 @@ func (widget *Widget) eventSummary(calEvent *CalEvent, conflict bool) string {
 	if calEvent.Now() {
 		summary = fmt.Sprintf(
 			"%s %s",
-			wtf.Config.UString("wtf.mods.gcal.currentIcon", "🔸"),
+			wtf.Config.UString(widget.ConfigKey("currentIcon"), "🔸"),
 			summary,
 		)
 	}
 
 	if conflict {
-		return fmt.Sprintf("%s %s", wtf.Config.UString("wtf.mods.gcal.conflictIcon", "🚨"), summary)
+		return fmt.Sprintf("%s %s", wtf.Config.UString(widget.ConfigKey("conflictIcon"), "🚨"), summary)
 	}
 
 	return summary
 }
 
 // timeUntil returns the number of hours or days until the event
-// If the event is in the past, returns nil
-func (widget *Widget) timeUntil(calEvent *CalEvent) string {
+// If the event is in the past, returns an empty cell
+func (widget *Widget) timeUntil(calEvent *CalEvent) wtf.Cell {
 	duration := time.Until(calEvent.Start()).Round(time.Minute)
 
 	if duration < 0 {
-		return ""
+		return wtf.Text("")
 	}
 
 	days := duration / (24 * time.Hour)
@@ -154,7 +148,7 @@ func (widget *Widget) timeUntil(calEvent *CalEvent) string {
 
 	untilStr := ""
 
-	color := "[lightblue]"
+	color := "lightblue"
 	if days > 0 {
 		untilStr = fmt.Sprintf("%dd", days)
 	} else if hours > 0 {
@@ -162,17 +156,17 @@ func (widget *Widget) timeUntil(calEvent *CalEvent) string {
 	} else {
 		untilStr = fmt.Sprintf("%dm", mins)
 		if mins < 30 {
-			color = "[red]"
+			color = "red"
 		}
 	}
 
-	return color + untilStr + "[white]"
+	return wtf.Cell{Text: untilStr, Align: tview.AlignRight, Color: color}
 }
 
 func (widget *Widget) titleColor(calEvent *CalEvent) string {
-	color := wtf.Config.UString("wtf.mods.gcal.colors.title", "white")
+	color := wtf.Config.UString(widget.ConfigKey("colors.title"), "white")
 
-	for _, untypedArr := range wtf.Config.UList("wtf.mods.gcal.colors.highlights") {
+	for _, untypedArr := range wtf.Config.UList(widget.ConfigKey("colors.highlights")) {
 		highlightElements := wtf.ToStrs(untypedArr.([]interface{}))
 
 		match, _ := regexp.MatchString(
@@ -186,14 +180,14 @@ func (widget *Widget) titleColor(calEvent *CalEvent) string {
 	}
 
 	if calEvent.Past() {
-		color = wtf.Config.UString("wtf.mods.gcal.colors.past", "gray")
+		color = wtf.Config.UString(widget.ConfigKey("colors.past"), "gray")
 	}
 
 	return color
 }
 
 func (widget *Widget) location(calEvent *CalEvent) string {
-	if wtf.Config.UBool("wtf.mods.gcal.displayLocation", true) == false {
+	if wtf.Config.UBool(widget.ConfigKey("displayLocation"), true) == false {
 		return ""
 	}
 
@@ -201,38 +195,36 @@ func (widget *Widget) location(calEvent *CalEvent) string {
 		return ""
 	}
 
-	return fmt.Sprintf(
-		"[%s]%s ",
-		widget.descriptionColor(calEvent),
-		calEvent.event.Location,
-	)
+	return calEvent.event.Location
 }
 
-func (widget *Widget) responseIcon(calEvent *CalEvent) string {
-	if false == wtf.Config.UBool("wtf.mods.gcal.displayResponseStatus", true) {
-		return ""
+func (widget *Widget) responseIcon(calEvent *CalEvent) wtf.Cell {
+	if false == wtf.Config.UBool(widget.ConfigKey("displayResponseStatus"), true) {
+		return wtf.Text("")
 	}
 
-	icon := "[gray]"
+	icon := wtf.Cell{Color: "gray"}
 
-	switch calEvent.ResponseFor(wtf.Config.UString("wtf.mods.gcal.email")) {
+	switch calEvent.ResponseFor(wtf.Config.UString(widget.ConfigKey("email"))) {
 	case "accepted":
-		return icon + "✔︎"
+		icon.Text = "✔︎"
 	case "declined":
-		return icon + "✘"
+		icon.Text = "✘"
 	case "needsAction":
-		return icon + "?"
+		icon.Text = "?"
 	case "tentative":
-		return icon + "~"
+		icon.Text = "~"
 	default:
-		return icon + " "
+		icon.Text = " "
 	}
+
+	return icon
 }
 
-func removeDeclined(events []*CalEvent) []*CalEvent {
+func (widget *Widget) removeDeclined(events []*CalEvent) []*CalEvent {
 	var ret []*CalEvent
 	for _, e := range events {
-		if e.ResponseFor(wtf.Config.UString("wtf.mods.gcal.email")) != "declined" {
+		if e.ResponseFor(wtf.Config.UString(widget.ConfigKey("email"))) != "declined" {
 			ret = append(ret, e)
 		}
 	}
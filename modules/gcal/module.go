@@ -0,0 +1,33 @@
+package gcal
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "gcal",
+		Settings: wtf.ConfigSchema{
+			"colors.day":            wtf.StringSetting,
+			"colors.description":    wtf.StringSetting,
+			"colors.highlights":     wtf.ListSetting,
+			"colors.past":           wtf.StringSetting,
+			"colors.title":          wtf.StringSetting,
+			"conflictIcon":          wtf.StringSetting,
+			"currentIcon":           wtf.StringSetting,
+			"displayLocation":       wtf.BoolSetting,
+			"displayResponseStatus": wtf.BoolSetting,
+			"email":                 wtf.StringSetting,
+			"eventCount":            wtf.IntSetting,
+			"multiCalendar":         wtf.BoolSetting,
+			"secretFile":            wtf.StringSetting,
+			"showDeclined":          wtf.BoolSetting,
+			"textInterval":          wtf.IntSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,6 +1,7 @@
 package gcal
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -17,9 +18,9 @@ type Widget struct {
 	app       *tview.Application
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Calendar", "gcal", true),
+		TextWidget: wtf.NewTextWidget(app, "Calendar", configKey, true),
 		ch:         make(chan struct{}),
 		app:        app,
 	}
@@ -36,29 +37,31 @@ func (widget *Widget) Disable() {
 	widget.TextWidget.Disable()
 }
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if isAuthenticated() {
-		widget.fetchAndDisplayEvents()
-		return
+		return widget.fetchAndDisplayEvents(ctx)
 	}
-	widget.app.Suspend(authenticate)
-	widget.Refresh()
+	widget.app.Suspend(func() { authenticate(widget.Key()) })
+
+	return widget.Refresh(ctx)
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) fetchAndDisplayEvents() {
-	calEvents, err := Fetch()
+func (widget *Widget) fetchAndDisplayEvents(ctx context.Context) error {
+	calEvents, err := Fetch(ctx, widget.Key())
 	if err != nil {
-		widget.calEvents = []*CalEvent{}
-	} else {
-		widget.calEvents = calEvents
+		return err
 	}
+
+	widget.calEvents = calEvents
 	widget.display()
+
+	return nil
 }
 
 func updateLoop(widget *Widget) {
-	interval := wtf.Config.UInt("wtf.mods.gcal.textInterval", 30)
+	interval := wtf.Config.UInt(widget.ConfigKey("textInterval"), 30)
 	if interval == 0 {
 		return
 	}
@@ -2,7 +2,9 @@ package gerrit
 
 import (
 	"fmt"
+	"strconv"
 
+	glb "github.com/andygrunwald/go-gerrit"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -10,65 +12,42 @@ func (widget *Widget) display() {
 
 	project := widget.currentGerritProject()
 	if project == nil {
-		widget.View.SetText(fmt.Sprintf("%s", " Gerrit project data is unavailable (1)"))
+		content := wtf.NewContent()
+		content.AddSection("").AddRow(wtf.Text("Gerrit project data is unavailable (1)"))
+		widget.Render(content)
 		return
 	}
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s- %s", widget.Name(), widget.title(project))))
 
-	str := wtf.SigilStr(len(widget.GerritProjects), widget.Idx, widget.View) + "\n"
-	str = str + " [red]Stats[white]\n"
-	str = str + widget.displayStats(project)
-	str = str + "\n"
-	str = str + " [red]Open Incoming Reviews[white]\n"
-	str = str + widget.displayMyIncomingReviews(project, wtf.Config.UString("wtf.mods.gerrit.username"))
-	str = str + "\n"
-	str = str + " [red]My Outgoing Reviews[white]\n"
-	str = str + widget.displayMyOutgoingReviews(project, wtf.Config.UString("wtf.mods.gerrit.username"))
+	content := wtf.NewContent()
+	content.Page, content.Pages = widget.Idx, len(widget.GerritProjects)
+	content.Selected = widget.selected
 
-	widget.View.SetText(str)
-}
-
-func (widget *Widget) displayMyIncomingReviews(project *GerritProject, username string) string {
-	if len(project.IncomingReviews) == 0 {
-		return " [grey]none[white]\n"
-	}
+	content.AddSection("Stats").AddRow(wtf.Text(fmt.Sprintf("Reviews: %d", project.ReviewCount)))
+	widget.addReviews(content.AddSection("Open Incoming Reviews"), project.IncomingReviews)
+	widget.addReviews(content.AddSection("My Outgoing Reviews"), project.OutgoingReviews)
 
-	str := ""
-	for idx, r := range project.IncomingReviews {
-		str = str + fmt.Sprintf(" [%s] [green]%d[white] [%s] %s\n", widget.rowColor(idx), r.Number, widget.rowColor(idx), r.Subject)
-	}
-
-	return str
+	widget.Render(content)
 }
 
-func (widget *Widget) displayMyOutgoingReviews(project *GerritProject, username string) string {
-	if len(project.OutgoingReviews) == 0 {
-		return " [grey]none[white]\n"
+// addReviews adds a selectable row for each review. Selection counts the incoming reviews
+// first, then the outgoing ones
+func (widget *Widget) addReviews(section *wtf.Section, reviews []glb.ChangeInfo) {
+	if len(reviews) == 0 {
+		section.AddRow(wtf.Cell{Text: "none", Color: "grey"})
+		return
 	}
 
-	str := ""
-	for idx, r := range project.OutgoingReviews {
-		str = str + fmt.Sprintf(" [%s] [green]%d[white] [%s] %s\n", widget.rowColor(idx+len(project.IncomingReviews)), r.Number, widget.rowColor(idx+len(project.IncomingReviews)), r.Subject)
+	for _, r := range reviews {
+		row := section.AddRow(wtf.Cell{Text: strconv.Itoa(r.Number), Color: "green"}, wtf.Text(r.Subject))
+		row.Link = widget.reviewURL(r)
+		row.Selectable = true
 	}
-
-	return str
 }
 
-func (widget *Widget) displayStats(project *GerritProject) string {
-	str := fmt.Sprintf(
-		" Reviews: %d\n",
-		project.ReviewCount,
-	)
-
-	return str
-}
-
-func (widget *Widget) rowColor(index int) string {
-	if widget.View.HasFocus() && (index == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
-	}
-	return wtf.RowColor("gerrit", index)
+func (widget *Widget) reviewURL(change glb.ChangeInfo) string {
+	return fmt.Sprintf("%s/%s/%d", wtf.Config.UString(widget.ConfigKey("domain")), "#/c", change.Number)
 }
 
 func (widget *Widget) title(project *GerritProject) string {
@@ -0,0 +1,24 @@
+package gerrit
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "gerrit",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"domain":                  wtf.StringSetting,
+			"password":                wtf.StringSetting,
+			"projects":                wtf.ListSetting,
+			"username":                wtf.StringSetting,
+			"verifyServerCertificate": wtf.BoolSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
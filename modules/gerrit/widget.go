@@ -1,35 +1,26 @@
 package gerrit
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
-	"net/http"
-	"os"
 	"regexp"
 
 	glb "github.com/andygrunwald/go-gerrit"
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-  Keyboard commands for Gerrit:
-
-    /: Show/hide this help window
-    h: Show the previous project
-    l: Show the next project
-    j: Select the next review in the list
-    k: Select the previous review in the list
-    r: Refresh the data
-
-    arrow left:  Show the previous project
-    arrow right: Show the next project
-    arrow down:  Select the next review in the list
-	arrow up:    Select the previous review in the list
-
-	return: Open the selected review in a browser
-`
+// Keys are the Gerrit widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Show the next project"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Show the previous project"},
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next review in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous review in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected review in a browser"},
+	wtf.RefreshKeyBinding,
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the review"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -46,17 +37,18 @@ var (
 	GerritURLPattern = regexp.MustCompile(`^(http|https)://(.*)$`)
 )
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Gerrit", "gerrit", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Gerrit", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Gerrit", configKey, true),
 
 		Idx: 0,
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
 
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 	widget.unselect()
 
 	return &widget
@@ -64,23 +56,19 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	baseURL := wtf.Config.UString("wtf.mods.gerrit.domain")
-	username := wtf.Config.UString("wtf.mods.gerrit.username")
-
-	password := wtf.Config.UString(
-		"wtf.mods.gerrit.password",
-		os.Getenv("WTF_GERRIT_PASSWORD"),
-	)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	baseURL := wtf.Config.UString(widget.ConfigKey("domain"))
+	username := wtf.Config.UString(widget.ConfigKey("username"))
 
-	verifyServerCertificate := wtf.Config.UBool("wtf.mods.gerrit.verifyServerCertificate", true)
+	password, err := wtf.Credential(widget.ConfigKey("password"), "WTF_GERRIT_PASSWORD")
+	if err != nil {
+		return err
+	}
 
-	httpClient := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: !verifyServerCertificate,
-		},
-		Proxy: http.ProxyFromEnvironment,
-	},
+	// The gerrit library doesn't take a context, so the client makes every request with it
+	httpClient, err := wtf.HTTPClientWithContext(ctx, widget.Key())
+	if err != nil {
+		return err
 	}
 
 	gerritUrl := baseURL
@@ -93,19 +81,22 @@ func (widget *Widget) Refresh() {
 	}
 	gerrit, err := glb.NewClient(gerritUrl, httpClient)
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-		return
+		return err
 	}
 	widget.gerrit = gerrit
-	widget.GerritProjects = widget.buildProjectCollection(wtf.Config.UList("wtf.mods.gerrit.projects"))
+	widget.GerritProjects = widget.buildProjectCollection(wtf.Config.UList(widget.ConfigKey("projects")))
 
+	// Keep refreshing the other projects when one fails, and report the first failure
+	var refreshErr error
 	for _, project := range widget.GerritProjects {
-		project.Refresh()
+		if err := project.Refresh(username); err != nil && refreshErr == nil {
+			refreshErr = err
+		}
 	}
 
 	widget.display()
+
+	return refreshErr
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -159,7 +150,7 @@ func (widget *Widget) openReview() {
 		} else {
 			change = project.OutgoingReviews[sel-len(project.IncomingReviews)]
 		}
-		wtf.OpenFile(fmt.Sprintf("%s/%s/%d", wtf.Config.UString("wtf.mods.gerrit.domain"), "#/c", change.Number))
+		wtf.OpenFile(widget.reviewURL(change))
 	}
 }
 
@@ -191,48 +182,12 @@ func (widget *Widget) currentGerritProject() *GerritProject {
 	return widget.GerritProjects[widget.Idx]
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.prevProject()
-		return nil
-	case "l":
-		widget.nextProject()
-		return nil
-	case "j":
-		widget.nextReview()
-		return nil
-	case "k":
-		widget.prevReview()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.prevProject()
-		return nil
-	case tcell.KeyRight:
-		widget.nextProject()
-		return nil
-	case tcell.KeyDown:
-		widget.nextReview()
-		return nil
-	case tcell.KeyUp:
-		widget.prevReview()
-		return nil
-	case tcell.KeyEnter:
-		widget.openReview()
-		return nil
-	case tcell.KeyEsc:
-		widget.unselect()
-		return event
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.nextProject)
+	widget.Keymap.On("prevSource", widget.prevProject)
+	widget.Keymap.On("next", widget.nextReview)
+	widget.Keymap.On("prev", widget.prevReview)
+	widget.Keymap.On("open", widget.openReview)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("unselect", widget.unselect)
 }
@@ -11,7 +11,7 @@ import (
 func (widget *Widget) display() {
 	repoData := widget.currentData()
 	if repoData == nil {
-		widget.View.SetText(" Git repo data is unavailable ")
+		widget.SetContent(" Git repo data is unavailable ")
 		return
 	}
 
@@ -26,7 +26,7 @@ func (widget *Widget) display() {
 	str = str + "\n"
 	str = str + widget.formatCommits(repoData.Commits)
 
-	widget.View.SetText(str)
+	widget.SetContent(str)
 }
 
 func (widget *Widget) formatChanges(data []string) string {
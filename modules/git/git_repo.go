@@ -14,10 +14,12 @@ type GitRepo struct {
 	Commits      []string
 	Repository   string
 	Path         string
+
+	configKey string
 }
 
-func NewGitRepo(repoPath string) *GitRepo {
-	repo := GitRepo{Path: repoPath}
+func NewGitRepo(repoPath string, configKey string) *GitRepo {
+	repo := GitRepo{Path: repoPath, configKey: configKey}
 
 	repo.Branch = repo.branch()
 	repo.ChangedFiles = repo.changedFiles()
@@ -50,12 +52,12 @@ func (repo *GitRepo) changedFiles() []string {
 }
 
 func (repo *GitRepo) commits() []string {
-	numStr := fmt.Sprintf("-n %d", wtf.Config.UInt("wtf.mods.git.commitCount", 10))
+	numStr := fmt.Sprintf("-n %d", wtf.Config.UInt(wtf.ConfigKeyFor(repo.configKey, "commitCount"), 10))
 
-	dateFormat := wtf.Config.UString("wtf.mods.git.dateFormat", "%b %d, %Y")
+	dateFormat := wtf.Config.UString(wtf.ConfigKeyFor(repo.configKey, "dateFormat"), "%b %d, %Y")
 	dateStr := fmt.Sprintf("--date=format:\"%s\"", dateFormat)
 
-	commitFormat := wtf.Config.UString("wtf.mods.git.commitFormat", "[forestgreen]%h [white]%s [grey]%an on %cd[white]")
+	commitFormat := wtf.Config.UString(wtf.ConfigKeyFor(repo.configKey, "commitFormat"), "[forestgreen]%h [white]%s [grey]%an on %cd[white]")
 	commitStr := fmt.Sprintf("--pretty=format:\"%s\"", commitFormat)
 
 	arg := []string{repo.gitDir(), repo.workTree(), "log", dateStr, numStr, commitStr}
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "git",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"commitCount":  wtf.IntSetting,
+			"commitFormat": wtf.StringSetting,
+			"dateFormat":   wtf.StringSetting,
+			"repositories": wtf.ListSetting,
+			"repository":   wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+	})
+}
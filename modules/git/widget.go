@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -10,18 +11,14 @@ import (
 	"strings"
 )
 
-const HelpText = `
-  Keyboard commands for Git:
-
-    /: Show/hide this help window
-    c: Checkout to branch
-    h: Previous git repository
-    l: Next git repository
-    p: Pull current git repository
-
-    arrow left:  Previous git repository
-    arrow right: Next git repository
-`
+// Keys are the Git widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next git repository"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous git repository"},
+	{Action: "checkout", Keys: []string{"c"}, Help: "Checkout to branch"},
+	{Action: "pull", Keys: []string{"p"}, Help: "Pull the current git repository"},
+}
 
 const offscreen = -1000
 const modalWidth = 80
@@ -37,11 +34,11 @@ type Widget struct {
 	pages    *tview.Pages
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, HelpText),
-		MultiSourceWidget: wtf.NewMultiSourceWidget("git", "repository", "repositories"),
-		TextWidget:        wtf.NewTextWidget(app, "Git", "git", true),
+		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Git", configKey, Keys)),
+		MultiSourceWidget: wtf.NewMultiSourceWidget(configKey, "repository", "repositories"),
+		TextWidget:        wtf.NewTextWidget(app, "Git", configKey, true),
 
 		app:   app,
 		pages: pages,
@@ -51,7 +48,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	widget.SetDisplayFunction(widget.display)
 
 	widget.HelpfulWidget.SetView(widget.View)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
@@ -68,7 +66,7 @@ func (widget *Widget) Checkout() {
 		widget.pages.RemovePage("modal")
 		widget.app.SetFocus(widget.View)
 		widget.display()
-		widget.Refresh()
+		widget.RequestRefresh()
 	}
 
 	widget.addButtons(form, checkoutFctn)
@@ -78,18 +76,20 @@ func (widget *Widget) Checkout() {
 func (widget *Widget) Pull() {
 	repoToPull := widget.GitRepos[widget.Idx]
 	repoToPull.pull()
-	widget.Refresh()
+	widget.RequestRefresh()
 
 }
 
-func (widget *Widget) Refresh() {
-	repoPaths := wtf.ToStrs(wtf.Config.UList("wtf.mods.git.repositories"))
+func (widget *Widget) Refresh(ctx context.Context) error {
+	repoPaths := wtf.ToStrs(wtf.Config.UList(widget.ConfigKey("repositories")))
 
 	widget.GitRepos = widget.gitRepos(repoPaths)
 	sort.Slice(widget.GitRepos, func(i, j int) bool {
 		return widget.GitRepos[i].Path < widget.GitRepos[j].Path
 	})
 	widget.display()
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -164,10 +164,10 @@ func (widget *Widget) gitRepos(repoPaths []string) []*GitRepo {
 
 	for _, repoPath := range repoPaths {
 		if strings.HasSuffix(repoPath, "/") {
-			repos = append(repos, findGitRepositories(make([]*GitRepo, 0), repoPath)...)
+			repos = append(repos, findGitRepositories(make([]*GitRepo, 0), repoPath, widget.Key())...)
 
 		} else {
-			repo := NewGitRepo(repoPath)
+			repo := NewGitRepo(repoPath, widget.Key())
 			repos = append(repos, repo)
 		}
 	}
@@ -175,7 +175,7 @@ func (widget *Widget) gitRepos(repoPaths []string) []*GitRepo {
 	return repos
 }
 
-func findGitRepositories(repositories []*GitRepo, directory string) []*GitRepo {
+func findGitRepositories(repositories []*GitRepo, directory string, configKey string) []*GitRepo {
 	directory = strings.TrimSuffix(directory, "/")
 
 	files, err := ioutil.ReadDir(directory)
@@ -190,14 +190,14 @@ func findGitRepositories(repositories []*GitRepo, directory string) []*GitRepo {
 			path = directory + "/" + file.Name()
 			if file.Name() == ".git" {
 				path = strings.TrimSuffix(path, "/.git")
-				repo := NewGitRepo(path)
+				repo := NewGitRepo(path, configKey)
 				repositories = append(repositories, repo)
 				continue
 			}
 			if file.Name() == "vendor" || file.Name() == "node_modules" {
 				continue
 			}
-			repositories = findGitRepositories(repositories, path)
+			repositories = findGitRepositories(repositories, path, configKey)
 		}
 	}
 
@@ -226,33 +226,9 @@ func (widget *Widget) Prev() {
 	}
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	case "p":
-		widget.Pull()
-		return nil
-	case "c":
-		widget.Checkout()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
+	widget.Keymap.On("checkout", widget.Checkout)
+	widget.Keymap.On("pull", widget.Pull)
 }
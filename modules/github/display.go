@@ -2,95 +2,84 @@ package github
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/google/go-github/github"
+	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
 func (widget *Widget) display() {
 	repo := widget.currentGithubRepo()
 	if repo == nil {
-		widget.View.SetText(" GitHub repo data is unavailable ")
+		content := wtf.NewContent()
+		content.AddSection("").AddRow(wtf.Text("GitHub repo data is unavailable"))
+		widget.Render(content)
 		return
 	}
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %s", widget.Name(), widget.title(repo))))
 
-	str := wtf.SigilStr(len(widget.GithubRepos), widget.Idx, widget.View) + "\n"
-	str = str + " [red]Stats[white]\n"
-	str = str + widget.displayStats(repo)
-	str = str + "\n"
-	str = str + " [red]Open Review Requests[white]\n"
-	str = str + widget.displayMyReviewRequests(repo, wtf.Config.UString("wtf.mods.github.username"))
-	str = str + "\n"
-	str = str + " [red]My Pull Requests[white]\n"
-	str = str + widget.displayMyPullRequests(repo, wtf.Config.UString("wtf.mods.github.username"))
-
-	widget.View.SetText(str)
-}
-
-func (widget *Widget) displayMyPullRequests(repo *GithubRepo, username string) string {
-	prs := repo.myPullRequests(username)
+	content := wtf.NewContent()
+	content.Page, content.Pages = widget.Idx, len(widget.GithubRepos)
 
-	if len(prs) == 0 {
-		return " [grey]none[white]\n"
-	}
-
-	str := ""
-	for _, pr := range prs {
-		str = str + fmt.Sprintf(" %s[green]%4d[white] %s\n", mergeString(pr), *pr.Number, *pr.Title)
-	}
+	widget.addStats(content.AddSection("Stats"), repo)
+	widget.addPullRequests(content.AddSection("Open Review Requests"), repo.myReviewRequests(wtf.Config.UString(widget.ConfigKey("username"))), false)
+	widget.addPullRequests(content.AddSection("My Pull Requests"), repo.myPullRequests(wtf.Config.UString(widget.ConfigKey("username"))), widget.showStatus())
 
-	return str
+	widget.Render(content)
 }
 
-func (widget *Widget) displayMyReviewRequests(repo *GithubRepo, username string) string {
-	prs := repo.myReviewRequests(username)
-
+// addPullRequests adds a row for each pull request, led by whether it can be merged if
+// showStatus is true
+func (widget *Widget) addPullRequests(section *wtf.Section, prs []*github.PullRequest, showStatus bool) {
 	if len(prs) == 0 {
-		return " [grey]none[white]\n"
+		section.AddRow(wtf.Cell{Text: "none", Color: "grey"})
+		return
 	}
 
-	str := ""
 	for _, pr := range prs {
-		str = str + fmt.Sprintf(" [green]%4d[white] %s\n", *pr.Number, *pr.Title)
-	}
+		cells := []wtf.Cell{}
+		if showStatus {
+			cells = append(cells, mergeCell(pr))
+		}
+
+		cells = append(cells,
+			wtf.Cell{Text: strconv.Itoa(pr.GetNumber()), Align: tview.AlignRight, Color: "green"},
+			wtf.Text(pr.GetTitle()),
+		)
 
-	return str
+		section.AddRow(cells...).Link = pr.GetHTMLURL()
+	}
 }
 
-func (widget *Widget) displayStats(repo *GithubRepo) string {
-	str := fmt.Sprintf(
-		" PRs: %d  Issues: %d  Stars: %d\n",
+func (widget *Widget) addStats(section *wtf.Section, repo *GithubRepo) {
+	section.AddRow(wtf.Text(fmt.Sprintf(
+		"PRs: %d  Issues: %d  Stars: %d",
 		repo.PullRequestCount(),
 		repo.IssueCount(),
 		repo.StarCount(),
-	)
-
-	return str
+	)))
 }
 
 func (widget *Widget) title(repo *GithubRepo) string {
 	return fmt.Sprintf("[green]%s - %s[white]", repo.Owner, repo.Name)
 }
 
-func showStatus() bool {
-	return wtf.Config.UBool("wtf.mods.github.enableStatus", false)
+func (widget *Widget) showStatus() bool {
+	return wtf.Config.UBool(widget.ConfigKey("enableStatus"), false)
 }
 
-var mergeIcons = map[string]string{
-	"dirty":    "[red]![white] ",
-	"clean":    "[green]✔[white] ",
-	"unstable": "[red]✖[white] ",
-	"blocked":  "[red]✖[white] ",
+var mergeIcons = map[string]wtf.Cell{
+	"dirty":    {Text: "!", Status: wtf.StatusError},
+	"clean":    {Text: "✔", Status: wtf.StatusOK},
+	"unstable": {Text: "✖", Status: wtf.StatusError},
+	"blocked":  {Text: "✖", Status: wtf.StatusError},
 }
 
-func mergeString(pr *github.PullRequest) string {
-	if !showStatus() {
-		return ""
-	}
-	if str, ok := mergeIcons[pr.GetMergeableState()]; ok {
-		return str
+func mergeCell(pr *github.PullRequest) wtf.Cell {
+	if cell, ok := mergeIcons[pr.GetMergeableState()]; ok {
+		return cell
 	}
-	return "? "
+	return wtf.Text("?")
 }
@@ -11,18 +11,21 @@ import (
 )
 
 type GithubRepo struct {
-	apiKey    string
 	baseURL   string
 	uploadURL string
 
+	configKey string
+
 	Name         string
 	Owner        string
 	PullRequests []*ghb.PullRequest
 	RemoteRepo   *ghb.Repository
 }
 
-func NewGithubRepo(name, owner string) *GithubRepo {
+func NewGithubRepo(name, owner, configKey string) *GithubRepo {
 	repo := GithubRepo{
+		configKey: configKey,
+
 		Name:  name,
 		Owner: owner,
 	}
@@ -36,10 +39,27 @@ func (repo *GithubRepo) Open() {
 	wtf.OpenFile(*repo.RemoteRepo.HTMLURL)
 }
 
-// Refresh reloads the github data via the Github API
-func (repo *GithubRepo) Refresh() {
-	repo.PullRequests, _ = repo.loadPullRequests()
-	repo.RemoteRepo, _ = repo.loadRemoteRepository()
+// Refresh reloads the github data via the Github API. With showStatus, the pull requests
+// created by username are also fetched one by one, to get their mergeable state
+func (repo *GithubRepo) Refresh(ctx context.Context, username string, showStatus bool) error {
+	pullRequests, err := repo.loadPullRequests(ctx)
+	if err != nil {
+		return err
+	}
+
+	if showStatus {
+		pullRequests = repo.individualPRs(ctx, pullRequests, username)
+	}
+
+	remoteRepo, err := repo.loadRemoteRepository(ctx)
+	if err != nil {
+		return err
+	}
+
+	repo.PullRequests = pullRequests
+	repo.RemoteRepo = remoteRepo
+
+	return nil
 }
 
 /* -------------------- Counts -------------------- */
@@ -76,16 +96,31 @@ func (repo *GithubRepo) isGitHubEnterprise() bool {
 	return false
 }
 
-func (repo *GithubRepo) oauthClient() *http.Client {
+func (repo *GithubRepo) oauthClient(ctx context.Context) (*http.Client, error) {
+	apiKey, err := wtf.Credential(wtf.ConfigKeyFor(repo.configKey, "apiKey"), "WTF_GITHUB_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+
 	tokenService := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: repo.apiKey},
+		&oauth2.Token{AccessToken: apiKey},
 	)
 
-	return oauth2.NewClient(context.Background(), tokenService)
+	httpClient, err := wtf.HTTPClient(repo.configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+
+	return oauth2.NewClient(ctx, tokenService), nil
 }
 
-func (repo *GithubRepo) githubClient() (*ghb.Client, error) {
-	oauthClient := repo.oauthClient()
+func (repo *GithubRepo) githubClient(ctx context.Context) (*ghb.Client, error) {
+	oauthClient, err := repo.oauthClient(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	if repo.isGitHubEnterprise() {
 		return ghb.NewEnterpriseClient(repo.baseURL, repo.uploadURL, oauthClient)
@@ -94,19 +129,16 @@ func (repo *GithubRepo) githubClient() (*ghb.Client, error) {
 	return ghb.NewClient(oauthClient), nil
 }
 
+// loadAPICredentials loads the URLs of the Github API to use. The API key is only read when
+// a request is made, since it may have to be fetched from a secret store
 func (repo *GithubRepo) loadAPICredentials() {
-	repo.apiKey = wtf.Config.UString(
-		"wtf.mods.github.apiKey",
-		os.Getenv("WTF_GITHUB_TOKEN"),
-	)
-
 	repo.baseURL = wtf.Config.UString(
-		"wtf.mods.github.baseURL",
+		wtf.ConfigKeyFor(repo.configKey, "baseURL"),
 		os.Getenv("WTF_GITHUB_BASE_URL"),
 	)
 
 	repo.uploadURL = wtf.Config.UString(
-		"wtf.mods.github.uploadURL",
+		wtf.ConfigKeyFor(repo.configKey, "uploadURL"),
 		os.Getenv("WTF_GITHUB_UPLOAD_URL"),
 	)
 }
@@ -123,25 +155,26 @@ func (repo *GithubRepo) myPullRequests(username string) []*ghb.PullRequest {
 		}
 	}
 
-	if showStatus() {
-		prs = repo.individualPRs(prs)
-	}
-
 	return prs
 }
 
 // individualPRs takes a list of pull requests (presumably returned from
-// github.PullRequests.List) and fetches them individually to get more detailed
-// status info on each. see: https://developer.github.com/v3/git/#checking-mergeability-of-pull-requests
-func (repo *GithubRepo) individualPRs(prs []*ghb.PullRequest) []*ghb.PullRequest {
-	github, err := repo.githubClient()
+// github.PullRequests.List) and fetches the ones created by username individually to get more
+// detailed status info on each. see: https://developer.github.com/v3/git/#checking-mergeability-of-pull-requests
+func (repo *GithubRepo) individualPRs(ctx context.Context, prs []*ghb.PullRequest, username string) []*ghb.PullRequest {
+	github, err := repo.githubClient(ctx)
 	if err != nil {
 		return prs
 	}
 
 	var ret []*ghb.PullRequest
 	for i := range prs {
-		pr, _, err := github.PullRequests.Get(context.Background(), repo.Owner, repo.Name, prs[i].GetNumber())
+		if prs[i].GetUser().GetLogin() != username {
+			ret = append(ret, prs[i])
+			continue
+		}
+
+		pr, _, err := github.PullRequests.Get(ctx, repo.Owner, repo.Name, prs[i].GetNumber())
 		if err != nil {
 			// worst case, just keep the original one
 			ret = append(ret, prs[i])
@@ -168,8 +201,8 @@ func (repo *GithubRepo) myReviewRequests(username string) []*ghb.PullRequest {
 	return prs
 }
 
-func (repo *GithubRepo) loadPullRequests() ([]*ghb.PullRequest, error) {
-	github, err := repo.githubClient()
+func (repo *GithubRepo) loadPullRequests(ctx context.Context) ([]*ghb.PullRequest, error) {
+	github, err := repo.githubClient(ctx)
 
 	if err != nil {
 		return nil, err
@@ -177,7 +210,7 @@ func (repo *GithubRepo) loadPullRequests() ([]*ghb.PullRequest, error) {
 
 	opts := &ghb.PullRequestListOptions{}
 
-	prs, _, err := github.PullRequests.List(context.Background(), repo.Owner, repo.Name, opts)
+	prs, _, err := github.PullRequests.List(ctx, repo.Owner, repo.Name, opts)
 
 	if err != nil {
 		return nil, err
@@ -186,14 +219,14 @@ func (repo *GithubRepo) loadPullRequests() ([]*ghb.PullRequest, error) {
 	return prs, nil
 }
 
-func (repo *GithubRepo) loadRemoteRepository() (*ghb.Repository, error) {
-	github, err := repo.githubClient()
+func (repo *GithubRepo) loadRemoteRepository(ctx context.Context) (*ghb.Repository, error) {
+	github, err := repo.githubClient(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	repository, _, err := github.Repositories.Get(context.Background(), repo.Owner, repo.Name)
+	repository, _, err := github.Repositories.Get(ctx, repo.Owner, repo.Name)
 
 	if err != nil {
 		return nil, err
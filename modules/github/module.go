@@ -0,0 +1,25 @@
+package github
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "github",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":       wtf.StringSetting,
+			"baseURL":      wtf.StringSetting,
+			"enableStatus": wtf.BoolSetting,
+			"repositories": wtf.MapSetting,
+			"uploadURL":    wtf.StringSetting,
+			"username":     wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
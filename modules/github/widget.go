@@ -1,24 +1,19 @@
 package github
 
 import (
-	"github.com/gdamore/tcell"
+	"context"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-  Keyboard commands for GitHub:
-
-    /: Show/hide this help window
-    h: Previous git repository
-    l: Next git repository
-    r: Refresh the data
-
-    arrow left:  Previous git repository
-    arrow right: Next git repository
-
-    return: Open the selected repository in a browser
-`
+// Keys are the GitHub widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next git repository"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous git repository"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected repository in a browser"},
+	wtf.RefreshKeyBinding,
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -28,30 +23,38 @@ type Widget struct {
 	Idx         int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "GitHub", "github", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("GitHub", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "GitHub", configKey, true),
 
 		Idx: 0,
 	}
 
-	widget.GithubRepos = widget.buildRepoCollection(wtf.Config.UMap("wtf.mods.github.repositories"))
+	widget.GithubRepos = widget.buildRepoCollection(wtf.Config.UMap(widget.ConfigKey("repositories")))
 
 	widget.HelpfulWidget.SetView(widget.View)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
+	// Keep refreshing the other repos when one fails, and report the first failure
+	var refreshErr error
+	username := wtf.Config.UString(widget.ConfigKey("username"))
 	for _, repo := range widget.GithubRepos {
-		repo.Refresh()
+		if err := repo.Refresh(ctx, username, widget.showStatus()); err != nil && refreshErr == nil {
+			refreshErr = err
+		}
 	}
 
 	widget.display()
+
+	return refreshErr
 }
 
 func (widget *Widget) Next() {
@@ -78,7 +81,7 @@ func (widget *Widget) buildRepoCollection(repoData map[string]interface{}) []*Gi
 	githubRepos := []*GithubRepo{}
 
 	for name, owner := range repoData {
-		repo := NewGithubRepo(name, owner.(string))
+		repo := NewGithubRepo(name, owner.(string), widget.Key())
 		githubRepos = append(githubRepos, repo)
 	}
 
@@ -97,35 +100,11 @@ func (widget *Widget) currentGithubRepo() *GithubRepo {
 	return widget.GithubRepos[widget.Idx]
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyEnter:
-		widget.openRepo()
-		return nil
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
+	widget.Keymap.On("open", widget.openRepo)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
 }
 
 func (widget *Widget) openRepo() {
@@ -2,72 +2,56 @@ package gitlab
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
+	glb "github.com/xanzy/go-gitlab"
 )
 
 func (widget *Widget) display() {
 
 	project := widget.currentGitlabProject()
 	if project == nil {
-		widget.View.SetText(" Gitlab project data is unavailable ")
+		content := wtf.NewContent()
+		content.AddSection("").AddRow(wtf.Text("Gitlab project data is unavailable"))
+		widget.Render(content)
 		return
 	}
 
 	widget.View.SetTitle(fmt.Sprintf("%s- %s", widget.Name(), widget.title(project)))
 
-	str := wtf.SigilStr(len(widget.GitlabProjects), widget.Idx, widget.View) + "\n"
-	str = str + " [red]Stats[white]\n"
-	str = str + widget.displayStats(project)
-	str = str + "\n"
-	str = str + " [red]Open Approval Requests[white]\n"
-	str = str + widget.displayMyApprovalRequests(project, wtf.Config.UString("wtf.mods.gitlab.username"))
-	str = str + "\n"
-	str = str + " [red]My Merge Requests[white]\n"
-	str = str + widget.displayMyMergeRequests(project, wtf.Config.UString("wtf.mods.gitlab.username"))
+	content := wtf.NewContent()
+	content.Page, content.Pages = widget.Idx, len(widget.GitlabProjects)
 
-	widget.View.SetText(str)
-}
-
-func (widget *Widget) displayMyMergeRequests(project *GitlabProject, username string) string {
-	mrs := project.myMergeRequests(username)
-
-	if len(mrs) == 0 {
-		return " [grey]none[white]\n"
-	}
-
-	str := ""
-	for _, mr := range mrs {
-		str = str + fmt.Sprintf(" [green]%4d[white] %s\n", mr.IID, mr.Title)
-	}
+	widget.addStats(content.AddSection("Stats"), project)
+	widget.addMergeRequests(content.AddSection("Open Approval Requests"), project.ApprovalRequests)
+	widget.addMergeRequests(content.AddSection("My Merge Requests"), project.myMergeRequests(wtf.Config.UString(widget.ConfigKey("username"))))
 
-	return str
+	widget.Render(content)
 }
 
-func (widget *Widget) displayMyApprovalRequests(project *GitlabProject, username string) string {
-	mrs := project.myApprovalRequests(username)
-
+func (widget *Widget) addMergeRequests(section *wtf.Section, mrs []*glb.MergeRequest) {
 	if len(mrs) == 0 {
-		return " [grey]none[white]\n"
+		section.AddRow(wtf.Cell{Text: "none", Color: "grey"})
+		return
 	}
 
-	str := ""
 	for _, mr := range mrs {
-		str = str + fmt.Sprintf(" [green]%4d[white] %s\n", mr.IID, mr.Title)
+		section.AddRow(
+			wtf.Cell{Text: strconv.Itoa(mr.IID), Align: tview.AlignRight, Color: "green"},
+			wtf.Text(mr.Title),
+		).Link = mr.WebURL
 	}
-
-	return str
 }
 
-func (widget *Widget) displayStats(project *GitlabProject) string {
-	str := fmt.Sprintf(
-		" MRs: %d  Issues: %d  Stars: %d\n",
+func (widget *Widget) addStats(section *wtf.Section, project *GitlabProject) {
+	section.AddRow(wtf.Text(fmt.Sprintf(
+		"MRs: %d  Issues: %d  Stars: %d",
 		project.MergeRequestCount(),
 		project.IssueCount(),
 		project.StarCount(),
-	)
-
-	return str
+	)))
 }
 
 func (widget *Widget) title(project *GitlabProject) string {
@@ -1,6 +1,8 @@
 package gitlab
 
 import (
+	"context"
+
 	glb "github.com/xanzy/go-gitlab"
 )
 
@@ -8,8 +10,10 @@ type GitlabProject struct {
 	gitlab *glb.Client
 	Path   string
 
-	MergeRequests []*glb.MergeRequest
-	RemoteProject *glb.Project
+	// ApprovalRequests are the merge requests the user has been asked to approve
+	ApprovalRequests []*glb.MergeRequest
+	MergeRequests    []*glb.MergeRequest
+	RemoteProject    *glb.Project
 }
 
 func NewGitlabProject(name string, namespace string, gitlab *glb.Client) *GitlabProject {
@@ -22,10 +26,24 @@ func NewGitlabProject(name string, namespace string, gitlab *glb.Client) *Gitlab
 	return &project
 }
 
-// Refresh reloads the gitlab data via the Gitlab API
-func (project *GitlabProject) Refresh() {
-	project.MergeRequests, _ = project.loadMergeRequests()
-	project.RemoteProject, _ = project.loadRemoteProject()
+// Refresh reloads the gitlab data via the Gitlab API, including which merge requests username
+// has been asked to approve
+func (project *GitlabProject) Refresh(ctx context.Context, username string) error {
+	mergeRequests, err := project.loadMergeRequests(ctx)
+	if err != nil {
+		return err
+	}
+
+	remoteProject, err := project.loadRemoteProject(ctx)
+	if err != nil {
+		return err
+	}
+
+	project.ApprovalRequests = project.approvalRequests(ctx, mergeRequests, username)
+	project.MergeRequests = mergeRequests
+	project.RemoteProject = remoteProject
+
+	return nil
 }
 
 /* -------------------- Counts -------------------- */
@@ -67,13 +85,13 @@ func (project *GitlabProject) myMergeRequests(username string) []*glb.MergeReque
 	return mrs
 }
 
-// myApprovalRequests returns a list of merge requests for which username has been
+// approvalRequests returns a list of merge requests for which username has been
 // requested to approve
-func (project *GitlabProject) myApprovalRequests(username string) []*glb.MergeRequest {
+func (project *GitlabProject) approvalRequests(ctx context.Context, mergeRequests []*glb.MergeRequest, username string) []*glb.MergeRequest {
 	mrs := []*glb.MergeRequest{}
 
-	for _, mr := range project.MergeRequests {
-		approvers, _, err := project.gitlab.MergeRequests.GetMergeRequestApprovals(project.Path, mr.IID)
+	for _, mr := range mergeRequests {
+		approvers, _, err := project.gitlab.MergeRequests.GetMergeRequestApprovals(project.Path, mr.IID, glb.WithContext(ctx))
 		if err != nil {
 			continue
 		}
@@ -87,13 +105,13 @@ func (project *GitlabProject) myApprovalRequests(username string) []*glb.MergeRe
 	return mrs
 }
 
-func (project *GitlabProject) loadMergeRequests() ([]*glb.MergeRequest, error) {
+func (project *GitlabProject) loadMergeRequests(ctx context.Context) ([]*glb.MergeRequest, error) {
 	state := "opened"
 	opts := glb.ListProjectMergeRequestsOptions{
 		State: &state,
 	}
 
-	mrs, _, err := project.gitlab.MergeRequests.ListProjectMergeRequests(project.Path, &opts)
+	mrs, _, err := project.gitlab.MergeRequests.ListProjectMergeRequests(project.Path, &opts, glb.WithContext(ctx))
 
 	if err != nil {
 		return nil, err
@@ -102,8 +120,8 @@ func (project *GitlabProject) loadMergeRequests() ([]*glb.MergeRequest, error) {
 	return mrs, nil
 }
 
-func (project *GitlabProject) loadRemoteProject() (*glb.Project, error) {
-	projectsitory, _, err := project.gitlab.Projects.GetProject(project.Path)
+func (project *GitlabProject) loadRemoteProject(ctx context.Context) (*glb.Project, error) {
+	projectsitory, _, err := project.gitlab.Projects.GetProject(project.Path, glb.WithContext(ctx))
 
 	if err != nil {
 		return nil, err
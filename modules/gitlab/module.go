@@ -0,0 +1,23 @@
+package gitlab
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "gitlab",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":   wtf.StringSetting,
+			"domain":   wtf.StringSetting,
+			"projects": wtf.MapSetting,
+			"username": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,69 +1,86 @@
 package gitlab
 
 import (
-	"os"
+	"context"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 	glb "github.com/xanzy/go-gitlab"
 )
 
-const HelpText = `
-  Keyboard commands for Gitlab:
-
-    /: Show/hide this help window
-    h: Previous project
-    l: Next project
-    r: Refresh the data
-
-    arrow left:  Previous project
-    arrow right: Next project
-`
+// Keys are the Gitlab widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next project"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous project"},
+	wtf.RefreshKeyBinding,
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
 
-	gitlab *glb.Client
+	// setupErr is why the API key or HTTP client couldn't be set up, reported on each refresh
+	setupErr error
+	gitlab   *glb.Client
 
 	GitlabProjects []*GitlabProject
 	Idx            int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
-	baseURL := wtf.Config.UString("wtf.mods.gitlab.domain")
-	gitlab := glb.NewClient(nil, apiKey())
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
+	baseURL := wtf.Config.UString(wtf.ConfigKeyFor(configKey, "domain"))
+	apiKey, setupErr := apiKey(configKey)
+
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil && setupErr == nil {
+		setupErr = err
+	}
+
+	gitlab := glb.NewClient(httpClient, apiKey)
 
 	if baseURL != "" {
 		gitlab.SetBaseURL(baseURL)
 	}
 
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Gitlab", "gitlab", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Gitlab", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Gitlab", configKey, true),
 
-		gitlab: gitlab,
+		setupErr: setupErr,
+		gitlab:   gitlab,
 
 		Idx: 0,
 	}
 
-	widget.GitlabProjects = widget.buildProjectCollection(wtf.Config.UMap("wtf.mods.gitlab.projects"))
+	widget.GitlabProjects = widget.buildProjectCollection(wtf.Config.UMap(widget.ConfigKey("projects")))
 
 	widget.HelpfulWidget.SetView(widget.View)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if widget.setupErr != nil {
+		return widget.setupErr
+	}
+
+	// Keep refreshing the other projects when one fails, and report the first failure
+	var refreshErr error
+	username := wtf.Config.UString(widget.ConfigKey("username"))
 	for _, project := range widget.GitlabProjects {
-		project.Refresh()
+		if err := project.Refresh(ctx, username); err != nil && refreshErr == nil {
+			refreshErr = err
+		}
 	}
 
 	widget.display()
+
+	return refreshErr
 }
 
 func (widget *Widget) Next() {
@@ -86,11 +103,8 @@ func (widget *Widget) Prev() {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.gitlab.apiKey",
-		os.Getenv("WTF_GITLAB_TOKEN"),
-	)
+func apiKey(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "WTF_GITLAB_TOKEN")
 }
 
 func (widget *Widget) buildProjectCollection(projectData map[string]interface{}) []*GitlabProject {
@@ -116,30 +130,8 @@ func (widget *Widget) currentGitlabProject() *GitlabProject {
 	return widget.GitlabProjects[widget.Idx]
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
 }
@@ -2,6 +2,7 @@ package gitter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/wtfutil/wtf/logger"
@@ -9,32 +10,33 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"strconv"
 )
 
-func GetMessages(roomId string, numberOfMessages int) ([]Message, error) {
+func GetMessages(ctx context.Context, configKey string, roomId string, numberOfMessages int, apiToken string) ([]Message, error) {
 	var messages []Message
 
-	resp, err := apiRequest("rooms/" + roomId + "/chatMessages?limit=" + strconv.Itoa(numberOfMessages))
+	resp, err := apiRequest(ctx, configKey, "rooms/"+roomId+"/chatMessages?limit="+strconv.Itoa(numberOfMessages), apiToken)
 	if err != nil {
 		return nil, err
 	}
 
-	parseJson(&messages, resp.Body)
+	err = parseJson(&messages, resp.Body)
 
-	return messages, nil
+	return messages, err
 }
 
-func GetRoom(roomUri string) (*Room, error) {
+func GetRoom(ctx context.Context, configKey string, roomUri, apiToken string) (*Room, error) {
 	var rooms Rooms
 
-	resp, err := apiRequest("rooms?q=" + roomUri)
+	resp, err := apiRequest(ctx, configKey, "rooms?q="+roomUri, apiToken)
 	if err != nil {
 		return nil, err
 	}
 
-	parseJson(&rooms, resp.Body)
+	if err := parseJson(&rooms, resp.Body); err != nil {
+		return nil, err
+	}
 
 	for _, room := range rooms.Results {
 		logger.Log(fmt.Sprintf("room: %s", room))
@@ -52,12 +54,21 @@ var (
 	apiBaseURL = "https://api.gitter.im/v1/"
 )
 
-func apiRequest(path string) (*http.Response, error) {
+func apiRequest(ctx context.Context, configKey string, path, apiToken string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", apiBaseURL+path, nil)
-	bearer := fmt.Sprintf("Bearer %s", apiToken())
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	bearer := fmt.Sprintf("Bearer %s", apiToken)
 	req.Header.Add("Authorization", bearer)
 
-	httpClient := &http.Client{}
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -70,10 +81,10 @@ func apiRequest(path string) (*http.Response, error) {
 	return resp, nil
 }
 
-func parseJson(obj interface{}, text io.Reader) {
+func parseJson(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -82,14 +93,9 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
-}
 
-func apiToken() string {
-	return wtf.Config.UString(
-		"wtf.mods.gitter.apiToken",
-		os.Getenv("WTF_GITTER_API_TOKEN"),
-	)
+	return nil
 }
@@ -0,0 +1,22 @@
+package gitter
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "gitter",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiToken":         wtf.StringSetting,
+			"numberOfMessages": wtf.IntSetting,
+			"roomUri":          wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
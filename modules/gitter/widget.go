@@ -1,24 +1,22 @@
 package gitter
 
 import (
+	"context"
 	"fmt"
-	"github.com/gdamore/tcell"
+
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 	"strconv"
 )
 
-const HelpText = `
- Keyboard commands for Gitter:
-
-   /: Show/hide this help window
-   j: Select the next message in the list
-   k: Select the previous message in the list
-   r: Refresh the data
-
-   arrow down: Select the next message in the list
-   arrow up:   Select the previous message in the list
-`
+// Keys are the Gitter widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next message in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous message in the list"},
+	wtf.RefreshKeyBinding,
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the message"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -28,10 +26,10 @@ type Widget struct {
 	selected int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Gitter", "gitter", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Gitter", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Gitter", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
@@ -39,42 +37,53 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.View.SetScrollable(true)
 	widget.View.SetRegions(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
-	room, err := GetRoom(wtf.Config.UString("wtf.mods.gitter.roomUri", "wtfutil/Lobby"))
+	apiToken, err := widget.apiToken()
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-		return
+		return err
 	}
 
-	if room == nil {
-		return
+	room, err := GetRoom(ctx, widget.Key(), wtf.Config.UString(widget.ConfigKey("roomUri"), "wtfutil/Lobby"), apiToken)
+	if err != nil {
+		return err
 	}
 
-	messages, err := GetMessages(room.ID, wtf.Config.UInt("wtf.mods.gitter.numberOfMessages", 10))
+	if room == nil {
+		return nil
+	}
 
+	messages, err := GetMessages(ctx, widget.Key(), room.ID, wtf.Config.UInt(widget.ConfigKey("numberOfMessages"), 10), apiToken)
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-	} else {
-		widget.messages = messages
+		return err
 	}
 
+	widget.messages = messages
 	widget.display()
 	widget.View.ScrollToEnd()
+
+	return nil
+}
+
+// SelectedItem returns the selected message, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.messages == nil || sel >= len(widget.messages) {
+		return nil
+	}
+
+	return widget.messages[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -86,37 +95,33 @@ func (widget *Widget) display() {
 
 	widget.View.SetWrap(true)
 	widget.View.Clear()
-	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %s", widget.Name(), wtf.Config.UString("wtf.mods.gitter.roomUri", "wtfutil/Lobby"))))
-	widget.View.SetText(widget.contentFrom(widget.messages))
+	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %s", widget.Name(), wtf.Config.UString(widget.ConfigKey("roomUri"), "wtfutil/Lobby"))))
+	widget.Render(widget.contentFrom(widget.messages))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
-func (widget *Widget) contentFrom(messages []Message) string {
-	var str string
-	for idx, message := range messages {
-		str = str + fmt.Sprintf(
-			`["%d"][""][%s] [blue]%s [lightslategray]%s: [%s]%s [aqua]%s`,
-			idx,
-			widget.rowColor(idx),
-			message.From.DisplayName,
-			message.From.Username,
-			widget.rowColor(idx),
-			message.Text,
-			message.Sent.Format("Jan 02, 15:04 MST"),
-		)
-
-		str = str + "\n"
+// contentFrom lists the messages with when they were sent first, as the messages themselves
+// are too long to line anything up after them
+func (widget *Widget) contentFrom(messages []Message) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
+
+	section := content.AddSection("")
+
+	for _, message := range messages {
+		section.AddRow(
+			wtf.Cell{Text: message.Sent.Format("Jan 02, 15:04 MST"), Color: "aqua"},
+			wtf.Cell{Text: message.From.DisplayName, Color: "blue"},
+			wtf.Cell{Text: message.From.Username + ":", Color: "lightslategray"},
+			wtf.Text(message.Text),
+		).Selectable = true
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) rowColor(idx int) string {
-	if widget.View.HasFocus() && (idx == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
-	}
-
-	return wtf.RowColor("gitter", idx)
+func (widget *Widget) apiToken() (string, error) {
+	return wtf.Credential(widget.ConfigKey("apiToken"), "WTF_GITTER_API_TOKEN")
 }
 
 func (widget *Widget) next() {
@@ -150,32 +155,9 @@ func (widget *Widget) unselect() {
 	widget.display()
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-	case "j":
-		widget.next()
-		return nil
-	case "k":
-		widget.prev()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyDown:
-		widget.next()
-		return nil
-	case tcell.KeyEsc:
-		widget.unselect()
-		return event
-	case tcell.KeyUp:
-		widget.prev()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", widget.next)
+	widget.Keymap.On("prev", widget.prev)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("unselect", widget.unselect)
 }
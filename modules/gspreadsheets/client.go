@@ -26,10 +26,16 @@ import (
 
 /* -------------------- Exported Functions -------------------- */
 
-func Fetch() ([]*sheets.ValueRange, error) {
-	ctx := context.Background()
+func Fetch(ctx context.Context, configKey string) ([]*sheets.ValueRange, error) {
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The OAuth client makes its requests through the HTTP client in the context
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
 
-	secretPath, _ := wtf.ExpandHomeDir(wtf.Config.UString("wtf.mods.gspreadsheets.secretFile"))
+	secretPath, _ := wtf.ExpandHomeDir(wtf.Config.UString(wtf.ConfigKeyFor(configKey, "secretFile")))
 
 	b, err := ioutil.ReadFile(secretPath)
 	if err != nil {
@@ -51,17 +57,17 @@ func Fetch() ([]*sheets.ValueRange, error) {
 		return nil, err
 	}
 
-	cells := wtf.ToStrs(wtf.Config.UList("wtf.mods.gspreadsheets.cells.addresses"))
-	documentId := wtf.Config.UString("wtf.mods.gspreadsheets.sheetId")
+	cells := wtf.ToStrs(wtf.Config.UList(wtf.ConfigKeyFor(configKey, "cells.addresses")))
+	documentId := wtf.Config.UString(wtf.ConfigKeyFor(configKey, "sheetId"))
 	addresses := strings.Join(cells[:], ";")
 
 	responses := make([]*sheets.ValueRange, len(cells))
 
 	for i := 0; i < len(cells); i++ {
-		resp, err := srv.Spreadsheets.Values.Get(documentId, cells[i]).Do()
+		// A refresh that's cancelled or times out fails here too, so this mustn't exit
+		resp, err := srv.Spreadsheets.Values.Get(documentId, cells[i]).Context(ctx).Do()
 		if err != nil {
-			log.Fatalf("Error fetching cells %s", addresses)
-			return nil, err
+			return nil, fmt.Errorf("Error fetching cells %s: %v", addresses, err)
 		}
 		responses[i] = resp
 	}
@@ -0,0 +1,23 @@
+package gspreadsheets
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "gspreadsheets",
+		Settings: wtf.ConfigSchema{
+			"cells.addresses": wtf.ListSetting,
+			"cells.names":     wtf.ListSetting,
+			"colors.values":   wtf.StringSetting,
+			"secretFile":      wtf.StringSetting,
+			"sheetId":         wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,6 +1,7 @@
 package gspreadsheets
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/rivo/tview"
@@ -12,9 +13,9 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Google Spreadsheets", "gspreadsheets", false),
+		TextWidget: wtf.NewTextWidget(app, "Google Spreadsheets", configKey, false),
 	}
 
 	return &widget
@@ -22,26 +23,37 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	cells, _ := Fetch()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	cells, err := Fetch(ctx, widget.Key())
+	if err != nil {
+		return err
+	}
+
+	widget.Render(widget.contentFrom(cells))
 
-	widget.View.SetText(widget.contentFrom(cells))
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(valueRanges []*sheets.ValueRange) string {
+func (widget *Widget) contentFrom(valueRanges []*sheets.ValueRange) *wtf.Content {
+	content := wtf.NewContent()
+	section := content.AddSection("")
+
 	if valueRanges == nil {
-		return "error 1"
+		section.AddRow(wtf.Text("error 1"))
+		return content
 	}
 
-	valuesColor := wtf.Config.UString("wtf.mods.gspreadsheets.colors.values", "green")
-	res := ""
+	valuesColor := wtf.Config.UString(widget.ConfigKey("colors.values"), "green")
 
-	cells := wtf.ToStrs(wtf.Config.UList("wtf.mods.gspreadsheets.cells.names"))
+	cells := wtf.ToStrs(wtf.Config.UList(widget.ConfigKey("cells.names")))
 	for i := 0; i < len(valueRanges); i++ {
-		res = res + fmt.Sprintf("%s\t[%s]%s\n", cells[i], valuesColor, valueRanges[i].Values[0][0])
+		section.AddRow(
+			wtf.Text(cells[i]),
+			wtf.Cell{Text: fmt.Sprintf("%v", valueRanges[i].Values[0][0]), Color: valuesColor},
+		)
 	}
 
-	return res
+	return content
 }
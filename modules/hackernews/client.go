@@ -2,6 +2,7 @@ package hackernews
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,35 +10,39 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
-func GetStories(storyType string) ([]int, error) {
+func GetStories(ctx context.Context, configKey string, storyType string) ([]int, error) {
 	var storyIds []int
 
 	switch strings.ToLower(storyType) {
 	case "new", "top", "job", "ask":
-		resp, err := apiRequest(storyType + "stories")
+		resp, err := apiRequest(ctx, configKey, storyType+"stories")
 		if err != nil {
 			return storyIds, err
 		}
 
-		parseJson(&storyIds, resp.Body)
+		if err := parseJson(&storyIds, resp.Body); err != nil {
+			return storyIds, err
+		}
 	}
 
 	return storyIds, nil
 }
 
-func GetStory(id int) (Story, error) {
+func GetStory(ctx context.Context, configKey string, id int) (Story, error) {
 	var story Story
 
-	resp, err := apiRequest("item/" + strconv.Itoa(id))
+	resp, err := apiRequest(ctx, configKey, "item/"+strconv.Itoa(id))
 	if err != nil {
 		return story, err
 	}
 
-	parseJson(&story, resp.Body)
+	err = parseJson(&story, resp.Body)
 
-	return story, nil
+	return story, err
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -46,10 +51,18 @@ var (
 	apiEndpoint = "https://hacker-news.firebaseio.com/v0/"
 )
 
-func apiRequest(path string) (*http.Response, error) {
+func apiRequest(ctx context.Context, configKey string, path string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", apiEndpoint+path+".json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
 
-	httpClient := &http.Client{}
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -62,10 +75,10 @@ func apiRequest(path string) (*http.Response, error) {
 	return resp, nil
 }
 
-func parseJson(obj interface{}, text io.Reader) {
+func parseJson(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -74,7 +87,9 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,21 @@
+package hackernews
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "hackernews",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"numberOfStories": wtf.IntSetting,
+			"storyType":       wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,30 +1,26 @@
 package hackernews
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
- Keyboard commands for Hacker News:
-
-   /: Show/hide this help window
-   j: Select the next story in the list
-   k: Select the previous story in the list
-   r: Refresh the data
-
-   arrow down: Select the next story in the list
-   arrow up:   Select the previous story in the list
-
-   return: Open the selected story in a browser
-   c: Open the comments of the article
-`
+// Keys are the Hacker News widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next story in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous story in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected story in a browser"},
+	wtf.RefreshKeyBinding,
+	{Action: "comments", Keys: []string{"c"}, Help: "Open the comments of the selected story"},
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the story"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -34,10 +30,10 @@ type Widget struct {
 	selected int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Hacker News", "hackernews", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Hacker News", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Hacker News", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
@@ -45,43 +41,53 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.View.SetScrollable(true)
 	widget.View.SetRegions(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
+	}
+
+	storyIds, err := GetStories(ctx, widget.Key(), wtf.Config.UString(widget.ConfigKey("storyType"), "top"))
+	if err != nil {
+		return err
 	}
 
-	storyIds, err := GetStories(wtf.Config.UString("wtf.mods.hackernews.storyType", "top"))
 	if storyIds == nil {
-		return
+		return nil
 	}
 
-	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-	} else {
-		var stories []Story
-		numberOfStoriesToDisplay := wtf.Config.UInt("wtf.mods.hackernews.numberOfStories", 10)
-		for idx := 0; idx < numberOfStoriesToDisplay; idx++ {
-			story, e := GetStory(storyIds[idx])
-			if e != nil {
-				panic(e)
-			} else {
-				stories = append(stories, story)
-			}
+	var stories []Story
+	numberOfStoriesToDisplay := wtf.Config.UInt(widget.ConfigKey("numberOfStories"), 10)
+	for idx := 0; idx < numberOfStoriesToDisplay; idx++ {
+		story, err := GetStory(ctx, widget.Key(), storyIds[idx])
+		if err != nil {
+			return err
 		}
 
-		widget.stories = stories
+		stories = append(stories, story)
 	}
 
+	widget.stories = stories
 	widget.display()
+
+	return nil
+}
+
+// SelectedItem returns the selected story, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.stories == nil || sel >= len(widget.stories) {
+		return nil
+	}
+
+	return widget.stories[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -94,37 +100,31 @@ func (widget *Widget) display() {
 	widget.View.SetWrap(false)
 
 	widget.View.Clear()
-	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %sstories", widget.Name(), wtf.Config.UString("wtf.mods.hackernews.storyType", "top"))))
-	widget.View.SetText(widget.contentFrom(widget.stories))
+	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %sstories", widget.Name(), wtf.Config.UString(widget.ConfigKey("storyType"), "top"))))
+	widget.Render(widget.contentFrom(widget.stories))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
-func (widget *Widget) contentFrom(stories []Story) string {
-	var str string
-	for idx, story := range stories {
-		u, _ := url.Parse(story.URL)
-		str = str + fmt.Sprintf(
-			`["%d"][""][%s] [yellow]%d. [%s]%s [blue](%s)`,
-			idx,
-			widget.rowColor(idx),
-			idx+1,
-			widget.rowColor(idx),
-			story.Title,
-			strings.TrimPrefix(u.Host, "www."),
-		)
+func (widget *Widget) contentFrom(stories []Story) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
 
-		str = str + "\n"
-	}
+	section := content.AddSection("")
 
-	return str
-}
+	for idx, story := range stories {
+		u, _ := url.Parse(story.URL)
 
-func (widget *Widget) rowColor(idx int) string {
-	if widget.View.HasFocus() && (idx == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
+		// The host goes in the same cell as the title, rather than a column of its own, so
+		// that it stays next to the title instead of being pushed past the longest one
+		row := section.AddRow(
+			wtf.Cell{Text: fmt.Sprintf("%d.", idx+1), Align: tview.AlignRight, Color: "yellow"},
+			wtf.Text(fmt.Sprintf("%s (%s)", story.Title, strings.TrimPrefix(u.Host, "www."))),
+		)
+		row.Link = story.URL
+		row.Selectable = true
 	}
 
-	return wtf.RowColor("hackernews", idx)
+	return content
 }
 
 func (widget *Widget) next() {
@@ -166,38 +166,11 @@ func (widget *Widget) unselect() {
 	widget.display()
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-	case "j":
-		widget.next()
-		return nil
-	case "k":
-		widget.prev()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	case "c":
-		widget.openComments()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyDown:
-		widget.next()
-		return nil
-	case tcell.KeyEnter:
-		widget.openStory()
-		return nil
-	case tcell.KeyEsc:
-		widget.unselect()
-		return event
-	case tcell.KeyUp:
-		widget.prev()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", widget.next)
+	widget.Keymap.On("prev", widget.prev)
+	widget.Keymap.On("open", widget.openStory)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("comments", widget.openComments)
+	widget.Keymap.On("unselect", widget.unselect)
 }
@@ -1,12 +1,10 @@
 package ipapi
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"strconv"
-	"text/template"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -15,7 +13,7 @@ import (
 // Widget widget struct
 type Widget struct {
 	wtf.TextWidget
-	result string
+	result *wtf.Content
 	colors struct {
 		name, value string
 	}
@@ -37,9 +35,9 @@ type ipinfo struct {
 }
 
 // NewWidget constructor
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "IPInfo", "ipapi", false),
+		TextWidget: wtf.NewTextWidget(app, "IPInfo", configKey, false),
 	}
 
 	widget.View.SetWrap(false)
@@ -50,77 +48,73 @@ func NewWidget(app *tview.Application) *Widget {
 }
 
 // Refresh refresh the module
-func (widget *Widget) Refresh() {
-	widget.ipinfo()
-	widget.View.SetText(widget.result)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if err := widget.ipinfo(ctx); err != nil {
+		return err
+	}
+
+	widget.Render(widget.result)
+
+	return nil
 }
 
 //this method reads the config and calls ipinfo for ip information
-func (widget *Widget) ipinfo() {
-	client := &http.Client{}
+func (widget *Widget) ipinfo(ctx context.Context) error {
+	client, err := wtf.HTTPClient(widget.Key())
+	if err != nil {
+		return err
+	}
+
 	req, err := http.NewRequest("GET", "http://ip-api.com/json", nil)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Set("User-Agent", "curl")
 	response, err := client.Do(req)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 	defer response.Body.Close()
 	var info ipinfo
 	err = json.NewDecoder(response.Body).Decode(&info)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 
 	widget.setResult(&info)
+
+	return nil
 }
 
 // read module configs
 func (widget *Widget) config() {
-	nameColor, valueColor := wtf.Config.UString("wtf.mods.ipinfo.colors.name", "red"), wtf.Config.UString("wtf.mods.ipinfo.colors.value", "white")
+	nameColor, valueColor := wtf.Config.UString(widget.ConfigKey("colors.name"), "red"), wtf.Config.UString(widget.ConfigKey("colors.value"), "white")
 	widget.colors.name = nameColor
 	widget.colors.value = valueColor
 }
 
 func (widget *Widget) setResult(info *ipinfo) {
-	resultTemplate, _ := template.New("ipinfo_result").Parse(
-		formatableText("IP Address", "Ip") +
-			formatableText("ISP", "ISP") +
-			formatableText("AS", "AS") +
-			formatableText("City", "City") +
-			formatableText("Region", "Region") +
-			formatableText("Country", "Country") +
-			formatableText("Coordinates", "Coordinates") +
-			formatableText("Postal Code", "PostalCode") +
-			formatableText("Organization", "Organization") +
-			formatableText("Timezone", "Timezone"),
-	)
-
-	resultBuffer := new(bytes.Buffer)
-
-	resultTemplate.Execute(resultBuffer, map[string]string{
-		"nameColor":    widget.colors.name,
-		"valueColor":   widget.colors.value,
-		"Ip":           info.Query,
-		"ISP":          info.ISP,
-		"AS":           info.AS,
-		"City":         info.City,
-		"Region":       info.Region,
-		"Country":      info.Country,
-		"Coordinates":  strconv.FormatFloat(info.Latitude, 'f', 6, 64) + "," + strconv.FormatFloat(info.Longitude, 'f', 6, 64),
-		"PostalCode":   info.PostalCode,
-		"Organization": info.Organization,
-		"Timezone":     info.Timezone,
-	})
-
-	widget.result = resultBuffer.String()
+	content := wtf.NewContent()
+	section := content.AddSection("")
+
+	widget.addField(section, "IP Address", info.Query)
+	widget.addField(section, "ISP", info.ISP)
+	widget.addField(section, "AS", info.AS)
+	widget.addField(section, "City", info.City)
+	widget.addField(section, "Region", info.Region)
+	widget.addField(section, "Country", info.Country)
+	widget.addField(section, "Coordinates", strconv.FormatFloat(info.Latitude, 'f', 6, 64)+","+strconv.FormatFloat(info.Longitude, 'f', 6, 64))
+	widget.addField(section, "Postal Code", info.PostalCode)
+	widget.addField(section, "Organization", info.Organization)
+	widget.addField(section, "Timezone", info.Timezone)
+
+	widget.result = content
 }
 
-func formatableText(key, value string) string {
-	return fmt.Sprintf(" [{{.nameColor}}]%s: [{{.valueColor}}]{{.%s}}\n", key, value)
+func (widget *Widget) addField(section *wtf.Section, name, value string) {
+	section.AddRow(
+		wtf.Cell{Text: name + ":", Color: widget.colors.name},
+		wtf.Cell{Text: value, Color: widget.colors.value},
+	)
 }
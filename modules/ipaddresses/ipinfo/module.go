@@ -0,0 +1,20 @@
+package ipinfo
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "ipinfo",
+		Settings: wtf.ConfigSchema{
+			"colors.name":  wtf.StringSetting,
+			"colors.value": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,11 +1,9 @@
 package ipinfo
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"text/template"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -13,7 +11,7 @@ import (
 
 type Widget struct {
 	wtf.TextWidget
-	result string
+	result *wtf.Content
 	colors struct {
 		name, value string
 	}
@@ -30,9 +28,9 @@ type ipinfo struct {
 	Organization string `json:"org"`
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "IPInfo", "ipinfo", false),
+		TextWidget: wtf.NewTextWidget(app, "IPInfo", configKey, false),
 	}
 
 	widget.View.SetWrap(false)
@@ -42,74 +40,70 @@ func NewWidget(app *tview.Application) *Widget {
 	return &widget
 }
 
-func (widget *Widget) Refresh() {
-	widget.ipinfo()
-	widget.View.Clear()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if err := widget.ipinfo(ctx); err != nil {
+		return err
+	}
+
+	widget.Render(widget.result)
 
-	widget.View.SetText(widget.result)
+	return nil
 }
 
 //this method reads the config and calls ipinfo for ip information
-func (widget *Widget) ipinfo() {
-	client := &http.Client{}
+func (widget *Widget) ipinfo(ctx context.Context) error {
+	client, err := wtf.HTTPClient(widget.Key())
+	if err != nil {
+		return err
+	}
+
 	req, err := http.NewRequest("GET", "https://ipinfo.io/", nil)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Set("User-Agent", "curl")
 	response, err := client.Do(req)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 	defer response.Body.Close()
 
 	var info ipinfo
 	err = json.NewDecoder(response.Body).Decode(&info)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 
 	widget.setResult(&info)
+
+	return nil
 }
 
 // read module configs
 func (widget *Widget) config() {
-	widget.colors.name = wtf.Config.UString("wtf.mods.ipinfo.colors.name", "white")
-	widget.colors.value = wtf.Config.UString("wtf.mods.ipinfo.colors.value", "white")
+	widget.colors.name = wtf.Config.UString(widget.ConfigKey("colors.name"), "white")
+	widget.colors.value = wtf.Config.UString(widget.ConfigKey("colors.value"), "white")
 }
 
 func (widget *Widget) setResult(info *ipinfo) {
-	resultTemplate, _ := template.New("ipinfo_result").Parse(
-		formatableText("IP", "Ip") +
-			formatableText("Hostname", "Hostname") +
-			formatableText("City", "City") +
-			formatableText("Region", "Region") +
-			formatableText("Country", "Country") +
-			formatableText("Coords", "Coordinates") +
-			formatableText("Org", "Organization"),
-	)
-
-	resultBuffer := new(bytes.Buffer)
-
-	resultTemplate.Execute(resultBuffer, map[string]string{
-		"nameColor":    widget.colors.name,
-		"valueColor":   widget.colors.value,
-		"Ip":           info.Ip,
-		"Hostname":     info.Hostname,
-		"City":         info.City,
-		"Region":       info.Region,
-		"Country":      info.Country,
-		"Coordinates":  info.Coordinates,
-		"PostalCode":   info.PostalCode,
-		"Organization": info.Organization,
-	})
-
-	widget.result = resultBuffer.String()
+	content := wtf.NewContent()
+	section := content.AddSection("")
+
+	widget.addField(section, "IP", info.Ip)
+	widget.addField(section, "Hostname", info.Hostname)
+	widget.addField(section, "City", info.City)
+	widget.addField(section, "Region", info.Region)
+	widget.addField(section, "Country", info.Country)
+	widget.addField(section, "Coords", info.Coordinates)
+	widget.addField(section, "Org", info.Organization)
+
+	widget.result = content
 }
 
-func formatableText(key, value string) string {
-	return fmt.Sprintf(" [{{.nameColor}}]%8s: [{{.valueColor}}]{{.%s}}\n", key, value)
+func (widget *Widget) addField(section *wtf.Section, name, value string) {
+	section.AddRow(
+		wtf.Cell{Text: name + ":", Align: tview.AlignRight, Color: widget.colors.name},
+		wtf.Cell{Text: value, Color: widget.colors.value},
+	)
 }
@@ -2,7 +2,7 @@ package jenkins
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
@@ -13,7 +13,7 @@ import (
 	"github.com/wtfutil/wtf/wtf"
 )
 
-func Create(jenkinsURL string, username string, apiKey string) (*View, error) {
+func Create(ctx context.Context, configKey string, jenkinsURL string, username string, apiKey string) (*View, error) {
 	const apiSuffix = "api/json?pretty=true"
 	parsedSuffix, err := url.Parse(apiSuffix)
 	if err != nil {
@@ -26,17 +26,18 @@ func Create(jenkinsURL string, username string, apiKey string) (*View, error) {
 	}
 	jenkinsAPIURL := parsedJenkinsURL.ResolveReference(parsedSuffix)
 
-	req, _ := http.NewRequest("GET", jenkinsAPIURL.String(), nil)
+	req, err := http.NewRequest("GET", jenkinsAPIURL.String(), nil)
+	if err != nil {
+		return &View{}, err
+	}
+	req = req.WithContext(ctx)
 	req.SetBasicAuth(username, apiKey)
 
-	verifyServerCertificate := wtf.Config.UBool("wtf.mods.jenkins.verifyServerCertificate", true)
-	httpClient := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: !verifyServerCertificate,
-		},
-		Proxy: http.ProxyFromEnvironment,
-	},
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return &View{}, err
 	}
+
 	resp, err := httpClient.Do(req)
 
 	if err != nil {
@@ -44,7 +45,9 @@ func Create(jenkinsURL string, username string, apiKey string) (*View, error) {
 	}
 
 	view := &View{}
-	parseJson(view, resp.Body)
+	if err := parseJson(view, resp.Body); err != nil {
+		return nil, err
+	}
 
 	return view, nil
 }
@@ -55,10 +58,10 @@ func ensureLastSlash(URL string) string {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func parseJson(obj interface{}, text io.Reader) {
+func parseJson(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -67,7 +70,9 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,23 @@
+package jenkins
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "jenkins",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":                  wtf.StringSetting,
+			"url":                     wtf.StringSetting,
+			"user":                    wtf.StringSetting,
+			"verifyServerCertificate": wtf.BoolSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
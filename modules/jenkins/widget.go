@@ -1,27 +1,22 @@
 package jenkins
 
 import (
+	"context"
 	"fmt"
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
-	"os"
 	"strconv"
 )
 
-const HelpText = `
- Keyboard commands for Jenkins:
-
-   /: Show/hide this help window
-   j: Select the next job in the list
-   k: Select the previous job in the list
-   r: Refresh the data
-
-   arrow down: Select the next job in the list
-   arrow up:   Select the previous job in the list
-
-   return: Open the selected job in a browser
-`
+// Keys are the Jenkins widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next job in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous job in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected job in a browser"},
+	wtf.RefreshKeyBinding,
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the job"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -31,10 +26,10 @@ type Widget struct {
 	selected int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Jenkins", "jenkins", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Jenkins", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Jenkins", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
@@ -42,32 +37,49 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.View.SetScrollable(true)
 	widget.View.SetRegions(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
+	}
+
+	apiKey, err := widget.apiKey()
+	if err != nil {
+		return err
 	}
 
 	view, err := Create(
-		wtf.Config.UString("wtf.mods.jenkins.url"),
-		wtf.Config.UString("wtf.mods.jenkins.user"),
-		widget.apiKey(),
+		ctx,
+		widget.Key(),
+		wtf.Config.UString(widget.ConfigKey("url")),
+		wtf.Config.UString(widget.ConfigKey("user")),
+		apiKey,
 	)
-	widget.view = view
-
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
-		widget.View.SetText(err.Error())
+		return err
 	}
 
+	widget.view = view
 	widget.display()
+
+	return nil
+}
+
+// SelectedItem returns the selected job, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.view == nil || sel >= len(widget.view.Jobs) {
+		return nil
+	}
+
+	return widget.view.Jobs[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -81,40 +93,29 @@ func (widget *Widget) display() {
 
 	widget.View.Clear()
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s: [red]%s", widget.Name(), widget.view.Name)))
-	widget.View.SetText(widget.contentFrom(widget.view))
+	widget.Render(widget.contentFrom(widget.view))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
-func (widget *Widget) apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.jenkins.apiKey",
-		os.Getenv("WTF_JENKINS_API_KEY"),
-	)
+func (widget *Widget) apiKey() (string, error) {
+	return wtf.Credential(widget.ConfigKey("apiKey"), "WTF_JENKINS_API_KEY")
 }
 
-func (widget *Widget) contentFrom(view *View) string {
-	var str string
-	for idx, job := range view.Jobs {
-		str = str + fmt.Sprintf(
-			`["%d"][""][%s] [%s]%-6s[white]`,
-			idx,
-			widget.rowColor(idx),
-			widget.jobColor(&job),
-			job.Name,
-		)
-
-		str = str + "\n"
-	}
+func (widget *Widget) contentFrom(view *View) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
 
-	return str
-}
+	section := content.AddSection("")
+
+	for idx := range view.Jobs {
+		job := &view.Jobs[idx]
 
-func (widget *Widget) rowColor(idx int) string {
-	if widget.View.HasFocus() && (idx == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
+		row := section.AddRow(wtf.Cell{Text: job.Name, Color: widget.jobColor(job)})
+		row.Link = job.Url
+		row.Selectable = true
 	}
 
-	return wtf.DefaultRowColor()
+	return content
 }
 
 func (widget *Widget) jobColor(job *Job) string {
@@ -159,35 +160,10 @@ func (widget *Widget) unselect() {
 	widget.display()
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-	case "j":
-		widget.next()
-		return nil
-	case "k":
-		widget.prev()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyDown:
-		widget.next()
-		return nil
-	case tcell.KeyEnter:
-		widget.openJob()
-		return nil
-	case tcell.KeyEsc:
-		widget.unselect()
-		return event
-	case tcell.KeyUp:
-		widget.prev()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", widget.next)
+	widget.Keymap.On("prev", widget.prev)
+	widget.Keymap.On("open", widget.openJob)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("unselect", widget.unselect)
 }
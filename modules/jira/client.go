@@ -2,20 +2,19 @@ package jira
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
 
 	"github.com/wtfutil/wtf/wtf"
 )
 
-func IssuesFor(username string, projects []string, jql string) (*SearchResult, error) {
+func IssuesFor(ctx context.Context, configKey string, username string, projects []string, jql string) (*SearchResult, error) {
 	query := []string{}
 
 	var projQuery = getProjectQuery(projects)
@@ -37,13 +36,15 @@ func IssuesFor(username string, projects []string, jql string) (*SearchResult, e
 
 	url := fmt.Sprintf("/rest/api/2/search?%s", v.Encode())
 
-	resp, err := jiraRequest(url)
+	resp, err := jiraRequest(ctx, configKey, url)
 	if err != nil {
 		return &SearchResult{}, err
 	}
 
 	searchResult := &SearchResult{}
-	parseJson(searchResult, resp.Body)
+	if err := parseJson(searchResult, resp.Body); err != nil {
+		return nil, err
+	}
 
 	return searchResult, nil
 }
@@ -54,30 +55,30 @@ func buildJql(key string, value string) string {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.jira.apiKey",
-		os.Getenv("WTF_JIRA_API_KEY"),
-	)
+func apiKey(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "WTF_JIRA_API_KEY")
 }
 
-func jiraRequest(path string) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", wtf.Config.UString("wtf.mods.jira.domain"), path)
+func jiraRequest(ctx context.Context, configKey string, path string) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", wtf.Config.UString(wtf.ConfigKeyFor(configKey, "domain")), path)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(wtf.Config.UString("wtf.mods.jira.email"), apiKey())
-
-	verifyServerCertificate := wtf.Config.UBool("wtf.mods.jira.verifyServerCertificate", true)
-	httpClient := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: !verifyServerCertificate,
-		},
-		Proxy: http.ProxyFromEnvironment,
-	},
+	req = req.WithContext(ctx)
+	apiKey, err := apiKey(configKey)
+	if err != nil {
+		return nil, err
 	}
+
+	req.SetBasicAuth(wtf.Config.UString(wtf.ConfigKeyFor(configKey, "email")), apiKey)
+
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -90,10 +91,10 @@ func jiraRequest(path string) (*http.Response, error) {
 	return resp, nil
 }
 
-func parseJson(obj interface{}, text io.Reader) {
+func parseJson(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -102,9 +103,11 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
+
+	return nil
 }
 
 func getProjectQuery(projects []string) string {
@@ -0,0 +1,26 @@
+package jira
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "jira",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":                  wtf.StringSetting,
+			"domain":                  wtf.StringSetting,
+			"email":                   wtf.StringSetting,
+			"jql":                     wtf.StringSetting,
+			"project":                 wtf.AnySetting,
+			"username":                wtf.StringSetting,
+			"verifyServerCertificate": wtf.BoolSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,26 +1,22 @@
 package jira
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 	"strconv"
 )
 
-const HelpText = `
- Keyboard commands for Jira:
-
-   /: Show/hide this help window
-   j: Select the next item in the list
-   k: Select the previous item in the list
-
-   arrow down: Select the next item in the list
-   arrow up:   Select the previous item in the list
-
-   return: Open the selected issue in a browser
-`
+// Keys are the Jira widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next issue in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous issue in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected issue in a browser"},
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the issue"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -30,10 +26,10 @@ type Widget struct {
 	selected int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Jira", "jira", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Jira", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Jira", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
@@ -41,29 +37,40 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.View.SetScrollable(true)
 	widget.View.SetRegions(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	searchResult, err := IssuesFor(
-		wtf.Config.UString("wtf.mods.jira.username"),
-		getProjects(),
-		wtf.Config.UString("wtf.mods.jira.jql", ""),
+		ctx,
+		widget.Key(),
+		wtf.Config.UString(widget.ConfigKey("username")),
+		widget.getProjects(),
+		wtf.Config.UString(widget.ConfigKey("jql"), ""),
 	)
 
 	if err != nil {
-		widget.result = nil
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-	} else {
-		widget.result = searchResult
+		return err
 	}
 
+	widget.result = searchResult
 	widget.display()
+
+	return nil
+}
+
+// SelectedItem returns the selected Jira issue, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.result == nil || sel >= len(widget.result.Issues) {
+		return nil
+	}
+
+	return widget.result.Issues[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -74,11 +81,11 @@ func (widget *Widget) display() {
 	}
 	widget.View.SetWrap(false)
 
-	str := fmt.Sprintf("%s- [green]%s[white]", widget.Name(), wtf.Config.UString("wtf.mods.jira.project"))
+	str := fmt.Sprintf("%s- [green]%s[white]", widget.Name(), wtf.Config.UString(widget.ConfigKey("project")))
 
 	widget.View.Clear()
 	widget.View.SetTitle(widget.ContextualTitle(str))
-	widget.View.SetText(fmt.Sprintf("%s", widget.contentFrom(widget.result)))
+	widget.Render(widget.contentFrom(widget.result))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
@@ -99,8 +106,7 @@ func (widget *Widget) prev() {
 func (widget *Widget) openItem() {
 	sel := widget.selected
 	if sel >= 0 && widget.result != nil && sel < len(widget.result.Issues) {
-		issue := &widget.result.Issues[widget.selected]
-		wtf.OpenFile(wtf.Config.UString("wtf.mods.jira.domain") + "/browse/" + issue.Key)
+		wtf.OpenFile(widget.issueURL(&widget.result.Issues[widget.selected]))
 	}
 }
 
@@ -108,36 +114,30 @@ func (widget *Widget) unselect() {
 	widget.selected = -1
 }
 
-func (widget *Widget) contentFrom(searchResult *SearchResult) string {
-	str := " [red]Assigned Issues[white]\n"
-
-	for idx, issue := range searchResult.Issues {
-		fmtStr := fmt.Sprintf(
-			`["%d"][""][%s] [%s]%-6s[white] [green]%-10s[white] [yellow][%s][white] [%s]%s`,
-			idx,
-			widget.rowColor(idx),
-			widget.issueTypeColor(&issue),
-			issue.IssueFields.IssueType.Name,
-			issue.Key,
-			issue.IssueFields.IssueStatus.IName,
-			widget.rowColor(idx),
-			issue.IssueFields.Summary,
-		)
+func (widget *Widget) contentFrom(searchResult *SearchResult) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
+
+	section := content.AddSection("Assigned Issues")
 
-		_, _, w, _ := widget.View.GetInnerRect()
-		fmtStr = fmtStr + wtf.PadRow(len(issue.IssueFields.Summary), w+1)
+	for idx := range searchResult.Issues {
+		issue := &searchResult.Issues[idx]
 
-		str = str + fmtStr + "\n"
+		row := section.AddRow(
+			wtf.Cell{Text: issue.IssueFields.IssueType.Name, Color: widget.issueTypeColor(issue)},
+			wtf.Cell{Text: issue.Key, Color: "green"},
+			wtf.Cell{Text: "[" + issue.IssueFields.IssueStatus.IName + "]", Color: "yellow"},
+			wtf.Text(issue.IssueFields.Summary),
+		)
+		row.Link = widget.issueURL(issue)
+		row.Selectable = true
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) rowColor(idx int) string {
-	if widget.View.HasFocus() && (idx == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
-	}
-	return wtf.RowColor("jira", idx)
+func (widget *Widget) issueURL(issue *Issue) string {
+	return wtf.Config.UString(widget.ConfigKey("domain")) + "/browse/" + issue.Key
 }
 
 func (widget *Widget) issueTypeColor(issue *Issue) string {
@@ -153,9 +153,9 @@ func (widget *Widget) issueTypeColor(issue *Issue) string {
 	}
 }
 
-func getProjects() []string {
+func (widget *Widget) getProjects() []string {
 	// see if project is set to a single string
-	configPath := "wtf.mods.jira.project"
+	configPath := widget.ConfigKey("project")
 	singleProject, err := wtf.Config.String(configPath)
 	if err == nil {
 		return []string{singleProject}
@@ -171,43 +171,18 @@ func getProjects() []string {
 	return ret
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-	case "j":
-		// Select the next item down
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", func() {
 		widget.next()
 		widget.display()
-		return nil
-	case "k":
-		// Select the next item up
+	})
+	widget.Keymap.On("prev", func() {
 		widget.prev()
 		widget.display()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyDown:
-		// Select the next item down
-		widget.next()
-		widget.display()
-		return nil
-	case tcell.KeyEnter:
-		widget.openItem()
-		return nil
-	case tcell.KeyEsc:
-		// Unselect the current row
+	})
+	widget.Keymap.On("open", widget.openItem)
+	widget.Keymap.On("unselect", func() {
 		widget.unselect()
 		widget.display()
-		return event
-	case tcell.KeyUp:
-		// Select the next item up
-		widget.prev()
-		widget.display()
-		return nil
-	default:
-		// Pass it along
-		return event
-	}
+	})
 }
@@ -17,10 +17,12 @@ type MercurialRepo struct {
 	Commits      []string
 	Repository   string
 	Path         string
+
+	configKey string
 }
 
-func NewMercurialRepo(repoPath string) *MercurialRepo {
-	repo := MercurialRepo{Path: repoPath}
+func NewMercurialRepo(repoPath string, configKey string) *MercurialRepo {
+	repo := MercurialRepo{Path: repoPath, configKey: configKey}
 
 	repo.Branch = strings.TrimSpace(repo.branch())
 	repo.Bookmark = strings.TrimSpace(repo.bookmark())
@@ -62,9 +64,9 @@ func (repo *MercurialRepo) changedFiles() []string {
 }
 
 func (repo *MercurialRepo) commits() []string {
-	numStr := fmt.Sprintf("-l %d", wtf.Config.UInt("wtf.mods.mercurial.commitCount", 10))
+	numStr := fmt.Sprintf("-l %d", wtf.Config.UInt(wtf.ConfigKeyFor(repo.configKey, "commitCount"), 10))
 
-	commitFormat := wtf.Config.UString("wtf.mods.mercurial.commitFormat", "[forestgreen]{rev}:{phase} [white]{desc|firstline|strip} [grey]{author|person} {date|age}[white]")
+	commitFormat := wtf.Config.UString(wtf.ConfigKeyFor(repo.configKey, "commitFormat"), "[forestgreen]{rev}:{phase} [white]{desc|firstline|strip} [grey]{author|person} {date|age}[white]")
 	commitStr := fmt.Sprintf("--template=\"%s\n\"", commitFormat)
 
 	arg := []string{"log", repo.repoPath(), numStr, commitStr}
@@ -0,0 +1,22 @@
+package mercurial
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "mercurial",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"commitCount":  wtf.IntSetting,
+			"commitFormat": wtf.StringSetting,
+			"repositories": wtf.ListSetting,
+			"repository":   wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+	})
+}
@@ -1,23 +1,20 @@
 package mercurial
 
 import (
+	"context"
 	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-  Keyboard commands for Mercurial:
-
-    /: Show/hide this help window
-    c: Checkout to branch
-    h: Previous mercurial repository
-    l: Next mercurial repository
-    p: Pull current mercurial repository
-
-    arrow left:  Previous mercurial repository
-    arrow right: Next mercurial repository
-`
+// Keys are the Mercurial widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next mercurial repository"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous mercurial repository"},
+	{Action: "checkout", Keys: []string{"c"}, Help: "Checkout to branch"},
+	{Action: "pull", Keys: []string{"p"}, Help: "Pull the current mercurial repository"},
+}
 
 const offscreen = -1000
 const modalWidth = 80
@@ -33,11 +30,11 @@ type Widget struct {
 	pages *tview.Pages
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, HelpText),
-		MultiSourceWidget: wtf.NewMultiSourceWidget("mercurial", "repository", "repositories"),
-		TextWidget:        wtf.NewTextWidget(app, "Mercurial", "mercurial", true),
+		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Mercurial", configKey, Keys)),
+		MultiSourceWidget: wtf.NewMultiSourceWidget(configKey, "repository", "repositories"),
+		TextWidget:        wtf.NewTextWidget(app, "Mercurial", configKey, true),
 
 		app:   app,
 		pages: pages,
@@ -47,7 +44,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	widget.SetDisplayFunction(widget.display)
 
 	widget.HelpfulWidget.SetView(widget.View)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
@@ -64,7 +62,7 @@ func (widget *Widget) Checkout() {
 		widget.pages.RemovePage("modal")
 		widget.app.SetFocus(widget.View)
 		widget.display()
-		widget.Refresh()
+		widget.RequestRefresh()
 	}
 
 	widget.addButtons(form, checkoutFctn)
@@ -74,15 +72,17 @@ func (widget *Widget) Checkout() {
 func (widget *Widget) Pull() {
 	repoToPull := widget.Data[widget.Idx]
 	repoToPull.pull()
-	widget.Refresh()
+	widget.RequestRefresh()
 
 }
 
-func (widget *Widget) Refresh() {
-	repoPaths := wtf.ToStrs(wtf.Config.UList("wtf.mods.mercurial.repositories"))
+func (widget *Widget) Refresh(ctx context.Context) error {
+	repoPaths := wtf.ToStrs(wtf.Config.UList(widget.ConfigKey("repositories")))
 
 	widget.Data = widget.mercurialRepos(repoPaths)
 	widget.display()
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -156,40 +156,16 @@ func (widget *Widget) mercurialRepos(repoPaths []string) []*MercurialRepo {
 	repos := []*MercurialRepo{}
 
 	for _, repoPath := range repoPaths {
-		repo := NewMercurialRepo(repoPath)
+		repo := NewMercurialRepo(repoPath, widget.Key())
 		repos = append(repos, repo)
 	}
 
 	return repos
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	case "p":
-		widget.Pull()
-		return nil
-	case "c":
-		widget.Checkout()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
+	widget.Keymap.On("checkout", widget.Checkout)
+	widget.Keymap.On("pull", widget.Pull)
 }
@@ -1,9 +1,10 @@
 package nbascore
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 	"io/ioutil"
@@ -12,12 +13,13 @@ import (
 	"time"
 )
 
-const HelpText = `
- Keyboard commands for NBA Score:
-   h: Go to previous day
-   l: Go to next day
-   c: Go back to current day
-`
+// Keys are the NBA Score widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "prevDay", Keys: []string{"h", "left"}, Help: "Go to the previous day"},
+	{Action: "nextDay", Keys: []string{"l", "right"}, Help: "Go to the next day"},
+	{Action: "today", Keys: []string{"c"}, Help: "Go back to the current day"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -25,63 +27,71 @@ type Widget struct {
 	app      *tview.Application
 	pages    *tview.Pages
 	language string
-	result   string
 }
 
 var offset = 0
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "NBA Score", "nbascore", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("NBA Score", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "NBA Score", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
 	widget.TextWidget.RefreshInt = 15
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 	widget.View.SetScrollable(true)
 
 	return &widget
 }
 
-func (widget *Widget) Refresh() {
-	widget.nbascore()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if err := widget.nbascore(ctx); err != nil {
+		return err
+	}
 
 	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
+
+	return nil
 }
 
-func (widget *Widget) nbascore() {
+func (widget *Widget) nbascore(ctx context.Context) error {
 	cur := time.Now().AddDate(0, 0, offset) // Go back/forward offset days
 	curString := cur.Format("20060102")     // Need 20060102 format to feed to api
-	client := &http.Client{}
+	client, err := wtf.HTTPClient(widget.Key())
+	if err != nil {
+		return err
+	}
+
 	req, err := http.NewRequest("GET", "http://data.nba.net/10s/prod/v1/"+curString+"/scoreboard.json", nil)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("Accept-Language", widget.language)
 	req.Header.Set("User-Agent", "curl")
 	response, err := client.Do(req)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 	defer response.Body.Close()
 	if response.StatusCode != 200 {
-		widget.result = err.Error()
-		return
+		return errors.New(response.Status)
 	} // Get data from data.nba.net and check if successful
 
 	contents, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 	result := map[string]interface{}{}
-	json.Unmarshal(contents, &result)
-	allGame := "" // store result in allgame
-	allGame += (" " + "[red]" + (cur.Format("20060102") + "\n") + "[white]")
+	if err := json.Unmarshal(contents, &result); err != nil {
+		return err
+	}
+	content := wtf.NewContent()
+	games := content.AddSection(cur.Format("20060102"))
+	games.TitleColor = "red"
 	for _, game := range result["games"].([]interface{}) {
 		vTeam, hTeam, vScore, hScore := "", "", "", ""
 		quarter := 0.
@@ -115,47 +125,44 @@ func (widget *Widget) nbascore() {
 		}
 		vNum, _ := strconv.Atoi(vScore)
 		hNum, _ := strconv.Atoi(hScore)
-		hColor := ""
+		vColor, hColor := "white", "white"
 		if quarter != 0 { // Compare the score
-			if vNum > hNum {
-				vTeam = "[orange]" + vTeam
-			} else if hNum > vNum {
-				// hScore = "[orange]" + hScore
-				hColor = "[orange]" // For correct padding
-				hTeam = hTeam + "[white]"
-			} else {
-				vTeam = "[orange]" + vTeam
-				hColor = "[orange]"
-				hTeam = hTeam + "[white]"
+			if vNum >= hNum {
+				vColor = "orange"
+			}
+			if hNum >= vNum {
+				hColor = "orange"
 			}
 		}
-		qColor := "[white]"
+		qColor := "white"
 		if activate == true {
-			qColor = "[sandybrown]"
+			qColor = "sandybrown"
 		}
-		allGame += fmt.Sprintf("%s%5s%v[white] %s %3s [white]vs %s%-3s %s\n", qColor, "Q", quarter, vTeam, vScore, hColor, hScore, hTeam) // Format the score and store in allgame
+		games.AddRow(
+			wtf.Cell{Text: fmt.Sprintf("Q%v", quarter), Align: tview.AlignRight, Color: qColor},
+			wtf.Cell{Text: vTeam, Color: vColor},
+			wtf.Cell{Text: vScore, Align: tview.AlignRight},
+			wtf.Text("vs"),
+			wtf.Cell{Text: hScore, Color: hColor},
+			wtf.Cell{Text: hTeam, Color: hColor},
+		)
 	}
-	widget.View.SetText(allGame)
+	widget.Render(content)
 
+	return nil
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch (string)(event.Rune()) {
-	case "h":
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("prevDay", func() {
 		offset--
-		widget.Refresh()
-		return nil
-	case "l":
+		widget.RequestRefresh()
+	})
+	widget.Keymap.On("nextDay", func() {
 		offset++
-		widget.Refresh()
-		return nil
-	case "c":
+		widget.RequestRefresh()
+	})
+	widget.Keymap.On("today", func() {
 		offset = 0
-		widget.Refresh()
-		return nil
-	case "/":
-		widget.ShowHelp()
-		return nil
-	}
-	return nil
+		widget.RequestRefresh()
+	})
 }
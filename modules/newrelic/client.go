@@ -1,6 +1,8 @@
 package newrelic
 
 import (
+	"net/http"
+
 	nr "github.com/yfronto/newrelic"
 )
 
@@ -9,10 +11,10 @@ type Client struct {
 	nrClient      *nr.Client
 }
 
-func NewClient(apiKey string, applicationId int) *Client {
+func NewClient(httpClient *http.Client, apiKey string, applicationId int) *Client {
 	return &Client{
 		applicationId: applicationId,
-		nrClient:      nr.NewClient(apiKey),
+		nrClient:      nr.NewWithHTTPClient(apiKey, httpClient),
 	}
 
 }
@@ -0,0 +1,21 @@
+package newrelic
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "newrelic",
+		Settings: wtf.ConfigSchema{
+			"apiKey":        wtf.StringSetting,
+			"applicationId": wtf.IntSetting,
+			"deployCount":   wtf.IntSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
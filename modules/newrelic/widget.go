@@ -1,8 +1,8 @@
 package newrelic
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -14,10 +14,9 @@ type Widget struct {
 	client *Client
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "New Relic", "newrelic", false),
-		client:     NewClient(apiKey(), wtf.Config.UInt("wtf.mods.newrelic.applicationId")),
+		TextWidget: wtf.NewTextWidget(app, "New Relic", configKey, false),
 	}
 
 	return &widget
@@ -25,37 +24,44 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	app, appErr := widget.client.Application()
-	deploys, depErr := widget.client.Deployments()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	apiKey, err := widget.apiKey()
+	if err != nil {
+		return err
+	}
 
-	appName := "error"
-	if appErr == nil {
-		appName = app.Name
+	// The newrelic library doesn't take a context, so the client makes every request with it
+	httpClient, err := wtf.HTTPClientWithContext(ctx, widget.Key())
+	if err != nil {
+		return err
 	}
 
-	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - [green]%s[white]", widget.Name(), appName)))
-	widget.View.Clear()
+	widget.client = NewClient(httpClient, apiKey, wtf.Config.UInt(widget.ConfigKey("applicationId")))
+
+	app, err := widget.client.Application()
+	if err != nil {
+		return err
+	}
 
-	var content string
-	if depErr != nil {
-		widget.View.SetWrap(true)
-		content = depErr.Error()
-	} else {
-		widget.View.SetWrap(false)
-		content = widget.contentFrom(deploys)
+	deploys, err := widget.client.Deployments()
+	if err != nil {
+		return err
 	}
 
-	widget.View.SetText(content)
+	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - [green]%s[white]", widget.Name(), app.Name)))
+	widget.View.Clear()
+
+	widget.View.SetWrap(false)
+	widget.Render(widget.contentFrom(deploys))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(deploys []nr.ApplicationDeployment) string {
-	str := fmt.Sprintf(
-		" %s\n",
-		"[red]Latest Deploys[white]",
-	)
+func (widget *Widget) contentFrom(deploys []nr.ApplicationDeployment) *wtf.Content {
+	content := wtf.NewContent()
+	section := content.AddSection("Latest Deploys")
 
 	revisions := []string{}
 
@@ -71,28 +77,23 @@ func (widget *Widget) contentFrom(deploys []nr.ApplicationDeployment) string {
 				revLen = len(deploy.Revision)
 			}
 
-			str = str + fmt.Sprintf(
-				" [green]%s[%s] %s %-.16s[white]\n",
-				deploy.Revision[0:revLen],
-				lineColor,
-				deploy.Timestamp.Format("Jan 02 15:04 MST"),
-				wtf.NameFromEmail(deploy.User),
+			section.AddRow(
+				wtf.Cell{Text: deploy.Revision[0:revLen], Color: "green"},
+				wtf.Cell{Text: deploy.Timestamp.Format("Jan 02 15:04 MST"), Color: lineColor},
+				wtf.Cell{Text: fmt.Sprintf("%.16s", wtf.NameFromEmail(deploy.User)), Color: lineColor},
 			)
 
 			revisions = append(revisions, deploy.Revision)
 
-			if len(revisions) == wtf.Config.UInt("wtf.mods.newrelic.deployCount", 5) {
+			if len(revisions) == wtf.Config.UInt(widget.ConfigKey("deployCount"), 5) {
 				break
 			}
 		}
 	}
 
-	return str
+	return content
 }
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.newrelic.apiKey",
-		os.Getenv("WTF_NEW_RELIC_API_KEY"),
-	)
+func (widget *Widget) apiKey() (string, error) {
+	return wtf.Credential(widget.ConfigKey("apiKey"), "WTF_NEW_RELIC_API_KEY")
 }
@@ -1,10 +1,10 @@
 package opsgenie
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -29,11 +29,16 @@ type Parent struct {
 
 /* -------------------- Exported Functions -------------------- */
 
-func Fetch(scheduleIdentifierType string, schedules []string) ([]*OnCallResponse, error) {
+func Fetch(ctx context.Context, configKey string, scheduleIdentifierType string, schedules []string) ([]*OnCallResponse, error) {
+	apiKey, err := apiKey(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	agregatedResponses := []*OnCallResponse{}
 	for _, sched := range schedules {
 		scheduleUrl := fmt.Sprintf("https://api.opsgenie.com/v2/schedules/%s/on-calls?scheduleIdentifierType=%s&flat=true", sched, scheduleIdentifierType)
-		response, err := opsGenieRequest(scheduleUrl, apiKey())
+		response, err := opsGenieRequest(ctx, configKey, scheduleUrl, apiKey)
 		agregatedResponses = append(agregatedResponses, response)
 		if err != nil {
 			return nil, err
@@ -44,22 +49,23 @@ func Fetch(scheduleIdentifierType string, schedules []string) ([]*OnCallResponse
 
 /* -------------------- Unexported Functions -------------------- */
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.opsgenie.apiKey",
-		os.Getenv("WTF_OPS_GENIE_API_KEY"),
-	)
+func apiKey(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "WTF_OPS_GENIE_API_KEY")
 }
 
-func opsGenieRequest(url string, apiKey string) (*OnCallResponse, error) {
+func opsGenieRequest(ctx context.Context, configKey string, url string, apiKey string) (*OnCallResponse, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", apiKey))
 
-	client := &http.Client{}
+	client, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
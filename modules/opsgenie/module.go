@@ -0,0 +1,22 @@
+package opsgenie
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "opsgenie",
+		Settings: wtf.ConfigSchema{
+			"apiKey":                 wtf.StringSetting,
+			"displayEmpty":           wtf.BoolSetting,
+			"schedule":               wtf.AnySetting,
+			"scheduleIdentifierType": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,7 +1,7 @@
 package opsgenie
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	"github.com/rivo/tview"
@@ -12,9 +12,9 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "OpsGenie", "opsgenie", false),
+		TextWidget: wtf.NewTextWidget(app, "OpsGenie", configKey, false),
 	}
 
 	return &widget
@@ -22,30 +22,30 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	data, err := Fetch(
-		wtf.Config.UString("wtf.mods.opsgenie.scheduleIdentifierType"),
-		getSchedules(),
+		ctx,
+		widget.Key(),
+		wtf.Config.UString(widget.ConfigKey("scheduleIdentifierType")),
+		widget.getSchedules(),
 	)
-	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
-
-	var content string
 	if err != nil {
-		widget.View.SetWrap(true)
-		content = err.Error()
-	} else {
-		widget.View.SetWrap(false)
-		content = widget.contentFrom(data)
+		return err
 	}
 
-	widget.View.SetText(content)
+	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
+
+	widget.View.SetWrap(false)
+	widget.Render(widget.contentFrom(data))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func getSchedules() []string {
+func (widget *Widget) getSchedules() []string {
 	// see if schedule is set to a single string
-	configPath := "wtf.mods.opsgenie.schedule"
+	configPath := widget.ConfigKey("schedule")
 	singleSchedule, err := wtf.Config.String(configPath)
 	if err == nil {
 		return []string{singleSchedule}
@@ -61,31 +61,29 @@ func getSchedules() []string {
 	return ret
 }
 
-func (widget *Widget) contentFrom(onCallResponses []*OnCallResponse) string {
-	str := ""
+func (widget *Widget) contentFrom(onCallResponses []*OnCallResponse) *wtf.Content {
+	content := wtf.NewContent()
 
-	displayEmpty := wtf.Config.UBool("wtf.mods.opsgenie.displayEmpty", true)
+	displayEmpty := wtf.Config.UBool(widget.ConfigKey("displayEmpty"), true)
 
 	for _, data := range onCallResponses {
 		if (len(data.OnCallData.Recipients) == 0) && (displayEmpty == false) {
 			continue
 		}
 
-		var msg string
+		section := content.AddSection("")
+		section.AddRow(wtf.Cell{Text: widget.cleanScheduleName(data.OnCallData.Parent.Name), Color: "green"})
+
 		if len(data.OnCallData.Recipients) == 0 {
-			msg = " [gray]no one[white]\n\n"
+			section.AddRow(wtf.Cell{Text: "no one", Color: "gray"})
 		} else {
-			msg = fmt.Sprintf(" %s\n\n", strings.Join(wtf.NamesFromEmails(data.OnCallData.Recipients), ", "))
+			section.AddRow(wtf.Text(strings.Join(wtf.NamesFromEmails(data.OnCallData.Recipients), ", ")))
 		}
-
-		str = str + widget.cleanScheduleName(data.OnCallData.Parent.Name)
-		str = str + msg
 	}
 
-	return str
+	return content
 }
 
 func (widget *Widget) cleanScheduleName(schedule string) string {
-	cleanedName := strings.Replace(schedule, "_", " ", -1)
-	return fmt.Sprintf(" [green]%s[white]\n", cleanedName)
+	return strings.Replace(schedule, "_", " ", -1)
 }
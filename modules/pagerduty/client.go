@@ -1,73 +1,102 @@
 package pagerduty
 
 import (
-	"os"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/google/go-querystring/query"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-// GetOnCalls returns a list of people currently on call
-func GetOnCalls() ([]pagerduty.OnCall, error) {
-	client := pagerduty.NewClient(apiKey())
+// The pagerduty library always uses the default HTTP client and can't be cancelled, so its
+// requests are made here with the widget's client instead, into the library's types
+const apiEndpoint = "https://api.pagerduty.com"
 
+// GetOnCalls returns a list of people currently on call
+func GetOnCalls(ctx context.Context, configKey string, apiKey string) ([]pagerduty.OnCall, error) {
 	var results []pagerduty.OnCall
 
 	var queryOpts pagerduty.ListOnCallOptions
 	queryOpts.Since = time.Now().Format("2006-01-02T15:04:05Z07:00")
 	queryOpts.Until = time.Now().Format("2006-01-02T15:04:05Z07:00")
 
-	oncalls, err := client.ListOnCalls(queryOpts)
-	if err != nil {
-		return nil, err
-	}
-
-	results = append(results, oncalls.OnCalls...)
-
-	for oncalls.APIListObject.More == true {
-		queryOpts.APIListObject.Offset = oncalls.APIListObject.Offset
-		oncalls, err = client.ListOnCalls(queryOpts)
-		if err != nil {
+	for {
+		var oncalls pagerduty.ListOnCallsResponse
+		if err := pagerDutyRequest(ctx, configKey, apiKey, "/oncalls", queryOpts, &oncalls); err != nil {
 			return nil, err
 		}
+
 		results = append(results, oncalls.OnCalls...)
-	}
 
-	return results, nil
+		if !oncalls.APIListObject.More || len(oncalls.OnCalls) == 0 {
+			return results, nil
+		}
+
+		queryOpts.APIListObject.Offset = oncalls.APIListObject.Offset + uint(len(oncalls.OnCalls))
+	}
 }
 
 // GetIncidents returns a list of people currently on call
-func GetIncidents() ([]pagerduty.Incident, error) {
-	client := pagerduty.NewClient(apiKey())
-
+func GetIncidents(ctx context.Context, configKey string, apiKey string) ([]pagerduty.Incident, error) {
 	var results []pagerduty.Incident
 
 	var queryOpts pagerduty.ListIncidentsOptions
 	queryOpts.DateRange = "all"
 	queryOpts.Statuses = []string{"triggered", "acknowledged"}
 
-	items, err := client.ListIncidents(queryOpts)
-	if err != nil {
-		return nil, err
-	}
-	results = append(results, items.Incidents...)
-
-	for items.APIListObject.More == true {
-		queryOpts.APIListObject.Offset = items.APIListObject.Offset
-		items, err = client.ListIncidents(queryOpts)
-		if err != nil {
+	for {
+		var items pagerduty.ListIncidentsResponse
+		if err := pagerDutyRequest(ctx, configKey, apiKey, "/incidents", queryOpts, &items); err != nil {
 			return nil, err
 		}
+
 		results = append(results, items.Incidents...)
-	}
 
-	return results, nil
+		if !items.APIListObject.More || len(items.Incidents) == 0 {
+			return results, nil
+		}
+
+		queryOpts.APIListObject.Offset = items.APIListObject.Offset + uint(len(items.Incidents))
+	}
 }
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.pagerduty.apiKey",
-		os.Getenv("WTF_PAGERDUTY_API_KEY"),
-	)
+/* -------------------- Unexported Functions -------------------- */
+
+// pagerDutyRequest gets the path, with the options as its query, and decodes the response into
+// result
+func pagerDutyRequest(ctx context.Context, configKey string, apiKey string, path string, opts interface{}, result interface{}) error {
+	params, err := query.Values(opts)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", apiEndpoint+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+apiKey)
+
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
 }
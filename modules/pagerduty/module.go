@@ -0,0 +1,23 @@
+package pagerduty
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "pagerduty",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":           wtf.StringSetting,
+			"escalationFilter": wtf.ListSetting,
+			"showIncidents":    wtf.BoolSetting,
+			"showSchedules":    wtf.BoolSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
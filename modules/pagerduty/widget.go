@@ -1,81 +1,136 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strconv"
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
+// Keys are the PagerDuty widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next incident in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous incident in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected incident in a browser"},
+	wtf.RefreshKeyBinding,
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the incident"},
+}
+
 type Widget struct {
+	wtf.HelpfulWidget
 	wtf.TextWidget
+
+	incidents []pagerduty.Incident
+	onCalls   []pagerduty.OnCall
+	selected  int
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "PagerDuty", "pagerduty", false),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("PagerDuty", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "PagerDuty", configKey, true),
 	}
 
+	widget.HelpfulWidget.SetView(widget.View)
+	widget.unselect()
+
+	widget.View.SetRegions(true)
+	widget.View.SetScrollable(true)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
+
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	var onCalls []pagerduty.OnCall
 	var incidents []pagerduty.Incident
 
-	var err1 error
-	var err2 error
+	apiKey, err := widget.apiKey()
+	if err != nil {
+		return err
+	}
 
-	if wtf.Config.UBool("wtf.mods.pagerduty.showSchedules", true) {
-		onCalls, err1 = GetOnCalls()
+	if wtf.Config.UBool(widget.ConfigKey("showSchedules"), true) {
+		onCalls, err = GetOnCalls(ctx, widget.Key(), apiKey)
+		if err != nil {
+			return err
+		}
 	}
 
-	if wtf.Config.UBool("wtf.mods.pagerduty.showIncidents") {
-		incidents, err2 = GetIncidents()
+	if wtf.Config.UBool(widget.ConfigKey("showIncidents")) {
+		incidents, err = GetIncidents(ctx, widget.Key(), apiKey)
+		if err != nil {
+			return err
+		}
 	}
 
-	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s", widget.Name())))
-	widget.View.Clear()
+	widget.incidents = incidents
+	widget.onCalls = onCalls
+	if widget.selected >= len(incidents) {
+		widget.selected = -1
+	}
 
-	var content string
-	if err1 != nil || err2 != nil {
-		widget.View.SetWrap(true)
-		if err1 != nil {
-			content = content + err1.Error()
-		}
-		if err2 != nil {
-			content = content + err2.Error()
-		}
-	} else {
-		widget.View.SetWrap(false)
-		content = widget.contentFrom(onCalls, incidents)
+	widget.display()
+
+	notifications := []wtf.Notification{}
+	for _, incident := range incidents {
+		notifications = append(notifications, wtf.Notification{
+			ID:      incident.ID,
+			Title:   fmt.Sprintf("PagerDuty incident #%d", incident.IncidentNumber),
+			Message: incident.Summary,
+		})
+	}
+	widget.NotifyChanges(notifications)
+
+	return nil
+}
+
+// SelectedItem returns the selected incident, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || sel >= len(widget.incidents) {
+		return nil
 	}
 
-	widget.View.SetText(content)
+	return widget.incidents[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerduty.Incident) string {
-	var str string
+func (widget *Widget) apiKey() (string, error) {
+	return wtf.Credential(widget.ConfigKey("apiKey"), "WTF_PAGERDUTY_API_KEY")
+}
+
+func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerduty.Incident) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
 
 	if len(incidents) > 0 {
-		str = str + "[yellow]Incidents[white]\n"
+		section := content.AddSection("Incidents")
+		section.TitleColor = "yellow"
+
 		for _, incident := range incidents {
-			str = str + fmt.Sprintf("[red]%s[white]\n", incident.Summary)
-			str = str + fmt.Sprintf("Status: %s\n", incident.Status)
-			str = str + fmt.Sprintf("Service: %s\n", incident.Service.Summary)
-			str = str + fmt.Sprintf("Escalation: %s\n", incident.EscalationPolicy.Summary)
+			row := section.AddRow(wtf.Cell{Text: incident.Summary, Status: wtf.StatusError})
+			row.Link = incident.HTMLURL
+			row.Selectable = true
+
+			section.AddRow(wtf.Text("Status: " + incident.Status))
+			section.AddRow(wtf.Text("Service: " + incident.Service.Summary))
+			section.AddRow(wtf.Text("Escalation: " + incident.EscalationPolicy.Summary))
 		}
 	}
 
 	tree := make(map[string][]pagerduty.OnCall)
 
-	filtering := wtf.Config.UList("wtf.mods.pagerduty.escalationFilter")
+	filtering := wtf.Config.UList(widget.ConfigKey("escalationFilter"))
 	filter := make(map[string]bool)
 	for _, item := range filtering {
 		filter[item.(string)] = true
@@ -97,17 +152,66 @@ func (widget *Widget) contentFrom(onCalls []pagerduty.OnCall, incidents []pagerd
 	sort.Strings(keys)
 
 	if len(keys) > 0 {
-		str = str + "[yellow]Schedules[white]\n"
+		section := content.AddSection("Schedules")
+		section.TitleColor = "yellow"
+
 		// Print out policies, and escalation order of users
 		for _, key := range keys {
-			str = str + fmt.Sprintf("[red]%s\n", key)
+			section.AddRow(wtf.Cell{Text: key, Color: "red"})
 			values := tree[key]
 			sort.Sort(ByEscalationLevel(values))
 			for _, item := range values {
-				str = str + fmt.Sprintf("[white]%d - %s\n", item.EscalationLevel, item.User.Summary)
+				section.AddRow(wtf.Text(fmt.Sprintf("%d - %s", item.EscalationLevel, item.User.Summary)))
 			}
 		}
 	}
 
-	return str
+	return content
+}
+
+func (widget *Widget) display() {
+	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
+	widget.View.Clear()
+
+	widget.View.SetWrap(false)
+	widget.Render(widget.contentFrom(widget.onCalls, widget.incidents))
+	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
+}
+
+func (widget *Widget) next() {
+	widget.selected++
+	if widget.selected >= len(widget.incidents) {
+		widget.selected = 0
+	}
+
+	widget.display()
+}
+
+func (widget *Widget) prev() {
+	widget.selected--
+	if widget.selected < 0 {
+		widget.selected = len(widget.incidents) - 1
+	}
+
+	widget.display()
+}
+
+func (widget *Widget) openIncident() {
+	sel := widget.selected
+	if sel >= 0 && sel < len(widget.incidents) {
+		wtf.OpenFile(widget.incidents[sel].HTMLURL)
+	}
+}
+
+func (widget *Widget) unselect() {
+	widget.selected = -1
+	widget.display()
+}
+
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", widget.next)
+	widget.Keymap.On("prev", widget.prev)
+	widget.Keymap.On("open", widget.openIncident)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("unselect", widget.unselect)
 }
@@ -1,6 +1,7 @@
 package power
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/rivo/tview"
@@ -13,9 +14,9 @@ type Widget struct {
 	Battery *Battery
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Power", "power", false),
+		TextWidget: wtf.NewTextWidget(app, "Power", configKey, false),
 		Battery:    NewBattery(),
 	}
 
@@ -24,7 +25,7 @@ func NewWidget(app *tview.Application) *Widget {
 	return &widget
 }
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	widget.Battery.Refresh()
 
 	content := ""
@@ -32,5 +33,7 @@ func (widget *Widget) Refresh() {
 	content = content + "\n"
 	content = content + widget.Battery.String()
 
-	widget.View.SetText(content)
+	widget.SetContent(content)
+
+	return nil
 }
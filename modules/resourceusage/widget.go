@@ -2,6 +2,7 @@ package resourceusage
 
 import (
 	"code.cloudfoundry.org/bytefmt"
+	"context"
 	"fmt"
 	"github.com/rivo/tview"
 	"github.com/shirou/gopsutil/cpu"
@@ -20,9 +21,9 @@ type Widget struct {
 }
 
 // NewWidget Make new instance of widget
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		BarGraph: wtf.NewBarGraph(app, "Resource Usage", "resourceusage", false),
+		BarGraph: wtf.NewBarGraph(app, "Resource Usage", configKey, false),
 	}
 
 	widget.View.SetWrap(false)
@@ -102,16 +103,17 @@ func MakeGraph(widget *Widget) {
 }
 
 // Refresh & update after interval time
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
 	widget.View.Clear()
 
 	display(widget)
 
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
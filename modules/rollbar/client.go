@@ -2,6 +2,7 @@ package rollbar
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,18 +13,24 @@ import (
 	"github.com/wtfutil/wtf/wtf"
 )
 
-func CurrentActiveItems() (*ActiveItems, error) {
+func CurrentActiveItems(ctx context.Context, configKey string) (*ActiveItems, error) {
 	items := &ActiveItems{}
 
-	accessToken := wtf.Config.UString("wtf.mods.rollbar.accessToken", "")
+	accessToken, err := wtf.Credential(wtf.ConfigKeyFor(configKey, "accessToken"), "WTF_ROLLBAR_ACCESS_TOKEN")
+	if err != nil {
+		return items, err
+	}
+
 	rollbarAPIURL.Host = "api.rollbar.com"
 	rollbarAPIURL.Path = "/api/1/items"
-	resp, err := rollbarItemRequest(accessToken)
+	resp, err := rollbarItemRequest(ctx, configKey, accessToken)
 	if err != nil {
 		return items, err
 	}
 
-	parseJSON(&items, resp.Body)
+	if err := parseJSON(&items, resp.Body); err != nil {
+		return items, err
+	}
 
 	return items, nil
 }
@@ -34,22 +41,30 @@ var (
 	rollbarAPIURL = &url.URL{Scheme: "https"}
 )
 
-func rollbarItemRequest(accessToken string) (*http.Response, error) {
+func rollbarItemRequest(ctx context.Context, configKey string, accessToken string) (*http.Response, error) {
 	params := url.Values{}
 	params.Add("access_token", accessToken)
-	userName := wtf.Config.UString("wtf.mods.rollbar.assignedToName", "")
+	userName := wtf.Config.UString(wtf.ConfigKeyFor(configKey, "assignedToName"), "")
 	params.Add("assigned_user", userName)
-	active := wtf.Config.UBool("wtf.mods.rollbar.activeOnly", false)
+	active := wtf.Config.UBool(wtf.ConfigKeyFor(configKey, "activeOnly"), false)
 	if active {
 		params.Add("status", "active")
 	}
 
 	requestURL := rollbarAPIURL.ResolveReference(&url.URL{RawQuery: params.Encode()})
 	req, err := http.NewRequest("GET", requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -62,10 +77,10 @@ func rollbarItemRequest(accessToken string) (*http.Response, error) {
 	return resp, nil
 }
 
-func parseJSON(obj interface{}, text io.Reader) {
+func parseJSON(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -74,7 +89,9 @@ func parseJSON(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,25 @@
+package rollbar
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "rollbar",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"accessToken":    wtf.StringSetting,
+			"activeOnly":     wtf.BoolSetting,
+			"assignedToName": wtf.StringSetting,
+			"count":          wtf.IntSetting,
+			"projectName":    wtf.StringSetting,
+			"projectOwner":   wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,27 +1,22 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
- Keyboard commands for Rollbar:
-
-   /: Show/hide this help window
-   j: Select the next item in the list
-   k: Select the previous item in the list
-   r: Refresh the data
-   u: unselect the current item(removes item being perma highlighted)
-
-   arrow down: Select the next item in the list
-   arrow up:   Select the previous item in the list
-
-   return: Open the selected item in a browser
-`
+// Keys are the Rollbar widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next item in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous item in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected item in a browser"},
+	wtf.RefreshKeyBinding,
+	{Action: "unselect", Keys: []string{"esc", "u"}, Help: "Unselect the item"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -31,37 +26,46 @@ type Widget struct {
 	selected int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Rollbar", "rollbar", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Rollbar", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Rollbar", configKey, true),
 	}
 	widget.HelpfulWidget.SetView(widget.View)
 	widget.unselect()
 
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
-	items, err := CurrentActiveItems()
-
+	items, err := CurrentActiveItems(ctx, widget.Key())
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-	} else {
-		widget.items = &items.Results
+		return err
 	}
 
+	widget.items = &items.Results
 	widget.display()
+
+	return nil
+}
+
+// SelectedItem returns the selected Rollbar item, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.items == nil || sel >= len(widget.items.Items) {
+		return nil
+	}
+
+	return widget.items.Items[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -72,41 +76,42 @@ func (widget *Widget) display() {
 	}
 
 	widget.View.SetWrap(false)
-	projectName := wtf.Config.UString("wtf.mods.rollbar.projectName", "Items")
+	projectName := wtf.Config.UString(widget.ConfigKey("projectName"), "Items")
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %s", widget.Name(), projectName)))
-	widget.View.SetText(widget.contentFrom(widget.items))
+	widget.Render(widget.contentFrom(widget.items))
 }
 
-func (widget *Widget) contentFrom(result *Result) string {
-	var str string
-	count := wtf.Config.UInt("wtf.mods.rollbar.count", 10)
+func (widget *Widget) contentFrom(result *Result) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
+
+	section := content.AddSection("")
+
+	count := wtf.Config.UInt(widget.ConfigKey("count"), 10)
 	if len(result.Items) > count {
 		result.Items = result.Items[:count]
 	}
-	for idx, item := range result.Items {
-
-		str = str + fmt.Sprintf(
-			"[%s] [%s] %s [%s] %s [%s]count: %d [%s]%s\n",
-			widget.rowColor(idx),
-			levelColor(&item),
-			item.Level,
-			statusColor(&item),
-			item.Title,
-			widget.rowColor(idx),
-			item.TotalOccurrences,
-			"blue",
-			item.Environment,
+	for idx := range result.Items {
+		item := &result.Items[idx]
+
+		row := section.AddRow(
+			wtf.Cell{Text: item.Level, Color: levelColor(item)},
+			wtf.Cell{Text: item.Title, Color: statusColor(item)},
+			wtf.Text(fmt.Sprintf("count: %d", item.TotalOccurrences)),
+			wtf.Cell{Text: item.Environment, Color: "blue"},
 		)
+		row.Link = widget.itemURL(item)
+		row.Selectable = true
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) rowColor(idx int) string {
-	if widget.View.HasFocus() && (idx == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
-	}
-	return "white"
+func (widget *Widget) itemURL(item *Item) string {
+	projectOwner := wtf.Config.UString(widget.ConfigKey("projectOwner"), "")
+	projectName := wtf.Config.UString(widget.ConfigKey("projectName"), "")
+
+	return fmt.Sprintf("https://rollbar.com/%s/%s/%s/%d", projectOwner, projectName, "items", item.ID)
 }
 
 func statusColor(item *Item) string {
@@ -152,11 +157,8 @@ func (widget *Widget) prev() {
 
 func (widget *Widget) openBuild() {
 	sel := widget.selected
-	projectOwner := wtf.Config.UString("wtf.mods.rollbar.projectOwner", "")
-	projectName := wtf.Config.UString("wtf.mods.rollbar.projectName", "")
 	if sel >= 0 && widget.items != nil && sel < len(widget.items.Items) {
-		item := &widget.items.Items[widget.selected]
-		wtf.OpenFile(fmt.Sprintf("https://rollbar.com/%s/%s/%s/%d", projectOwner, projectName, "items", item.ID))
+		wtf.OpenFile(widget.itemURL(&widget.items.Items[widget.selected]))
 	}
 }
 
@@ -165,39 +167,10 @@ func (widget *Widget) unselect() {
 	widget.display()
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-	case "j":
-		widget.next()
-		return nil
-	case "k":
-		widget.prev()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	case "u":
-		widget.unselect()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyDown:
-		widget.next()
-		return nil
-	case tcell.KeyEnter:
-		widget.openBuild()
-		return nil
-	case tcell.KeyEsc:
-		widget.unselect()
-		return event
-	case tcell.KeyUp:
-		widget.prev()
-		widget.display()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", widget.next)
+	widget.Keymap.On("prev", widget.prev)
+	widget.Keymap.On("open", widget.openBuild)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("unselect", widget.unselect)
 }
@@ -51,16 +51,16 @@ func firewallStateLinux() string { // might be very Ubuntu specific
 		var o bytes.Buffer
 		cmd.Stdout = &o
 		if err := cmd.Run(); err != nil {
-			return "[red]NA[white]"
+			return "NA"
 		}
 
 		if strings.Contains(o.String(), "inactive") {
-			return "[red]Disabled[white]"
+			return "Disabled"
 		} else {
-			return "[green]Enabled[white]"
+			return "Enabled"
 		}
 	} else {
-		return "[red]N/A[white]"
+		return "N/A"
 	}
 }
 
@@ -86,15 +86,15 @@ func firewallStateWindows() string {
 
 	switch fwStat {
 		case "3":
-			return "[green]Good[white] (3/3)"
+			return "Good (3/3)"
 		case "2":
-			return "[orange]Poor[white] (2/3)"
+			return "Poor (2/3)"
 		case "1":
-			return "[yellow]Bad[white] (1/3)"
+			return "Bad (1/3)"
 		case "0":
-			return "[red]Disabled[white]"
+			return "Disabled"
 		default:	
-			return "[white]N/A[white]"
+			return "N/A"
 	}
 }
 
@@ -102,7 +102,7 @@ func firewallStateWindows() string {
 // "Stealth": Not responding to pings from unauthorized devices
 
 func firewallStealthStateLinux() string {
-	return "[white]N/A[white]"
+	return "N/A"
 }
 
 func firewallStealthStateMacOS() string {
@@ -113,7 +113,7 @@ func firewallStealthStateMacOS() string {
 }
 
 func firewallStealthStateWindows() string {
-	return "[white]N/A[white]"
+	return "N/A"
 }
 
 
@@ -1,7 +1,7 @@
 package security
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	"github.com/rivo/tview"
@@ -12,9 +12,9 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Security", "security", false),
+		TextWidget: wtf.NewTextWidget(app, "Security", configKey, false),
 	}
 
 	return &widget
@@ -22,52 +22,60 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
 	data := NewSecurityData()
 	data.Fetch()
 
-	widget.View.SetText(widget.contentFrom(data))
+	widget.Render(widget.contentFrom(data))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) contentFrom(data *SecurityData) string {
-	str := " [red]WiFi[white]\n"
-	str = str + fmt.Sprintf(" %8s: %s\n", "Network", data.WifiName)
-	str = str + fmt.Sprintf(" %8s: %s\n", "Crypto", data.WifiEncryption)
-	str = str + "\n"
-
-	str = str + " [red]Firewall[white]\n"
-	str = str + fmt.Sprintf(" %8s: %4s\n", "Status", data.FirewallEnabled)
-	str = str + fmt.Sprintf(" %8s: %4s\n", "Stealth", data.FirewallStealth)
-	str = str + "\n"
-
-	str = str + " [red]Users[white]\n"
-	str = str + fmt.Sprintf("  %s", strings.Join(data.LoggedInUsers, "\n "))
-	str = str + "\n"
-
-	str = str + " [red]DNS[white]\n"
-	//str = str + fmt.Sprintf(" %8s: [%s]%-3s[white]   %-16s\n", "Enabled", widget.labelColor(data.FirewallEnabled), data.FirewallEnabled, data.DnsAt(0))
-	//str = str + fmt.Sprintf(" %8s: [%s]%-3s[white]   %-16s\n", "Stealth", widget.labelColor(data.FirewallStealth), data.FirewallStealth, data.DnsAt(1))
-	str = str + fmt.Sprintf("  %12s\n", data.DnsAt(0))
-	str = str + fmt.Sprintf("  %12s\n", data.DnsAt(1))
-	str = str + "\n"
-
-	return str
+func (widget *Widget) contentFrom(data *SecurityData) *wtf.Content {
+	content := wtf.NewContent()
+
+	wifi := content.AddSection("WiFi")
+	wifi.AddRow(label("Network"), wtf.Text(data.WifiName))
+	wifi.AddRow(label("Crypto"), wtf.Text(data.WifiEncryption))
+
+	firewall := content.AddSection("Firewall")
+	firewall.AddRow(label("Status"), wtf.Cell{Text: data.FirewallEnabled, Status: firewallStatus(data.FirewallEnabled)})
+	firewall.AddRow(label("Stealth"), wtf.Cell{Text: data.FirewallStealth, Status: firewallStatus(data.FirewallStealth)})
+
+	users := content.AddSection("Users")
+	for _, user := range data.LoggedInUsers {
+		users.AddRow(wtf.Text(user))
+	}
+
+	dns := content.AddSection("DNS")
+	dns.AddRow(wtf.Text(data.DnsAt(0)))
+	dns.AddRow(wtf.Text(data.DnsAt(1)))
+
+	return content
+}
+
+// label returns a right-aligned label cell, so the values beside it line up
+func label(text string) wtf.Cell {
+	return wtf.Cell{Text: text + ":", Align: tview.AlignRight}
 }
 
-func (widget *Widget) labelColor(label string) string {
-	switch label {
-	case "on":
-		return "green"
-	case "off":
-		return "red"
+// firewallStatus returns how healthy a firewall state, as reported by the OS, is
+func firewallStatus(state string) wtf.Status {
+	switch {
+	case state == "on", state == "Enabled", strings.HasPrefix(state, "Good"):
+		return wtf.StatusOK
+	case strings.HasPrefix(state, "Poor"), strings.HasPrefix(state, "Bad"):
+		return wtf.StatusWarning
+	case state == "off", state == "Disabled", state == "NA":
+		return wtf.StatusError
 	default:
-		return "white"
+		return wtf.StatusNone
 	}
 }
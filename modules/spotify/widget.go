@@ -1,21 +1,22 @@
 package spotify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/sticreations/spotigopher/spotigopher"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-	To control Spotify use:
-		[Spacebar] for Play & Pause
-		[h] for Previous Song
-		[l] for Next Song
-`
+// Keys are the Spotify widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "playPause", Keys: []string{"space"}, Help: "Play or pause"},
+	{Action: "prevSong", Keys: []string{"h"}, Help: "Previous song"},
+	{Action: "nextSong", Keys: []string{"l"}, Help: "Next song"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -24,17 +25,18 @@ type Widget struct {
 	spotigopher.Info
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	spotifyClient := spotigopher.NewClient()
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Spotify", "spotify", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Spotify", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Spotify", configKey, true),
 		SpotifyClient: spotifyClient,
 		Info:          spotigopher.Info{},
 	}
 	widget.HelpfulWidget.SetView(widget.View)
 	widget.TextWidget.RefreshInt = 5
-	widget.View.SetInputCapture(widget.captureInput)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 	widget.View.SetWrap(true)
 	widget.View.SetWordWrap(true)
 	widget.View.SetTitle(fmt.Sprint("[green]Spotify[white]"))
@@ -47,39 +49,37 @@ func (w *Widget) refreshSpotifyInfos() error {
 	return err
 }
 
-func (w *Widget) Refresh() {
-	w.render()
+func (w *Widget) Refresh(ctx context.Context) error {
+	return w.render()
 }
 
-func (w *Widget) render() {
-	err := w.refreshSpotifyInfos()
-	w.View.Clear()
-	if err != nil {
-		w.TextWidget.View.SetText(err.Error())
-	} else {
-		w.TextWidget.View.SetText(w.createOutput())
+func (w *Widget) render() error {
+	if err := w.refreshSpotifyInfos(); err != nil {
+		return err
 	}
+
+	w.View.Clear()
+	w.SetContent(w.createOutput())
+
+	return nil
 }
 
-func (w *Widget) captureInput(event *tcell.EventKey) *tcell.EventKey {
-	switch (string)(event.Rune()) {
-	case "h":
+func (w *Widget) bindKeys() {
+	w.Keymap.On("playPause", func() {
+		w.SpotifyClient.PlayPause()
+		time.Sleep(time.Second * 1)
+		w.RequestRefresh()
+	})
+	w.Keymap.On("prevSong", func() {
 		w.SpotifyClient.Previous()
 		time.Sleep(time.Second * 1)
-		w.Refresh()
-		return nil
-	case "l":
+		w.RequestRefresh()
+	})
+	w.Keymap.On("nextSong", func() {
 		w.SpotifyClient.Next()
 		time.Sleep(time.Second * 1)
-		w.Refresh()
-		return nil
-	case " ":
-		w.SpotifyClient.PlayPause()
-		time.Sleep(time.Second * 1)
-		w.Refresh()
-		return nil
-	}
-	return nil
+		w.RequestRefresh()
+	})
 }
 
 func (w *Widget) createOutput() string {
@@ -0,0 +1,23 @@
+package spotifyweb
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name:     "spotifyweb",
+		HelpText: HelpText,
+		Keys:     Keys,
+		Settings: wtf.ConfigSchema{
+			"callbackPort": wtf.StringSetting,
+			"clientID":     wtf.StringSetting,
+			"secretKey":    wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
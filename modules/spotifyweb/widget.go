@@ -1,38 +1,33 @@
 package spotifyweb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
 	"time"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/wtf"
 	"github.com/zmb3/spotify"
 )
 
-// HelpText contains the help text for the Spotify Web API widget.
-const HelpText = `
-Keyboard commands for Spotify Web:
+// HelpText is shown in the Spotify Web widget's help, above its keyboard commands
+const HelpText = `   Before any of these commands are used, you should authenticate using the
+   URL provided by the widget.
 
-	Before any of these commands are used, you should authenticate using the
-	URL provided by the widget.
+   The widget should automatically open a browser window for you, otherwise
+   you should check out the logs for the URL.`
 
-	The widget should automatically open a browser window for you, otherwise
-	you should check out the logs for the URL.
-
-	/: Show/hide this help window
-	h: Switch to previous song in Spotify queue
-	l: Switch to next song in Spotify queue
-	s: Toggle shuffle
-
-	[space]: Pause/play current song
-
-	esc: Unselect the Spotify Web module
-`
+// Keys are the Spotify Web widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "playPause", Keys: []string{"space"}, Help: "Pause/play the current song"},
+	{Action: "prevSong", Keys: []string{"h"}, Help: "Switch to the previous song in the Spotify queue"},
+	{Action: "nextSong", Keys: []string{"l"}, Help: "Switch to the next song in the Spotify queue"},
+	{Action: "shuffle", Keys: []string{"s"}, Help: "Toggle shuffle"},
+}
 
 // Info is the struct that contains all the information the Spotify player displays to the user
 type Info struct {
@@ -48,9 +43,10 @@ type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
 	Info
-	clientChan  chan *spotify.Client
-	client      *spotify.Client
-	playerState *spotify.PlayerState
+	clientChan    chan *spotify.Client
+	client        *spotify.Client
+	credentialErr error
+	playerState   *spotify.PlayerState
 }
 
 var (
@@ -73,44 +69,51 @@ func authHandler(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		logger.Log(fmt.Sprintf("State mismatch: %s != %s\n", st, state))
 	}
-	// use the token to get an authenticated client
+	// use the token to get an authenticated client. The authenticator makes it with its own
+	// HTTP client, which can't be swapped out, so wtf.HTTPClient's settings don't apply
 	client := auth.NewClient(tok)
 	fmt.Fprintf(w, "Login Completed!")
 	tempClientChan <- &client
 }
 
-func clientID() string {
-	return wtf.Config.UString(
-		"wtf.mods.spotifyweb.clientID",
-		os.Getenv("SPOTIFY_ID"),
-	)
-}
+// authInfo returns the client ID and secret key of the Spotify application to authenticate with
+func authInfo(configKey string) (string, string, error) {
+	clientID, err := wtf.Credential(wtf.ConfigKeyFor(configKey, "clientID"), "SPOTIFY_ID")
+	if err != nil {
+		return "", "", err
+	}
 
-func secretKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.spotifyweb.secretKey",
-		os.Getenv("SPOTIFY_SECRET"),
-	)
+	secretKey, err := wtf.Credential(wtf.ConfigKeyFor(configKey, "secretKey"), "SPOTIFY_SECRET")
+	if err != nil {
+		return "", "", err
+	}
+
+	return clientID, secretKey, nil
 }
 
 // NewWidget creates a new widget for WTF
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
-	callbackPort = wtf.Config.UString("wtf.mods.spotifyweb.callbackPort", "8080")
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
+	callbackPort = wtf.Config.UString(wtf.ConfigKeyFor(configKey, "callbackPort"), "8080")
 	redirectURI = "http://localhost:" + callbackPort + "/callback"
 
 	auth = spotify.NewAuthenticator(redirectURI, spotify.ScopeUserReadCurrentlyPlaying, spotify.ScopeUserReadPlaybackState, spotify.ScopeUserModifyPlaybackState)
-	auth.SetAuthInfo(clientID(), secretKey())
+	clientID, secretKey, credentialErr := authInfo(configKey)
+	auth.SetAuthInfo(clientID, secretKey)
 	authURL = auth.AuthURL(state)
 
 	var client *spotify.Client
 	var playerState *spotify.PlayerState
 
+	keymap := wtf.NewKeymap("Spotify Web", configKey, Keys)
+	keymap.Notes = HelpText
+
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "SpotifyWeb", "spotifyweb", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, keymap),
+		TextWidget:    wtf.NewTextWidget(app, "SpotifyWeb", configKey, true),
 		Info:          Info{},
 		clientChan:    tempClientChan,
 		client:        client,
+		credentialErr: credentialErr,
 		playerState:   playerState,
 	}
 
@@ -135,7 +138,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 		logger.Log("[SpotifyWeb] Authentication complete.")
 		widget.client = client
 		widget.playerState = playerState
-		widget.Refresh()
+		widget.RequestRefresh()
 	}()
 
 	// While I wish I could find the reason this doesn't work, I can't.
@@ -149,7 +152,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.HelpfulWidget.SetView(widget.View)
 	widget.TextWidget.RefreshInt = 5
-	widget.View.SetInputCapture(widget.captureInput)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 	widget.View.SetWrap(true)
 	widget.View.SetWordWrap(true)
 	widget.View.SetTitle("[green]Spotify Web[white]")
@@ -157,6 +161,10 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 }
 
 func (w *Widget) refreshSpotifyInfos() error {
+	if w.credentialErr != nil {
+		return w.credentialErr
+	}
+
 	if w.client == nil || w.playerState == nil {
 		return errors.New("Authentication failed! Please log in to Spotify by visiting the following page in your browser: " + authURL)
 	}
@@ -183,52 +191,47 @@ func (w *Widget) refreshSpotifyInfos() error {
 }
 
 // Refresh refreshes the current view of the widget
-func (w *Widget) Refresh() {
-	w.render()
+func (w *Widget) Refresh(ctx context.Context) error {
+	return w.render()
 }
 
-func (w *Widget) render() {
-	err := w.refreshSpotifyInfos()
-	w.View.Clear()
-	if err != nil {
-		w.TextWidget.View.SetText(err.Error())
-	} else {
-		w.TextWidget.View.SetText(w.createOutput())
+func (w *Widget) render() error {
+	if err := w.refreshSpotifyInfos(); err != nil {
+		return err
 	}
+
+	w.View.Clear()
+	w.SetContent(w.createOutput())
+
+	return nil
 }
 
-func (w *Widget) captureInput(event *tcell.EventKey) *tcell.EventKey {
-	switch (string)(event.Rune()) {
-	case "/":
-		w.ShowHelp()
-		return nil
-	case "h":
-		w.client.Previous()
-		time.Sleep(time.Millisecond * 500)
-		w.Refresh()
-		return nil
-	case "l":
-		w.client.Next()
-		time.Sleep(time.Millisecond * 500)
-		w.Refresh()
-		return nil
-	case " ":
+func (w *Widget) bindKeys() {
+	w.Keymap.On("playPause", func() {
 		if w.playerState.CurrentlyPlaying.Playing {
 			w.client.Pause()
 		} else {
 			w.client.Play()
 		}
 		time.Sleep(time.Millisecond * 500)
-		w.Refresh()
-		return nil
-	case "s":
+		w.RequestRefresh()
+	})
+	w.Keymap.On("prevSong", func() {
+		w.client.Previous()
+		time.Sleep(time.Millisecond * 500)
+		w.RequestRefresh()
+	})
+	w.Keymap.On("nextSong", func() {
+		w.client.Next()
+		time.Sleep(time.Millisecond * 500)
+		w.RequestRefresh()
+	})
+	w.Keymap.On("shuffle", func() {
 		w.playerState.ShuffleState = !w.playerState.ShuffleState
 		w.client.Shuffle(w.playerState.ShuffleState)
 		time.Sleep(time.Millisecond * 500)
-		w.Refresh()
-		return nil
-	}
-	return nil
+		w.RequestRefresh()
+	})
 }
 
 func (w *Widget) createOutput() string {
@@ -1,6 +1,7 @@
 package status
 
 import (
+	"context"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -11,9 +12,9 @@ type Widget struct {
 	CurrentIcon int
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget:  wtf.NewTextWidget(app, "Status", "status", false),
+		TextWidget:  wtf.NewTextWidget(app, "Status", configKey, false),
 		CurrentIcon: 0,
 	}
 
@@ -22,8 +23,10 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	widget.View.SetText(widget.animation())
+func (widget *Widget) Refresh(ctx context.Context) error {
+	widget.SetContent(widget.animation())
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -0,0 +1,21 @@
+package system
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// BuildDate and BuildVersion describe the running binary. They're set by main on start-up
+var (
+	BuildDate    = "dev"
+	BuildVersion = "dev"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "system",
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey, BuildDate, BuildVersion)
+		},
+	})
+}
@@ -1,7 +1,7 @@
 package system
 
 import (
-	"fmt"
+	"context"
 	"time"
 
 	"github.com/rivo/tview"
@@ -16,9 +16,9 @@ type Widget struct {
 	Version    string
 }
 
-func NewWidget(app *tview.Application, date, version string) *Widget {
+func NewWidget(app *tview.Application, configKey string, date, version string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "System", "system", false),
+		TextWidget: wtf.NewTextWidget(app, "System", configKey, false),
 
 		Date:    date,
 		Version: version,
@@ -29,20 +29,25 @@ func NewWidget(app *tview.Application, date, version string) *Widget {
 	return &widget
 }
 
-func (widget *Widget) Refresh() {
-	widget.View.SetText(
-		fmt.Sprintf(
-			"%8s: %s\n%8s: %s\n\n%8s: %s\n%8s: %s",
-			"Built",
-			widget.prettyDate(),
-			"Vers",
-			widget.Version,
-			"OS",
-			widget.systemInfo.ProductVersion,
-			"Build",
-			widget.systemInfo.BuildVersion,
-		),
-	)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	content := wtf.NewContent()
+
+	wtfInfo := content.AddSection("")
+	wtfInfo.AddRow(field("Built"), wtf.Text(widget.prettyDate()))
+	wtfInfo.AddRow(field("Vers"), wtf.Text(widget.Version))
+
+	osInfo := content.AddSection("")
+	osInfo.AddRow(field("OS"), wtf.Text(widget.systemInfo.ProductVersion))
+	osInfo.AddRow(field("Build"), wtf.Text(widget.systemInfo.BuildVersion))
+
+	widget.Render(content)
+
+	return nil
+}
+
+// field returns a label, right-aligned to the widest label in its section
+func field(label string) wtf.Cell {
+	return wtf.Cell{Text: label + ":", Align: tview.AlignRight}
 }
 
 func (widget *Widget) prettyDate() string {
@@ -0,0 +1,22 @@
+package textfile
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "textfile",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"filePath":    wtf.StringSetting,
+			"filePaths":   wtf.ListSetting,
+			"format":      wtf.BoolSetting,
+			"formatStyle": wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+	})
+}
@@ -2,6 +2,7 @@ package textfile
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -12,23 +13,18 @@ import (
 	"github.com/alecthomas/chroma/formatters"
 	"github.com/alecthomas/chroma/lexers"
 	"github.com/alecthomas/chroma/styles"
-	"github.com/gdamore/tcell"
 	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-  Keyboard commands for Textfile:
-
-    /: Show/hide this help window
-    h: Previous text file
-    l: Next text file
-    o: Open the text file in the operating system
-
-    arrow left:  Previous text file
-    arrow right: Next text file
-`
+// Keys are the Textfile widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next text file"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous text file"},
+	{Action: "openFile", Keys: []string{"o"}, Help: "Open the text file in the operating system"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -36,11 +32,11 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, HelpText),
-		MultiSourceWidget: wtf.NewMultiSourceWidget("textfile", "filePath", "filePaths"),
-		TextWidget:        wtf.NewTextWidget(app, "TextFile", "textfile", true),
+		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Textfile", configKey, Keys)),
+		MultiSourceWidget: wtf.NewMultiSourceWidget(configKey, "filePath", "filePaths"),
+		TextWidget:        wtf.NewTextWidget(app, "TextFile", configKey, true),
 	}
 
 	// Don't use a timer for this widget, watch for filesystem changes instead
@@ -53,7 +49,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.View.SetWrap(true)
 	widget.View.SetWordWrap(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	go widget.watchForFileChanges()
 
@@ -64,8 +61,10 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 // Refresh is only called once on start-up. Its job is to display the
 // text files that first time. After that, the watcher takes over
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	widget.display()
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -76,7 +75,7 @@ func (widget *Widget) display() {
 
 	text := wtf.SigilStr(len(widget.Sources), widget.Idx, widget.View) + "\n"
 
-	if wtf.Config.UBool("wtf.mods.textfile.format", false) {
+	if wtf.Config.UBool(widget.ConfigKey("format"), false) {
 		text = text + widget.formattedText()
 	} else {
 		text = text + widget.plainText()
@@ -84,7 +83,7 @@ func (widget *Widget) display() {
 
 	//widget.View.Lock()
 	widget.View.SetTitle(title) // <- Writes to TextView's title
-	widget.View.SetText(text)   // <- Writes to TextView's text
+	widget.SetContent(text)     // <- Writes to TextView's text
 	//widget.View.Unlock()
 }
 
@@ -105,7 +104,7 @@ func (widget *Widget) formattedText() string {
 		lexer = lexers.Fallback
 	}
 
-	style := styles.Get(wtf.Config.UString("wtf.mods.textfile.formatStyle", "vim"))
+	style := styles.Get(wtf.Config.UString(widget.ConfigKey("formatStyle"), "vim"))
 	if style == nil {
 		style = styles.Fallback
 	}
@@ -135,34 +134,12 @@ func (widget *Widget) plainText() string {
 	return string(text)
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	case "o":
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
+	widget.Keymap.On("openFile", func() {
 		wtf.OpenFile(widget.CurrentSource())
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
-
-	return event
+	})
 }
 
 func (widget *Widget) watchForFileChanges() {
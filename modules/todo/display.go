@@ -3,64 +3,118 @@ package todo
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
-	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/checklist"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const checkWidth = 4
+// indentWidth is how far each level of nesting is indented
+const indentWidth = 2
+
+// sortOrders are the orders the "sort" setting can list
+var sortOrders = map[string]checklist.Order{
+	"checked":  checklist.ByChecked,
+	"due":      checklist.ByDue,
+	"overdue":  checklist.ByOverdue,
+	"priority": checklist.ByPriority,
+	"text":     checklist.ByText,
+}
 
 func (widget *Widget) display() {
-	str := ""
-	newList := checklist.NewChecklist()
+	widget.list.Sort(widget.orders()...)
 
-	offset := 0
+	content := wtf.NewContent()
+	content.Selected = widget.list.Selected
 
-	for idx, item := range widget.list.UncheckedItems() {
-		str = str + widget.formattedItemLine(idx, item, widget.list.SelectedItem(), widget.list.LongestLine())
-		newList.Items = append(newList.Items, item)
-		offset++
+	section := content.AddSection("")
+	for _, row := range widget.list.Rows() {
+		section.AddRow(widget.itemCell(row)).Selectable = true
 	}
 
-	for idx, item := range widget.list.CheckedItems() {
-		str = str + widget.formattedItemLine(idx+offset, item, widget.list.SelectedItem(), widget.list.LongestLine())
-		newList.Items = append(newList.Items, item)
+	widget.Render(content)
+	widget.View.Highlight(strconv.Itoa(widget.list.Selected)).ScrollToHighlight()
+}
+
+// checkMark returns the string used to indicate an item is checked or unchecked,
+// honouring this instance's checkedIcon setting
+func (widget *Widget) checkMark(item *checklist.ChecklistItem) string {
+	return item.CheckMark(wtf.Config.UString(widget.ConfigKey("checkedIcon"), "x"))
+}
+
+// filters returns the filters the "hideChecked" and "tags" settings ask for
+func (widget *Widget) filters() []checklist.Filter {
+	filters := []checklist.Filter{}
+
+	if wtf.Config.UBool(widget.ConfigKey("hideChecked"), false) {
+		filters = append(filters, checklist.Unchecked)
 	}
 
-	newList.SetSelectedByItem(widget.list.SelectedItem())
-	widget.SetList(newList)
+	if tags := wtf.ToStrs(wtf.Config.UList(widget.ConfigKey("tags"))); len(tags) > 0 {
+		filters = append(filters, checklist.Tagged(tags...))
+	}
 
-	widget.View.Clear()
-	widget.View.SetText(str)
-	widget.View.Highlight(strconv.Itoa(widget.list.Selected)).ScrollToHighlight()
+	return filters
 }
 
-func (widget *Widget) formattedItemLine(idx int, item *checklist.ChecklistItem, selectedItem *checklist.ChecklistItem, maxLen int) string {
-	foreColor, backColor := "white", wtf.Config.UString("wtf.colors.background", "black")
+// itemCell returns the item, indented by its depth, as a single cell so that nested items
+// stay indented rather than being lined up in columns
+func (widget *Widget) itemCell(row checklist.ChecklistRow) wtf.Cell {
+	item := row.Item
+	color := ""
 
 	if item.Checked {
-		foreColor = wtf.Config.UString("wtf.colors.checked", "white")
+		color = wtf.Config.UString("wtf.colors.checked", "white")
+	} else if item.IsOverdue() {
+		color = wtf.Config.UString(widget.ConfigKey("colors.overdue"), "red")
+	}
+
+	return wtf.Cell{
+		Text:  fmt.Sprintf("%s|%s| %s", strings.Repeat(" ", row.Depth*indentWidth), widget.checkMark(item), itemText(item)),
+		Color: color,
+	}
+}
+
+// orders returns the orders the "sort" setting lists, which by default puts checked items
+// after unchecked ones
+func (widget *Widget) orders() []checklist.Order {
+	names := wtf.ToStrs(wtf.Config.UList(widget.ConfigKey("sort"), []interface{}{"checked"}))
+
+	orders := []checklist.Order{}
+	for _, name := range names {
+		if order, ok := sortOrders[name]; ok {
+			orders = append(orders, order)
+		}
 	}
 
-	if widget.View.HasFocus() && (item == selectedItem) {
-		foreColor = wtf.Config.UString("wtf.colors.highlight.fore", "black")
-		backColor = wtf.Config.UString("wtf.colors.highlight.back", "orange")
+	return orders
+}
+
+// itemText returns the item's text followed by its priority, tags and due date, if it has them.
+// Tags that the text already mentions, as todo.txt and Markdown files write them, aren't repeated
+func itemText(item *checklist.ChecklistItem) string {
+	parts := []string{item.Text}
+
+	if item.Priority > 0 {
+		parts = append(parts, fmt.Sprintf("!%d", item.Priority))
+	}
+
+	for _, tag := range item.Tags {
+		if strings.HasPrefix(tag, "@") {
+			if !item.Mentions(tag) {
+				parts = append(parts, tag)
+			}
+			continue
+		}
+
+		if !item.Mentions("#"+tag) && !item.Mentions("+"+tag) {
+			parts = append(parts, "#"+tag)
+		}
 	}
 
-	str := fmt.Sprintf(
-		`["%d"][""][%s:%s]|%s| %s[white]`,
-		idx,
-		foreColor,
-		backColor,
-		item.CheckMark(),
-		tview.Escape(item.Text),
-	)
-
-	_, _, w, _ := widget.View.GetInnerRect()
-	if w > maxLen {
-		maxLen = w
+	if item.Due != "" {
+		parts = append(parts, "due "+item.Due)
 	}
 
-	return str + wtf.PadRow((checkWidth+len(item.Text)), (checkWidth+maxLen+1)) + "\n"
+	return strings.Join(parts, " ")
 }
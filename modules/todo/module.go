@@ -0,0 +1,25 @@
+package todo
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "todo",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"checkedIcon":    wtf.StringSetting,
+			"colors.overdue": wtf.StringSetting,
+			"filename":       wtf.StringSetting,
+			"format":         wtf.StringSetting,
+			"hideChecked":    wtf.BoolSetting,
+			"sort":           wtf.ListSetting,
+			"tags":           wtf.ListSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+	})
+}
@@ -1,35 +1,38 @@
 package todo
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
+	"sync"
+	"time"
 
 	"github.com/gdamore/tcell"
+	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/checklist"
 	"github.com/wtfutil/wtf/wtf"
-	"gopkg.in/yaml.v2"
 )
 
-const HelpText = `
- Keyboard commands for Todo:
-
-   /: Show/hide this help window
-   j: Select the next item in the list
-   k: Select the previous item in the list
-   n: Create a new list item
-   o: Open the todo file in the operating system
-
-   arrow down: Select the next item in the list
-   arrow up:   Select the previous item in the list
-
-   ctrl-d: Delete the selected item
-
-   esc:    Unselect the todo list
-   return: Edit selected item
-   space:  Check the selected item on or off
-`
+// Keys are the todo list's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next item in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous item in the list"},
+	{Action: "toggle", Keys: []string{"space"}, Help: "Check the selected item on or off"},
+	{Action: "new", Keys: []string{"n"}, Help: "Create a new list item"},
+	{Action: "edit", Keys: []string{"enter"}, Help: "Edit the selected item"},
+	{Action: "delete", Keys: []string{"ctrl-d"}, Help: "Delete the selected item"},
+	{Action: "moveDown", Keys: []string{"ctrl-j"}, Help: "Move the selected item down the list"},
+	{Action: "moveUp", Keys: []string{"ctrl-k"}, Help: "Move the selected item up the list"},
+	{Action: "indent", Keys: []string{">"}, Help: "Nest the selected item under the one above it"},
+	{Action: "outdent", Keys: []string{"<"}, Help: "Move the selected item out from under its parent"},
+	{Action: "openFile", Keys: []string{"o"}, Help: "Open the todo file in the operating system"},
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the todo list"},
+}
 
 const offscreen = -1000
 const modalWidth = 80
@@ -43,36 +46,98 @@ type Widget struct {
 	filePath string
 	list     checklist.Checklist
 	pages    *tview.Pages
+
+	// format reads and writes the todo file, and formatErr is why there isn't one, reported on
+	// each refresh
+	format    checklist.Format
+	formatErr error
+
+	// fileSum is the checksum of the todo file as the widget last read or wrote it, so that
+	// edits made outside of WTF can be told apart from its own. writes counts the widget's
+	// writes, so that a list read before one of them isn't applied after it
+	fileMu  sync.Mutex
+	fileSum [sha256.Size]byte
+	writes  int
+
+	watchOnce sync.Once
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Todo", "todo", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Todo", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Todo", configKey, true),
 
-		app:      app,
-		filePath: wtf.Config.UString("wtf.mods.todo.filename"),
-		list:     checklist.NewChecklist(),
-		pages:    pages,
+		app:   app,
+		list:  checklist.NewChecklist(),
+		pages: pages,
 	}
 
-	widget.init()
+	widget.filePath = wtf.Config.UString(widget.ConfigKey("filename"))
+	widget.format, widget.formatErr = checklist.NewFormat(
+		wtf.Config.UString(widget.ConfigKey("format"), ""),
+		widget.filePath,
+	)
+	widget.list.SetFilters(widget.filters()...)
+
 	widget.HelpfulWidget.SetView(widget.View)
 
 	widget.View.SetScrollable(true)
 	widget.View.SetRegions(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	widget.load()
-	widget.display()
+// Refresh reads the todo file, and hands the list to the app's goroutine to display, since
+// that's where the keys change it
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if widget.formatErr != nil {
+		return widget.formatErr
+	}
 
-	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
+	widget.fileMu.Lock()
+	writes := widget.writes
+	widget.fileMu.Unlock()
+
+	data, err := widget.readFile()
+	if err != nil {
+		return err
+	}
+
+	list, format, err := widget.parse(data)
+	if err != nil {
+		return err
+	}
+
+	// The file only exists for certain once it's been loaded, so it's watched from then on,
+	// for as long as the scheduler that refreshes the widget runs
+	widget.watchOnce.Do(func() {
+		go widget.watchForFileChanges(ctx)
+	})
+
+	widget.update(func() {
+		// A write since the file was read has already replaced what was read
+		if !widget.writtenSince(writes) {
+			widget.apply(data, list, format)
+		}
+
+		widget.display()
+		widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
+	})
+
+	return nil
+}
+
+// SelectedItem returns the selected checklist item, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	if item := widget.list.SelectedItem(); item != nil {
+		return item
+	}
+
+	return nil
 }
 
 func (widget *Widget) SetList(newList checklist.Checklist) {
@@ -92,106 +157,133 @@ func (widget *Widget) editItem() {
 	saveFctn := func() {
 		text := form.GetFormItem(0).(*tview.InputField).GetText()
 
-		widget.list.Update(text)
-		widget.persist()
 		widget.pages.RemovePage("modal")
 		widget.app.SetFocus(widget.View)
-		widget.display()
+		widget.change(func() {
+			widget.list.Update(text)
+		})
 	}
 
 	widget.addButtons(form, saveFctn)
 	widget.modalFocus(form)
 }
 
-func (widget *Widget) init() {
+// init creates the todo file if it doesn't exist yet
+func (widget *Widget) init() error {
 	_, err := cfg.CreateFile(widget.filePath)
-	if err != nil {
-		panic(err)
-	}
+	return err
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case " ":
-		// Check/uncheck selected item
-		widget.list.Toggle()
-		widget.persist()
-		widget.display()
-		return nil
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "j":
-		// Select the next item down
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", func() {
 		widget.list.Next()
 		widget.display()
-		return nil
-	case "k":
-		// Select the next item up
+	})
+	widget.Keymap.On("prev", func() {
 		widget.list.Prev()
 		widget.display()
-		return nil
-	case "n":
-		// Add a new item
-		widget.newItem()
-		return nil
-	case "o":
-		// Open the file
-		confDir, _ := cfg.ConfigDir()
-		wtf.OpenFile(fmt.Sprintf("%s/%s", confDir, widget.filePath))
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyCtrlD:
-		// Delete the selected item
-		widget.list.Delete()
-		widget.persist()
-		widget.display()
-		return nil
-	case tcell.KeyCtrlJ:
-		// Move selected item down in the list
-		widget.list.Demote()
-		widget.persist()
-		widget.display()
-		return nil
-	case tcell.KeyCtrlK:
-		// Move selected item up in the list
-		widget.list.Promote()
-		widget.persist()
-		widget.display()
-		return nil
-	case tcell.KeyDown:
-		// Select the next item down
-		widget.list.Next()
-		widget.display()
-		return nil
-	case tcell.KeyEnter:
-		widget.editItem()
-		return nil
-	case tcell.KeyEsc:
-		// Unselect the current row
+	})
+	widget.Keymap.On("toggle", func() {
+		widget.change(widget.list.Toggle)
+	})
+	widget.Keymap.On("new", widget.newItem)
+	widget.Keymap.On("edit", widget.editItem)
+	widget.Keymap.On("delete", func() {
+		widget.change(widget.list.Delete)
+	})
+	widget.Keymap.On("moveDown", func() {
+		widget.change(widget.list.Demote)
+	})
+	widget.Keymap.On("moveUp", func() {
+		widget.change(widget.list.Promote)
+	})
+	widget.Keymap.On("indent", func() {
+		widget.change(widget.list.Indent)
+	})
+	widget.Keymap.On("outdent", func() {
+		widget.change(widget.list.Outdent)
+	})
+	widget.Keymap.On("openFile", func() {
+		wtf.OpenFile(widget.fullPath())
+	})
+	widget.Keymap.On("unselect", func() {
 		widget.list.Unselect()
 		widget.display()
-		return event
-	case tcell.KeyUp:
-		// Select the next item up
-		widget.list.Prev()
-		widget.display()
-		return nil
-	default:
-		// Pass it along
-		return event
+	})
+}
+
+// apply replaces the list with one read from the file. The selection is kept unless the file
+// has one of its own
+func (widget *Widget) apply(data []byte, list checklist.Checklist, format checklist.Format) {
+	widget.format = format
+	widget.list.Items = list.Items
+
+	if list.Selected >= 0 {
+		widget.list.Selected = list.Selected
 	}
+
+	widget.fileMu.Lock()
+	widget.fileSum = sha256.Sum256(data)
+	widget.fileMu.Unlock()
 }
 
-// Loads the todo list from Yaml file
-func (widget *Widget) load() {
+// change makes a change to the list and writes it to the file. If the file has been edited
+// outside of WTF since it was last read, it's read again first, so that the change is made to
+// the edited list rather than the old list being written over it. If the file can't be
+// written, i.e.: it's read-only or has been removed, the widget shows the error until its next
+// successful refresh
+func (widget *Widget) change(fn func()) {
+	if data, err := widget.readFile(); err == nil && widget.changedOnDisk(data) {
+		if list, format, err := widget.parse(data); err == nil {
+			widget.apply(data, list, format)
+		}
+	}
+
+	fn()
+	widget.display()
+
+	if err := widget.persist(); err != nil {
+		widget.SetRefreshError(fmt.Errorf("could not save %s: %v", widget.filePath, err))
+	}
+}
+
+// changedOnDisk returns true if the file's contents aren't what the widget last read or wrote
+func (widget *Widget) changedOnDisk(data []byte) bool {
+	sum := sha256.Sum256(data)
+
+	widget.fileMu.Lock()
+	defer widget.fileMu.Unlock()
+
+	return !bytes.Equal(sum[:], widget.fileSum[:])
+}
+
+// fullPath returns the path to the todo file, which is relative to the config directory
+func (widget *Widget) fullPath() string {
 	confDir, _ := cfg.ConfigDir()
-	filePath := fmt.Sprintf("%s/%s", confDir, widget.filePath)
+	return fmt.Sprintf("%s/%s", confDir, widget.filePath)
+}
+
+// parse reads the todo list from the file's contents. It's read with a format of its own,
+// since formats like Markdown keep what surrounds the list for when it's written
+func (widget *Widget) parse(data []byte) (checklist.Checklist, checklist.Format, error) {
+	list := checklist.NewChecklist()
+
+	format, err := checklist.NewFormat(wtf.Config.UString(widget.ConfigKey("format"), ""), widget.filePath)
+	if err != nil {
+		return list, nil, err
+	}
 
-	fileData, _ := wtf.ReadFileBytes(filePath)
-	yaml.Unmarshal(fileData, &widget.list)
+	err = format.Read(data, &list)
+	return list, format, err
+}
+
+// readFile returns the contents of the todo file, which is created if it doesn't exist yet
+func (widget *Widget) readFile() ([]byte, error) {
+	if err := widget.init(); err != nil {
+		return nil, err
+	}
+
+	return wtf.ReadFileBytes(widget.fullPath())
 }
 
 func (widget *Widget) newItem() {
@@ -200,29 +292,104 @@ func (widget *Widget) newItem() {
 	saveFctn := func() {
 		text := form.GetFormItem(0).(*tview.InputField).GetText()
 
-		widget.list.Add(false, text)
-		widget.persist()
 		widget.pages.RemovePage("modal")
 		widget.app.SetFocus(widget.View)
-		widget.display()
+		widget.change(func() {
+			widget.list.Insert(&checklist.ChecklistItem{
+				Text:    text,
+				Created: time.Now().Format(checklist.DateFormat),
+			})
+		})
 	}
 
 	widget.addButtons(form, saveFctn)
 	widget.modalFocus(form)
 }
 
-// persist writes the todo list to Yaml file
-func (widget *Widget) persist() {
-	confDir, _ := cfg.ConfigDir()
-	filePath := fmt.Sprintf("%s/%s", confDir, widget.filePath)
+// persist writes the todo list to its file
+func (widget *Widget) persist() error {
+	fileData, err := widget.format.Write(&widget.list)
+	if err != nil {
+		return err
+	}
 
-	fileData, _ := yaml.Marshal(&widget.list)
+	if err := ioutil.WriteFile(widget.fullPath(), fileData, 0644); err != nil {
+		return err
+	}
 
-	err := ioutil.WriteFile(filePath, fileData, 0644)
+	widget.fileMu.Lock()
+	widget.fileSum = sha256.Sum256(fileData)
+	widget.writes++
+	widget.fileMu.Unlock()
 
-	if err != nil {
-		panic(err)
+	return nil
+}
+
+// update runs fn on the app's goroutine, where the keys change the list too. Without an app,
+// i.e.: for 'wtf --once', it's run straight away
+func (widget *Widget) update(fn func()) {
+	if widget.app == nil {
+		fn()
+		return
 	}
+
+	widget.app.QueueUpdateDraw(fn)
+}
+
+// watchForFileChanges refreshes the widget whenever the todo file is written to, so that edits
+// made outside of WTF show up. Writes that leave the file as the widget itself last wrote it
+// are ignored. The watch ends once ctx is done, as it is when the config is reloaded and the
+// widget replaced, or once the widget is disabled. If the file can't be watched, it's just not
+// refreshed on changes
+func (widget *Widget) watchForFileChanges(ctx context.Context) {
+	watch := watcher.New()
+	watch.FilterOps(watcher.Write)
+
+	if err := watch.Add(widget.fullPath()); err != nil {
+		return
+	}
+
+	go func() {
+		// The watcher can only be closed once it's started. It's closed in the background,
+		// since closing it waits for any event it's sending to be received here
+		watch.Wait()
+
+		done := ctx.Done()
+
+		for {
+			select {
+			case <-done:
+				done = nil
+				go watch.Close()
+			case <-watch.Event:
+				if widget.Disabled() {
+					go watch.Close()
+					continue
+				}
+
+				if data, err := widget.readFile(); err == nil && !widget.changedOnDisk(data) {
+					continue
+				}
+
+				widget.RequestRefresh()
+			case <-watch.Error:
+			case <-watch.Closed:
+				return
+			}
+		}
+	}()
+
+	// Start the watching process - it'll check for changes every 100ms.
+	watch.Start(time.Millisecond * 100)
+}
+
+// writtenSince returns true if the widget has written the file since it had written it the
+// given number of times
+func (widget *Widget) writtenSince(writes int) bool {
+	widget.fileMu.Lock()
+	defer widget.fileMu.Unlock()
+
+	return widget.writes != writes
 }
 
 /* -------------------- Modal Form -------------------- */
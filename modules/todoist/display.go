@@ -3,12 +3,9 @@ package todoist
 import (
 	"fmt"
 
-	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const checkWidth = 4
-
 func (widget *Widget) display() {
 	proj := widget.CurrentProject()
 
@@ -19,33 +16,14 @@ func (widget *Widget) display() {
 	title := fmt.Sprintf("[green]%s[white]", proj.Project.Name)
 	widget.View.SetTitle(widget.ContextualTitle(title))
 
-	str := wtf.SigilStr(len(widget.projects), widget.idx, widget.View) + "\n"
-
-	maxLen := proj.LongestLine()
-
-	for index, item := range proj.tasks {
-		foreColor, backColor := "white", wtf.Config.UString("wtf.colors.background", "black")
-
-		if index == proj.index {
-			foreColor = wtf.Config.UString("wtf.colors.highlight.fore", "black")
-			backColor = wtf.Config.UString("wtf.colors.highlight.back", "orange")
-		}
-
-		row := fmt.Sprintf(
-			"[%s:%s]| | %s[white]",
-			foreColor,
-			backColor,
-			tview.Escape(item.Content),
-		)
-
-		_, _, w, _ := widget.View.GetInnerRect()
-		if w > maxLen {
-			maxLen = w
-		}
+	content := wtf.NewContent()
+	content.Page, content.Pages = widget.idx, len(widget.projects)
+	content.Selected = proj.index
 
-		str = str + row + wtf.PadRow((checkWidth+len(item.Content)), (checkWidth+maxLen+1)) + "\n"
+	section := content.AddSection("")
+	for _, item := range proj.tasks {
+		section.AddRow(wtf.Text("| | " + item.Content)).Selectable = true
 	}
 
-	//widget.View.Clear()
-	widget.View.SetText(str)
+	widget.Render(content)
 }
@@ -0,0 +1,21 @@
+package todoist
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "todoist",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":   wtf.StringSetting,
+			"projects": wtf.ListSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,7 +1,7 @@
 package todoist
 
 import (
-	"os"
+	"context"
 
 	"github.com/darkSasori/todoist"
 	"github.com/gdamore/tcell"
@@ -9,42 +9,38 @@ import (
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
- Keyboard commands for Todoist:
-
-   /: Show/hide this help window
-   c: Close the selected item
-   d: Delete the selected item
-   h: Previous Todoist list
-   j: Select the next item in the list
-   k: Select the previous item in the list
-   l: Next Todoist list
-   r: Refresh the todo list data
-
-   arrow down: Select the next item in the list
-   arrow left: Previous Todoist list
-   arrow right: Next Todoist list
-   arrow up: Select the previous item in the list
-`
+// Keys are the Todoist widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next Todoist list"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous Todoist list"},
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next item in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous item in the list"},
+	{Action: "close", Keys: []string{"c"}, Help: "Close the selected item"},
+	{Action: "delete", Keys: []string{"d"}, Help: "Delete the selected item"},
+	{Action: "refresh", Keys: []string{"r"}, Help: "Refresh the todo list data"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
 
-	projects []*Project
-	idx      int
+	credentialErr error
+	projects      []*Project
+	idx           int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "Todoist", "todoist", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Todoist", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Todoist", configKey, true),
 	}
 
-	widget.loadAPICredentials()
-	widget.projects = loadProjects()
+	widget.credentialErr = widget.loadAPICredentials()
+	widget.projects = widget.loadProjects()
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.bindKeys()
 	widget.View.SetInputCapture(widget.keyboardIntercept)
 
 	return &widget
@@ -82,12 +78,18 @@ func (w *Widget) PreviousProject() {
 	w.display()
 }
 
-func (w *Widget) Refresh() {
+func (w *Widget) Refresh(ctx context.Context) error {
+	if w.credentialErr != nil {
+		return w.credentialErr
+	}
+
 	if w.Disabled() || w.CurrentProject() == nil {
-		return
+		return nil
 	}
 
 	w.display()
+
+	return nil
 }
 
 /* -------------------- Keyboard Movement -------------------- */
@@ -130,72 +132,45 @@ func (w *Widget) Delete() {
 
 /* -------------------- Unexported Functions -------------------- */
 
+func (w *Widget) bindKeys() {
+	w.Keymap.On("nextSource", w.NextProject)
+	w.Keymap.On("prevSource", w.PreviousProject)
+	w.Keymap.On("next", w.Down)
+	w.Keymap.On("prev", w.Up)
+	w.Keymap.On("close", w.Close)
+	w.Keymap.On("delete", w.Delete)
+	w.Keymap.On("refresh", w.RequestRefresh)
+}
+
+// keyboardIntercept ignores the keys until there's a project for them to act on
 func (w *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	if len(w.projects) == 0 {
 		return event
 	}
 
-	switch string(event.Rune()) {
-	case "/":
-		w.ShowHelp()
-		return nil
-	case "r":
-		w.Refresh()
-		return nil
-	case "d":
-		w.Delete()
-		return nil
-	case "c":
-		w.Close()
-		return nil
-	}
+	return w.Keymap.InputCapture(event)
+}
 
-	switch w.vimBindings(event) {
-	case tcell.KeyLeft:
-		w.PreviousProject()
-		return nil
-	case tcell.KeyRight:
-		w.NextProject()
-		return nil
-	case tcell.KeyUp:
-		w.Up()
-		return nil
-	case tcell.KeyDown:
-		w.Down()
-		return nil
+func (widget *Widget) loadAPICredentials() error {
+	token, err := wtf.Credential(widget.ConfigKey("apiKey"), "WTF_TODOIST_TOKEN")
+	if err != nil {
+		return err
 	}
 
-	return event
-}
+	// The library makes its own HTTP client for every request, so this widget can't use
+	// wtf.HTTPClient and its "http" settings don't apply
+	todoist.Token = token
 
-func (widget *Widget) loadAPICredentials() {
-	todoist.Token = wtf.Config.UString(
-		"wtf.mods.todoist.apiKey",
-		os.Getenv("WTF_TODOIST_TOKEN"),
-	)
+	return nil
 }
 
-func loadProjects() []*Project {
+func (widget *Widget) loadProjects() []*Project {
 	projects := []*Project{}
 
-	for _, id := range wtf.Config.UList("wtf.mods.todoist.projects") {
+	for _, id := range wtf.Config.UList(widget.ConfigKey("projects")) {
 		proj := NewProject(id.(int))
 		projects = append(projects, proj)
 	}
 
 	return projects
 }
-
-func (w *Widget) vimBindings(event *tcell.EventKey) tcell.Key {
-	switch string(event.Rune()) {
-	case "h":
-		return tcell.KeyLeft
-	case "l":
-		return tcell.KeyRight
-	case "k":
-		return tcell.KeyUp
-	case "j":
-		return tcell.KeyDown
-	}
-	return event.Key()
-}
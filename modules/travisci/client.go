@@ -2,13 +2,13 @@ package travisci
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
 
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -18,18 +18,20 @@ var TRAVIS_HOSTS = map[bool]string{
 	true:  "travis-ci.com",
 }
 
-func BuildsFor() (*Builds, error) {
+func BuildsFor(ctx context.Context, configKey string) (*Builds, error) {
 	builds := &Builds{}
 
-	pro := wtf.Config.UBool("wtf.mods.travisci.pro", false)
+	pro := wtf.Config.UBool(wtf.ConfigKeyFor(configKey, "pro"), false)
 	travisAPIURL.Host = "api." + TRAVIS_HOSTS[pro]
 
-	resp, err := travisRequest("builds")
+	resp, err := travisRequest(ctx, configKey, "builds")
 	if err != nil {
 		return builds, err
 	}
 
-	parseJson(&builds, resp.Body)
+	if err := parseJson(&builds, resp.Body); err != nil {
+		return nil, err
+	}
 
 	return builds, nil
 }
@@ -40,24 +42,35 @@ var (
 	travisAPIURL = &url.URL{Scheme: "https", Path: "/"}
 )
 
-func travisRequest(path string) (*http.Response, error) {
+func travisRequest(ctx context.Context, configKey string, path string) (*http.Response, error) {
 	params := url.Values{}
 	params.Add("limit", "10")
 
 	requestUrl := travisAPIURL.ResolveReference(&url.URL{Path: path, RawQuery: params.Encode()})
 
+	apiToken, err := apiToken(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequest("GET", requestUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Travis-API-Version", "3")
 
-	bearer := fmt.Sprintf("token %s", apiToken())
+	bearer := fmt.Sprintf("token %s", apiToken)
 	req.Header.Add("Authorization", bearer)
+
+	httpClient, err := wtf.HTTPClient(configKey)
 	if err != nil {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -70,17 +83,14 @@ func travisRequest(path string) (*http.Response, error) {
 	return resp, nil
 }
 
-func apiToken() string {
-	return wtf.Config.UString(
-		"wtf.mods.travisci.apiKey",
-		os.Getenv("WTF_TRAVIS_API_TOKEN"),
-	)
+func apiToken(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "WTF_TRAVIS_API_TOKEN")
 }
 
-func parseJson(obj interface{}, text io.Reader) {
+func parseJson(obj interface{}, text io.Reader) error {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -89,7 +99,9 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			return err
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,21 @@
+package travisci
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "travisci",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey": wtf.StringSetting,
+			"pro":    wtf.BoolSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,26 +1,22 @@
 package travisci
 
 import (
+	"context"
 	"fmt"
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 	"strings"
 )
 
-const HelpText = `
- Keyboard commands for Travis CI:
-
-   /: Show/hide this help window
-   j: Select the next build in the list
-   k: Select the previous build in the list
-   r: Refresh the data
-
-   arrow down: Select the next build in the list
-   arrow up:   Select the previous build in the list
-
-   return: Open the selected build in a browser
-`
+// Keys are the Travis CI widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next build in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous build in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected build in a browser"},
+	wtf.RefreshKeyBinding,
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the build"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -30,38 +26,47 @@ type Widget struct {
 	selected int
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
-		TextWidget:    wtf.NewTextWidget(app, "TravisCI", "travisci", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Travis CI", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "TravisCI", configKey, true),
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
 	widget.unselect()
 
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
-	builds, err := BuildsFor()
-
+	builds, err := BuildsFor(ctx, widget.Key())
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		widget.View.SetText(err.Error())
-	} else {
-		widget.builds = builds
+		return err
 	}
 
+	widget.builds = builds
 	widget.display()
+
+	return nil
+}
+
+// SelectedItem returns the selected build, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.builds == nil || sel >= len(widget.builds.Builds) {
+		return nil
+	}
+
+	return widget.builds.Builds[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -74,34 +79,33 @@ func (widget *Widget) display() {
 	widget.View.SetWrap(false)
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - Builds", widget.Name())))
-	widget.View.SetText(widget.contentFrom(widget.builds))
+	widget.Render(widget.contentFrom(widget.builds))
 }
 
-func (widget *Widget) contentFrom(builds *Builds) string {
-	var str string
-	for idx, build := range builds.Builds {
-
-		str = str + fmt.Sprintf(
-			"[%s] [%s] %s-%s (%s) [%s]%s - [blue]%s\n",
-			widget.rowColor(idx),
-			buildColor(&build),
-			build.Repository.Name,
-			build.Number,
-			build.Branch.Name,
-			widget.rowColor(idx),
-			strings.Split(build.Commit.Message, "\n")[0],
-			build.CreatedBy.Login,
+func (widget *Widget) contentFrom(builds *Builds) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
+
+	section := content.AddSection("")
+
+	for idx := range builds.Builds {
+		build := &builds.Builds[idx]
+
+		row := section.AddRow(
+			wtf.Cell{Text: fmt.Sprintf("%s-%s (%s)", build.Repository.Name, build.Number, build.Branch.Name), Color: buildColor(build)},
+			wtf.Text(strings.Split(build.Commit.Message, "\n")[0]),
+			wtf.Cell{Text: build.CreatedBy.Login, Color: "blue"},
 		)
+		row.Link = widget.buildURL(build)
+		row.Selectable = true
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) rowColor(idx int) string {
-	if widget.View.HasFocus() && (idx == widget.selected) {
-		return wtf.DefaultFocussedRowColor()
-	}
-	return "White"
+func (widget *Widget) buildURL(build *Build) string {
+	travisHost := TRAVIS_HOSTS[wtf.Config.UBool(widget.ConfigKey("pro"), false)]
+	return fmt.Sprintf("https://%s/%s/%s/%d", travisHost, build.Repository.Slug, "builds", build.ID)
 }
 
 func buildColor(build *Build) string {
@@ -146,9 +150,7 @@ func (widget *Widget) prev() {
 func (widget *Widget) openBuild() {
 	sel := widget.selected
 	if sel >= 0 && widget.builds != nil && sel < len(widget.builds.Builds) {
-		build := &widget.builds.Builds[widget.selected]
-		travisHost := TRAVIS_HOSTS[wtf.Config.UBool("wtf.mods.travisci.pro", false)]
-		wtf.OpenFile(fmt.Sprintf("https://%s/%s/%s/%d", travisHost, build.Repository.Slug, "builds", build.ID))
+		wtf.OpenFile(widget.buildURL(&widget.builds.Builds[widget.selected]))
 	}
 }
 
@@ -157,36 +159,10 @@ func (widget *Widget) unselect() {
 	widget.display()
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-	case "j":
-		widget.next()
-		return nil
-	case "k":
-		widget.prev()
-		return nil
-	case "r":
-		widget.Refresh()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyDown:
-		widget.next()
-		return nil
-	case tcell.KeyEnter:
-		widget.openBuild()
-		return nil
-	case tcell.KeyEsc:
-		widget.unselect()
-		return event
-	case tcell.KeyUp:
-		widget.prev()
-		widget.display()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", widget.next)
+	widget.Keymap.On("prev", widget.prev)
+	widget.Keymap.On("open", widget.openBuild)
+	widget.Keymap.On("refresh", widget.RequestRefresh)
+	widget.Keymap.On("unselect", widget.unselect)
 }
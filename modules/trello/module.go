@@ -0,0 +1,23 @@
+package trello
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "trello",
+		Settings: wtf.ConfigSchema{
+			"accessToken": wtf.StringSetting,
+			"apiKey":      wtf.StringSetting,
+			"board":       wtf.StringSetting,
+			"list":        wtf.AnySetting,
+			"username":    wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
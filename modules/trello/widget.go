@@ -1,8 +1,8 @@
 package trello
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/adlio/trello"
 	"github.com/rivo/tview"
@@ -13,9 +13,9 @@ type Widget struct {
 	wtf.TextWidget
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Trello", "trello", false),
+		TextWidget: wtf.NewTextWidget(app, "Trello", configKey, false),
 	}
 
 	return &widget
@@ -23,69 +23,78 @@ func NewWidget(app *tview.Application) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
-	client := trello.NewClient(
-		widget.apiKey(),
-		widget.accessToken(),
-	)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	apiKey, err := widget.apiKey()
+	if err != nil {
+		return err
+	}
+
+	accessToken, err := widget.accessToken()
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := wtf.HTTPClient(widget.Key())
+	if err != nil {
+		return err
+	}
+
+	client := trello.NewClient(apiKey, accessToken)
+	client.Client = httpClient
+	client = client.WithContext(ctx)
 
 	// Get the cards
-	searchResult, err := GetCards(client, getLists())
+	searchResult, err := GetCards(
+		client,
+		wtf.Config.UString(widget.ConfigKey("username")),
+		wtf.Config.UString(widget.ConfigKey("board")),
+		widget.getLists(),
+	)
 
-	var content string
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetTitle(widget.Name())
-		content = err.Error()
-	} else {
-		widget.View.SetWrap(false)
-		widget.View.SetTitle(
-			fmt.Sprintf(
-				"[white]%s: [green]%s ",
-				widget.Name(),
-				wtf.Config.UString("wtf.mods.trello.board"),
-			),
-		)
-		content = widget.contentFrom(searchResult)
+		return err
 	}
 
-	widget.View.SetText(content)
+	widget.View.SetWrap(false)
+	widget.View.SetTitle(
+		fmt.Sprintf(
+			"[white]%s: [green]%s ",
+			widget.Name(),
+			wtf.Config.UString(widget.ConfigKey("board")),
+		),
+	)
+	widget.Render(widget.contentFrom(searchResult))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) accessToken() string {
-	return wtf.Config.UString(
-		"wtf.mods.trello.accessToken",
-		os.Getenv("WTF_TRELLO_ACCESS_TOKEN"),
-	)
+func (widget *Widget) accessToken() (string, error) {
+	return wtf.Credential(widget.ConfigKey("accessToken"), "WTF_TRELLO_ACCESS_TOKEN")
 }
 
-func (widget *Widget) apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.trello.apiKey",
-		os.Getenv("WTF_TRELLO_APP_KEY"),
-	)
+func (widget *Widget) apiKey() (string, error) {
+	return wtf.Credential(widget.ConfigKey("apiKey"), "WTF_TRELLO_APP_KEY")
 }
 
-func (widget *Widget) contentFrom(searchResult *SearchResult) string {
-	str := ""
+func (widget *Widget) contentFrom(searchResult *SearchResult) *wtf.Content {
+	content := wtf.NewContent()
 
 	for list, cardArray := range searchResult.TrelloCards {
-		str = fmt.Sprintf("%s [red]Cards in %s[white]\n", str, list)
+		section := content.AddSection("Cards in " + list)
 		for _, card := range cardArray {
-			str = fmt.Sprintf("%s [green]%s[white]\n", str, card.Name)
+			section.AddRow(wtf.Cell{Text: card.Name, Color: "green"})
 		}
-		str = fmt.Sprintf("%s\n", str)
 	}
 
-	return str
+	return content
 }
 
-func getLists() map[string]string {
+func (widget *Widget) getLists() map[string]string {
 	list := make(map[string]string)
 	// see if project is set to a single string
-	configPath := "wtf.mods.trello.list"
+	configPath := widget.ConfigKey("list")
 	singleList, err := wtf.Config.String(configPath)
 	if err == nil {
 		list[singleList] = ""
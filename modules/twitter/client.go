@@ -1,9 +1,9 @@
 package twitter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 
 	"github.com/wtfutil/wtf/wtf"
@@ -15,30 +15,29 @@ import (
 
 // Client represents the data required to connect to the Twitter API
 type Client struct {
-	apiBase     string
-	bearerToken string
-	count       int
-	screenName  string
+	apiBase    string
+	configKey  string
+	count      int
+	screenName string
 }
 
 // NewClient creates and returns a new Twitter client
-func NewClient() *Client {
+func NewClient(configKey string) *Client {
 	client := Client{
 		apiBase:    "https://api.twitter.com/1.1/",
-		count:      wtf.Config.UInt("wtf.mods.twitter.count", 5),
+		configKey:  configKey,
+		count:      wtf.Config.UInt(wtf.ConfigKeyFor(configKey, "count"), 5),
 		screenName: "",
 	}
 
-	client.loadAPICredentials()
-
 	return &client
 }
 
 /* -------------------- Public Functions -------------------- */
 
 // Tweets returns a list of tweets of a user
-func (client *Client) Tweets() []Tweet {
-	tweets, err := client.tweets()
+func (client *Client) Tweets(ctx context.Context) []Tweet {
+	tweets, err := client.tweets(ctx)
 	if err != nil {
 		return []Tweet{}
 	}
@@ -49,7 +48,15 @@ func (client *Client) Tweets() []Tweet {
 /* -------------------- Private Functions -------------------- */
 
 // tweets is the private interface for retrieving the list of user tweets
-func (client *Client) tweets() (tweets []Tweet, err error) {
+func (client *Client) tweets(ctx context.Context) (tweets []Tweet, err error) {
+	bearerToken, err := wtf.Credential(
+		wtf.ConfigKeyFor(client.configKey, "bearerToken"),
+		"WTF_TWITTER_BEARER_TOKEN",
+	)
+	if err != nil {
+		return tweets, err
+	}
+
 	apiURL := fmt.Sprintf(
 		"%s/statuses/user_timeline.json?screen_name=%s&count=%s",
 		client.apiBase,
@@ -57,7 +64,7 @@ func (client *Client) tweets() (tweets []Tweet, err error) {
 		strconv.Itoa(client.count),
 	)
 
-	data, err := Request(client.bearerToken, apiURL)
+	data, err := Request(ctx, client.configKey, bearerToken, apiURL)
 	if err != nil {
 		return tweets, err
 	}
@@ -65,10 +72,3 @@ func (client *Client) tweets() (tweets []Tweet, err error) {
 
 	return
 }
-
-func (client *Client) loadAPICredentials() {
-	client.bearerToken = wtf.Config.UString(
-		"wtf.mods.twitter.bearerToken",
-		os.Getenv("WTF_TWITTER_BEARER_TOKEN"),
-	)
-}
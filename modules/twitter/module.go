@@ -0,0 +1,23 @@
+package twitter
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "twitter",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"bearerToken": wtf.StringSetting,
+			"count":       wtf.IntSetting,
+			"screenName":  wtf.StringSetting,
+			"screenNames": wtf.ListSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -2,21 +2,29 @@ package twitter
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
-func Request(bearerToken string, apiURL string) ([]byte, error) {
+func Request(ctx context.Context, configKey string, bearerToken string, apiURL string) ([]byte, error) {
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	// Expected authorization format for single-application twitter dev accounts
 	req.Header.Add("Authorization",
 		fmt.Sprintf("Bearer %s", bearerToken))
 
-	client := &http.Client{}
+	client, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
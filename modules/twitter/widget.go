@@ -1,26 +1,23 @@
 package twitter
 
 import (
+	"context"
 	"fmt"
 	"html"
 	"regexp"
 
 	"github.com/dustin/go-humanize"
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-  Keyboard commands for Textfile:
-
-    /: Show/hide this help window
-    h: Previous Twitter name
-    l: Next Twitter name
-
-    arrow left:  Previous Twitter name
-    arrow right: Next Twitter name
-`
+// Keys are the Twitter widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next Twitter name"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous Twitter name"},
+	{Action: "open", Keys: []string{"o"}, Help: "Open the current Twitter name"},
+}
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -32,11 +29,11 @@ type Widget struct {
 	sources []string
 }
 
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, HelpText),
-		MultiSourceWidget: wtf.NewMultiSourceWidget("twitter", "screenName", "screenNames"),
-		TextWidget:        wtf.NewTextWidget(app, "Twitter", "twitter", true),
+		HelpfulWidget:     wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Twitter", configKey, Keys)),
+		MultiSourceWidget: wtf.NewMultiSourceWidget(configKey, "screenName", "screenNames"),
+		TextWidget:        wtf.NewTextWidget(app, "Twitter", configKey, true),
 
 		idx: 0,
 	}
@@ -44,36 +41,38 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	widget.HelpfulWidget.SetView(widget.View)
 
 	widget.LoadSources()
-	widget.SetDisplayFunction(widget.display)
+	widget.SetDisplayFunction(widget.RequestRefresh)
 
-	widget.client = NewClient()
+	widget.client = NewClient(widget.Key())
 
 	widget.View.SetBorderPadding(1, 1, 1, 1)
 	widget.View.SetWrap(true)
 	widget.View.SetWordWrap(true)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-// Refresh is called on the interval and refreshes the data
-func (widget *Widget) Refresh() {
-	widget.display()
+// Refresh is called on the interval, and when the source changes, and refreshes the data
+func (widget *Widget) Refresh(ctx context.Context) error {
+	widget.client.screenName = widget.CurrentSource()
+	widget.display(widget.client.Tweets(ctx))
+
+	return nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) display() {
-	widget.client.screenName = widget.CurrentSource()
-	tweets := widget.client.Tweets()
+func (widget *Widget) display(tweets []Tweet) {
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("Twitter - [green]@%s[white]", widget.CurrentSource())))
 
 	if len(tweets) == 0 {
 		str := fmt.Sprintf("\n\n\n%s", wtf.CenterText("[blue]No Tweets[white]", 50))
-		widget.View.SetText(str)
+		widget.SetContent(str)
 		return
 	}
 
@@ -82,7 +81,7 @@ func (widget *Widget) display() {
 		str = str + widget.format(tweet)
 	}
 
-	widget.View.SetText(str)
+	widget.SetContent(str)
 }
 
 // If the tweet's Username is the same as the account we're watching, no
@@ -137,32 +136,12 @@ func (widget *Widget) format(tweet Tweet) string {
 	return fmt.Sprintf("%s\n[grey]%s[white]\n\n", body, attribution)
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	case "o":
-		wtf.OpenFile(widget.CurrentSource())
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
+	widget.Keymap.On("open", widget.openSource)
+}
 
-	return event
+func (widget *Widget) openSource() {
+	wtf.OpenFile(widget.CurrentSource())
 }
@@ -1,6 +1,7 @@
 package unknown
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/rivo/tview"
@@ -21,11 +22,13 @@ func NewWidget(app *tview.Application, name string) *Widget {
 
 /* -------------------- Exported Functions -------------------- */
 
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s", widget.Name())))
 	widget.View.Clear()
 
 	content := fmt.Sprintf("Widget %s does not exist", widget.Name())
-	widget.View.SetText(content)
+	widget.SetContent(content)
+
+	return nil
 }
@@ -1,10 +1,10 @@
 package victorops
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/wtfutil/wtf/logger"
@@ -12,37 +12,45 @@ import (
 )
 
 // Fetch gets the current oncall users
-func Fetch() ([]OnCallTeam, error) {
+func Fetch(ctx context.Context, configKey string) ([]OnCallTeam, error) {
+	apiID, err := apiID(configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := apiKey(configKey)
+	if err != nil {
+		return nil, err
+	}
+
 	scheduleURL := "https://api.victorops.com/api-public/v1/oncall/current"
-	response, err := victorOpsRequest(scheduleURL, apiID(), apiKey())
+	response, err := victorOpsRequest(ctx, configKey, scheduleURL, apiID, apiKey)
 	return response, err
 }
 
 /* ---------------- Unexported Functions ---------------- */
-func apiID() string {
-	return wtf.Config.UString(
-		"wtf.mods.victorops.apiID",
-		os.Getenv("WTF_VICTOROPS_API_ID"),
-	)
+func apiID(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiID"), "WTF_VICTOROPS_API_ID")
 }
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.victorops.apiKey",
-		os.Getenv("WTF_VICTOROPS_API_KEY"),
-	)
+func apiKey(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "WTF_VICTOROPS_API_KEY")
 }
 
-func victorOpsRequest(url string, apiID string, apiKey string) ([]OnCallTeam, error) {
+func victorOpsRequest(ctx context.Context, configKey string, url string, apiID string, apiKey string) ([]OnCallTeam, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		logger.Log(fmt.Sprintf("Failed to initialize sessions to VictorOps. ERROR: %s", err))
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("X-VO-Api-Id", apiID)
 	req.Header.Set("X-VO-Api-Key", apiKey)
-	client := &http.Client{}
+	client, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
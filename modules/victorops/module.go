@@ -0,0 +1,22 @@
+package victorops
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name:     "victorops",
+		HelpText: HelpText,
+		Settings: wtf.ConfigSchema{
+			"apiID":  wtf.StringSetting,
+			"apiKey": wtf.StringSetting,
+			"team":   wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,7 +1,7 @@
 package victorops
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -23,9 +23,9 @@ type Widget struct {
 }
 
 // NewWidget creates a new widget
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "VictorOps - OnCall", "victorops", true),
+		TextWidget: wtf.NewTextWidget(app, "VictorOps - OnCall", configKey, true),
 	}
 
 	widget.View.SetScrollable(true)
@@ -35,22 +35,22 @@ func NewWidget(app *tview.Application) *Widget {
 }
 
 // Refresh gets latest content for the widget
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
 	if widget.Disabled() {
-		return
+		return nil
 	}
 
-	teams, err := Fetch()
-	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
-
+	teams, err := Fetch(ctx, widget.Key())
 	if err != nil {
-		widget.View.SetWrap(true)
-		widget.View.SetText(err.Error())
-	} else {
-		widget.teams = teams
+		return err
 	}
 
+	widget.View.SetTitle(widget.ContextualTitle(widget.Name()))
+
+	widget.teams = teams
 	widget.display()
+
+	return nil
 }
 
 func (widget *Widget) display() {
@@ -60,30 +60,31 @@ func (widget *Widget) display() {
 
 	widget.View.SetWrap(false)
 	widget.View.Clear()
-	widget.View.SetText(widget.contentFrom(widget.teams))
+	widget.Render(widget.contentFrom(widget.teams))
 }
 
-func (widget *Widget) contentFrom(teams []OnCallTeam) string {
-	teamToDisplay := wtf.Config.UString("wtf.mods.victorops.team")
-	var str string
+func (widget *Widget) contentFrom(teams []OnCallTeam) *wtf.Content {
+	content := wtf.NewContent()
+
+	teamToDisplay := wtf.Config.UString(widget.ConfigKey("team"))
 	for _, team := range teams {
 		if len(teamToDisplay) > 0 && teamToDisplay != team.Slug {
 			continue
 		}
 
-		str = fmt.Sprintf("%s[green]%s\n", str, team.Name)
+		section := content.AddSection(team.Name)
+		section.TitleColor = "green"
+
 		if len(team.OnCall) == 0 {
-			str = fmt.Sprintf("%s[grey]no one\n", str)
+			section.AddRow(wtf.Cell{Text: "no one", Color: "grey"})
 		}
 		for _, onCall := range team.OnCall {
-			str = fmt.Sprintf("%s[white]%s - %s\n", str, onCall.Policy, onCall.Userlist)
+			section.AddRow(wtf.Text(onCall.Policy), wtf.Text("-"), wtf.Text(onCall.Userlist))
 		}
-
-		str = fmt.Sprintf("%s\n", str)
 	}
 
-	if len(str) == 0 {
-		str = "Could not find any teams to display"
+	if len(content.Sections) == 0 {
+		content.AddSection("").AddRow(wtf.Text("Could not find any teams to display"))
 	}
-	return str
+	return content
 }
@@ -0,0 +1,22 @@
+package prettyweather
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "prettyweather",
+		Settings: wtf.ConfigSchema{
+			"city":     wtf.StringSetting,
+			"language": wtf.StringSetting,
+			"unit":     wtf.StringSetting,
+			"view":     wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, configKey)
+		},
+		Network: true,
+	})
+}
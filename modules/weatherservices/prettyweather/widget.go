@@ -1,6 +1,7 @@
 package prettyweather
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -18,49 +19,56 @@ type Widget struct {
 	language string
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Pretty Weather", "prettyweather", false),
+		TextWidget: wtf.NewTextWidget(app, "Pretty Weather", configKey, false),
 	}
 
 	return &widget
 }
 
-func (widget *Widget) Refresh() {
-	widget.prettyWeather()
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if err := widget.prettyWeather(ctx); err != nil {
+		return err
+	}
+
+	widget.SetContent(widget.result)
 
-	widget.View.SetText(widget.result)
+	return nil
 }
 
 //this method reads the config and calls wttr.in for pretty weather
-func (widget *Widget) prettyWeather() {
-	client := &http.Client{}
-	widget.unit = wtf.Config.UString("wtf.mods.prettyweather.unit", "m")
-	widget.city = wtf.Config.UString("wtf.mods.prettyweather.city", "")
-	widget.view = wtf.Config.UString("wtf.mods.prettyweather.view", "0")
-	widget.language = wtf.Config.UString("wtf.mods.prettyweather.language", "en")
+func (widget *Widget) prettyWeather(ctx context.Context) error {
+	client, err := wtf.HTTPClient(widget.Key())
+	if err != nil {
+		return err
+	}
+
+	widget.unit = wtf.Config.UString(widget.ConfigKey("unit"), "m")
+	widget.city = wtf.Config.UString(widget.ConfigKey("city"), "")
+	widget.view = wtf.Config.UString(widget.ConfigKey("view"), "0")
+	widget.language = wtf.Config.UString(widget.ConfigKey("language"), "en")
 	req, err := http.NewRequest("GET", "https://wttr.in/"+widget.city+"?"+widget.view+"?"+widget.unit, nil)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("Accept-Language", widget.language)
 	req.Header.Set("User-Agent", "curl")
 	response, err := client.Do(req)
 	if err != nil {
-		widget.result = err.Error()
-		return
-
+		return err
 	}
 	defer response.Body.Close()
 
 	contents, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		widget.result = err.Error()
-		return
+		return err
 	}
 
 	//widget.result = strings.TrimSpace(string(contents))
 	widget.result = strings.TrimSpace(wtf.ASCIItoTviewColors(string(contents)))
+
+	return nil
 }
@@ -11,18 +11,18 @@ import (
 func (widget *Widget) display() {
 
 	if widget.apiKeyValid() == false {
-		widget.View.SetText(" Environment variable WTF_OWM_API_KEY is not set")
+		widget.SetContent(" Environment variable WTF_OWM_API_KEY is not set")
 		return
 	}
 
 	cityData := widget.currentData()
 	if cityData == nil {
-		widget.View.SetText(" Weather data is unavailable: no city data")
+		widget.SetContent(" Weather data is unavailable: no city data")
 		return
 	}
 
 	if len(cityData.Weather) == 0 {
-		widget.View.SetText(" Weather data is unavailable: no weather data")
+		widget.SetContent(" Weather data is unavailable: no weather data")
 		return
 	}
 
@@ -33,7 +33,7 @@ func (widget *Widget) display() {
 	content = content + widget.temperatures(cityData) + "\n"
 	content = content + widget.sunInfo(cityData)
 
-	widget.View.SetText(content)
+	widget.SetContent(content)
 }
 
 func (widget *Widget) description(cityData *owm.CurrentWeatherData) string {
@@ -54,14 +54,14 @@ func (widget *Widget) sunInfo(cityData *owm.CurrentWeatherData) string {
 }
 
 func (widget *Widget) temperatures(cityData *owm.CurrentWeatherData) string {
-	tempUnit := wtf.Config.UString("wtf.mods.weather.tempUnit", "C")
+	tempUnit := wtf.Config.UString(widget.ConfigKey("tempUnit"), "C")
 
 	str := fmt.Sprintf("%8s: %4.1f° %s\n", "High", cityData.Main.TempMax, tempUnit)
 
 	str = str + fmt.Sprintf(
 		"%8s: [%s]%4.1f° %s[white]\n",
 		"Current",
-		wtf.Config.UString("wtf.mods.weather.colors.current", "green"),
+		wtf.Config.UString(widget.ConfigKey("colors.current"), "green"),
 		cityData.Main.Temp,
 		tempUnit,
 	)
@@ -0,0 +1,24 @@
+package weather
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "weather",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":         wtf.StringSetting,
+			"cityids":        wtf.ListSetting,
+			"colors.current": wtf.StringSetting,
+			"language":       wtf.StringSetting,
+			"tempUnit":       wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,24 +1,19 @@
 package weather
 
 import (
-	"os"
+	"context"
 
 	owm "github.com/briandowns/openweathermap"
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const HelpText = `
-  Keyboard commands for Weather:
-
-    /: Show/hide this help window
-    h: Previous weather location
-    l: Next weather location
-
-    arrow left:  Previous weather location
-    arrow right: Next weather location
-`
+// Keys are the Weather widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "nextSource", Keys: []string{"l", "right"}, Help: "Next weather location"},
+	{Action: "prevSource", Keys: []string{"h", "left"}, Help: "Previous weather location"},
+}
 
 // Widget is the container for weather data.
 type Widget struct {
@@ -31,19 +26,17 @@ type Widget struct {
 }
 
 // NewWidget creates and returns a new instance of the weather Widget.
-func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
-	configKey := "weather"
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Weather", configKey, Keys)),
 		TextWidget:    wtf.NewTextWidget(app, "Weather", configKey, true),
 
 		Idx: 0,
 	}
 
-	widget.loadAPICredentials()
-
 	widget.HelpfulWidget.SetView(widget.View)
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
@@ -53,27 +46,40 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 // Fetch retrieves OpenWeatherMap data from the OpenWeatherMap API.
 // It takes a list of OpenWeatherMap city IDs.
 // It returns a list of OpenWeatherMap CurrentWeatherData structs, one per valid city code.
-func (widget *Widget) Fetch(cityIDs []int) []*owm.CurrentWeatherData {
+func (widget *Widget) Fetch(ctx context.Context, cityIDs []int) ([]*owm.CurrentWeatherData, error) {
 	data := []*owm.CurrentWeatherData{}
 
 	for _, cityID := range cityIDs {
-		result, err := widget.currentWeather(widget.APIKey, cityID)
-		if err == nil {
-			data = append(data, result)
+		result, err := widget.currentWeather(ctx, widget.APIKey, cityID)
+		if err != nil {
+			return nil, err
 		}
+
+		data = append(data, result)
 	}
 
-	return data
+	return data, nil
 }
 
 // Refresh fetches new data from the OpenWeatherMap API and loads the new data into the.
 // widget's view for rendering
-func (widget *Widget) Refresh() {
+func (widget *Widget) Refresh(ctx context.Context) error {
+	if err := widget.loadAPICredentials(); err != nil {
+		return err
+	}
+
 	if widget.apiKeyValid() {
-		widget.Data = widget.Fetch(wtf.ToInts(wtf.Config.UList("wtf.mods.weather.cityids", widget.defaultCityCodes())))
+		data, err := widget.Fetch(ctx, wtf.ToInts(wtf.Config.UList(widget.ConfigKey("cityids"), widget.defaultCityCodes())))
+		if err != nil {
+			return err
+		}
+
+		widget.Data = data
 	}
 
 	widget.display()
+
+	return nil
 }
 
 // Next displays data for the next city data in the list. If the current city is the last
@@ -124,11 +130,17 @@ func (widget *Widget) currentData() *owm.CurrentWeatherData {
 	return widget.Data[widget.Idx]
 }
 
-func (widget *Widget) currentWeather(apiKey string, cityCode int) (*owm.CurrentWeatherData, error) {
+func (widget *Widget) currentWeather(ctx context.Context, apiKey string, cityCode int) (*owm.CurrentWeatherData, error) {
+	httpClient, err := wtf.HTTPClientWithContext(ctx, widget.Key())
+	if err != nil {
+		return nil, err
+	}
+
 	weather, err := owm.NewCurrent(
-		wtf.Config.UString("wtf.mods.weather.tempUnit", "C"),
-		wtf.Config.UString("wtf.mods.weather.language", "EN"),
+		wtf.Config.UString(widget.ConfigKey("tempUnit"), "C"),
+		wtf.Config.UString(widget.ConfigKey("language"), "EN"),
 		apiKey,
+		owm.WithHttpClient(httpClient),
 	)
 	if err != nil {
 		return nil, err
@@ -153,36 +165,20 @@ func (widget *Widget) defaultCityCodes() []interface{} {
 	return defaults
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "/":
-		widget.ShowHelp()
-		return nil
-	case "h":
-		widget.Prev()
-		return nil
-	case "l":
-		widget.Next()
-		return nil
-	}
-
-	switch event.Key() {
-	case tcell.KeyLeft:
-		widget.Prev()
-		return nil
-	case tcell.KeyRight:
-		widget.Next()
-		return nil
-	default:
-		return event
-	}
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("nextSource", widget.Next)
+	widget.Keymap.On("prevSource", widget.Prev)
 }
 
 // loadAPICredentials loads the API authentication credentials for this module
 // First checks to see if they're in the config file. If not, checks the ENV var
-func (widget *Widget) loadAPICredentials() {
-	widget.APIKey = wtf.Config.UString(
-		"wtf.mods.weather.apiKey",
-		os.Getenv("WTF_OWM_API_KEY"),
-	)
+func (widget *Widget) loadAPICredentials() error {
+	apiKey, err := wtf.Credential(widget.ConfigKey("apiKey"), "WTF_OWM_API_KEY")
+	if err != nil {
+		return err
+	}
+
+	widget.APIKey = apiKey
+
+	return nil
 }
@@ -2,6 +2,7 @@ package zendesk
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -16,16 +17,13 @@ type Resource struct {
 	Raw      string
 }
 
-func apiKey() string {
-	return wtf.Config.UString(
-		"wtf.mods.zendesk.apiKey",
-		os.Getenv("ZENDESK_API"),
-	)
+func apiKey(configKey string) (string, error) {
+	return wtf.Credential(wtf.ConfigKeyFor(configKey, "apiKey"), "ZENDESK_API")
 }
 
-func subdomain() string {
+func subdomain(configKey string) string {
 	return wtf.Config.UString(
-		"wtf.mods.zendesk.subdomain",
+		wtf.ConfigKeyFor(configKey, "subdomain"),
 		os.Getenv("ZENDESK_SUBDOMAIN"),
 	)
 }
@@ -36,24 +34,24 @@ func errHandler(err error) {
 	}
 }
 
-func api(key string, meth string, path string, params string) (*Resource, error) {
-	trn := &http.Transport{}
-
-	client := &http.Client{
-		Transport: trn,
+func api(ctx context.Context, configKey string, key string, meth string, path string, params string) (*Resource, error) {
+	client, err := wtf.HTTPClient(configKey)
+	if err != nil {
+		return nil, err
 	}
 
-	baseURL := fmt.Sprintf("https://%v.zendesk.com/api/v2", subdomain())
+	baseURL := fmt.Sprintf("https://%v.zendesk.com/api/v2", subdomain(configKey))
 	URL := baseURL + "/tickets.json?sort_by=status"
 
 	req, err := http.NewRequest(meth, URL, bytes.NewBufferString(params))
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Add("Content-Type", "application/json")
 
-	username := wtf.Config.UString("wtf.mods.zendesk.username")
+	username := wtf.Config.UString(wtf.ConfigKeyFor(configKey, "username"))
 	apiUser := fmt.Sprintf("%v/token", username)
 	req.SetBasicAuth(apiUser, key)
 
@@ -0,0 +1,23 @@
+package zendesk
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.Module{
+		Name: "zendesk",
+		Keys: Keys,
+		Settings: wtf.ConfigSchema{
+			"apiKey":    wtf.StringSetting,
+			"status":    wtf.StringSetting,
+			"subdomain": wtf.StringSetting,
+			"username":  wtf.StringSetting,
+		},
+		Factory: func(app *tview.Application, pages *tview.Pages, configKey string) wtf.Wtfable {
+			return NewWidget(app, pages, configKey)
+		},
+		Network: true,
+	})
+}
@@ -1,8 +1,8 @@
 package zendesk
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 )
 
 type TicketArray struct {
@@ -45,7 +45,7 @@ type Ticket struct {
 	Fields                interface{} `json:"fields"`
 }
 
-func listTickets(pag ...string) (*TicketArray, error) {
+func listTickets(ctx context.Context, configKey string, pag ...string) (*TicketArray, error) {
 
 	TicketStruct := &TicketArray{}
 
@@ -55,7 +55,12 @@ func listTickets(pag ...string) (*TicketArray, error) {
 	} else {
 		path = pag[0]
 	}
-	resource, err := api(apiKey(), "GET", path, "")
+	apiKey, err := apiKey(configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := api(ctx, configKey, apiKey, "GET", path, "")
 	if err != nil {
 		return nil, err
 	}
@@ -66,11 +71,11 @@ func listTickets(pag ...string) (*TicketArray, error) {
 
 }
 
-func newTickets(ticketStatus string) (*TicketArray, error) {
+func newTickets(ctx context.Context, configKey string, ticketStatus string) (*TicketArray, error) {
 	newTicketArray := &TicketArray{}
-	tickets, err := listTickets()
+	tickets, err := listTickets(ctx, configKey)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	for _, Ticket := range tickets.Tickets {
 		if Ticket.Status == ticketStatus && Ticket.Status != "closed" && Ticket.Status != "solved" {
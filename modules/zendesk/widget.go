@@ -1,74 +1,102 @@
 package zendesk
 
 import (
+	"context"
 	"fmt"
-	"log"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
 )
 
+// Keys are the Zendesk widget's commands, and the keys they're bound to by default
+var Keys = []wtf.KeyBinding{
+	wtf.HelpKeyBinding,
+	{Action: "next", Keys: []string{"j", "down"}, Help: "Select the next ticket in the list"},
+	{Action: "prev", Keys: []string{"k", "up"}, Help: "Select the previous ticket in the list"},
+	{Action: "open", Keys: []string{"enter"}, Help: "Open the selected ticket in a browser"},
+	{Action: "unselect", Keys: []string{"esc"}, Help: "Unselect the ticket"},
+}
+
 type Widget struct {
+	wtf.HelpfulWidget
 	wtf.TextWidget
 
 	result   *TicketArray
 	selected int
 }
 
-func NewWidget(app *tview.Application) *Widget {
+func NewWidget(app *tview.Application, pages *tview.Pages, configKey string) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "Zendesk", "zendesk", true),
+		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, wtf.NewKeymap("Zendesk", configKey, Keys)),
+		TextWidget:    wtf.NewTextWidget(app, "Zendesk", configKey, true),
 	}
 
-	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.HelpfulWidget.SetView(widget.View)
+	widget.bindKeys()
+	widget.View.SetInputCapture(widget.Keymap.InputCapture)
 
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
-func (widget *Widget) Refresh() {
-	ticketStatus := wtf.Config.UString("wtf.mods.zendesk.status")
-	ticketArray, err := newTickets(ticketStatus)
-	ticketArray.Count = len(ticketArray.Tickets)
+func (widget *Widget) Refresh(ctx context.Context) error {
+	ticketStatus := wtf.Config.UString(widget.ConfigKey("status"))
+	ticketArray, err := newTickets(ctx, widget.Key(), ticketStatus)
 	if err != nil {
-		log.Fatal(err)
-	} else {
-		widget.result = ticketArray
+		return err
 	}
 
+	ticketArray.Count = len(ticketArray.Tickets)
+
+	widget.result = ticketArray
 	widget.display()
+
+	return nil
+}
+
+// SelectedItem returns the selected ticket, for actions to run on
+func (widget *Widget) SelectedItem() interface{} {
+	sel := widget.selected
+	if sel < 0 || widget.result == nil || sel >= len(widget.result.Tickets) {
+		return nil
+	}
+
+	return widget.result.Tickets[sel]
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
 	widget.View.SetTitle(fmt.Sprintf("%s (%d)", widget.Name(), widget.result.Count))
-	widget.View.SetText(widget.textContent(widget.result.Tickets))
+	widget.Render(widget.contentFrom(widget.result.Tickets))
 }
 
-func (widget *Widget) textContent(items []Ticket) string {
+func (widget *Widget) contentFrom(items []Ticket) *wtf.Content {
+	content := wtf.NewContent()
+	content.Selected = widget.selected
+
 	if len(items) == 0 {
-		return fmt.Sprintf("No unassigned tickets in queue - woop!!")
+		content.AddSection("").AddRow(wtf.Text("No unassigned tickets in queue - woop!!"))
+		return content
 	}
 
-	str := ""
-	for idx, data := range items {
-		str = str + widget.format(data, idx)
+	for _, data := range items {
+		widget.addTicket(content.AddSection(""), data)
 	}
 
-	return str
+	return content
 }
 
-func (widget *Widget) format(ticket Ticket, idx int) string {
-	var str string
-	requesterName := widget.parseRequester(ticket)
-	textColor := wtf.Config.UString("wtf.colors.background", "green")
-	if idx == widget.selected {
-		textColor = wtf.Config.UString("wtf.colors.background", "orange")
-	}
-	str = fmt.Sprintf(" [%s:]%d - %s\n %s\n\n", textColor, ticket.Id, requesterName, ticket.Subject)
-	return str
+func (widget *Widget) addTicket(section *wtf.Section, ticket Ticket) {
+	row := section.AddRow(wtf.Text(fmt.Sprintf("%d - %v", ticket.Id, widget.parseRequester(ticket))))
+	row.Link = widget.ticketURL(ticket)
+	row.Selectable = true
+
+	section.AddRow(wtf.Text(ticket.Subject))
+}
+
+func (widget *Widget) ticketURL(ticket Ticket) string {
+	return fmt.Sprintf("https://%s.zendesk.com/agent/tickets/%d", subdomain(widget.Key()), ticket.Id)
 }
 
 // this is a nasty means of extracting the actual name of the requester from the Via interface of the Ticket.
@@ -101,9 +129,7 @@ func (widget *Widget) prev() {
 func (widget *Widget) openTicket() {
 	sel := widget.selected
 	if sel >= 0 && widget.result != nil && sel < len(widget.result.Tickets) {
-		issue := &widget.result.Tickets[widget.selected]
-		ticketUrl := fmt.Sprintf("https://%s.zendesk.com/agent/tickets/%d", subdomain(), issue.Id)
-		wtf.OpenFile(ticketUrl)
+		wtf.OpenFile(widget.ticketURL(widget.result.Tickets[widget.selected]))
 	}
 }
 
@@ -111,40 +137,18 @@ func (widget *Widget) unselect() {
 	widget.selected = -1
 }
 
-func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	switch string(event.Rune()) {
-	case "j":
-		// Select the next item down
-		widget.next()
-		widget.display()
-		return nil
-	case "k":
-		// Select the next item up
-		widget.prev()
-		widget.display()
-		return nil
-	}
-	switch event.Key() {
-	case tcell.KeyDown:
-		// Select the next item down
+func (widget *Widget) bindKeys() {
+	widget.Keymap.On("next", func() {
 		widget.next()
 		widget.display()
-		return nil
-	case tcell.KeyUp:
-		// Select the next item up
+	})
+	widget.Keymap.On("prev", func() {
 		widget.prev()
 		widget.display()
-		return nil
-	case tcell.KeyEnter:
-		widget.openTicket()
-		return nil
-	case tcell.KeyEsc:
-		// Unselect the current row
+	})
+	widget.Keymap.On("open", widget.openTicket)
+	widget.Keymap.On("unselect", func() {
 		widget.unselect()
 		widget.display()
-		return event
-	default:
-		// Pass it along
-		return event
-	}
+	})
 }
@@ -0,0 +1,274 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Dashboard is what the API reads from and acts on. It's supplied by the caller, because the
+// widgets and boards it covers are rebuilt whenever the config changes
+type Dashboard interface {
+	// Widgets returns the running widgets
+	Widgets() []wtf.Wtfable
+
+	// Focus shows the board the widget is on and focuses it, and returns false if it can't
+	Focus(key string) bool
+}
+
+// Server is a local HTTP server that exposes the state of the running widgets as JSON:
+//
+//	GET  /widgets               every widget, with when it last refreshed and its error
+//	GET  /widgets/<key>         one widget, along with its content
+//	POST /widgets/<key>/refresh refreshes the widget as soon as possible
+//	POST /widgets/<key>/focus   focuses the widget
+type Server struct {
+	dashboard Dashboard
+	http      *http.Server
+	mux       *http.ServeMux
+}
+
+// widgetState is a widget as it's returned by the API
+type widgetState struct {
+	Key         string     `json:"key"`
+	Title       string     `json:"title"`
+	Focusable   bool       `json:"focusable"`
+	Hidden      bool       `json:"hidden"`
+	RefreshedAt *time.Time `json:"refreshedAt"`
+	Error       string     `json:"error,omitempty"`
+	FailedAt    *time.Time `json:"failedAt,omitempty"`
+	Crashed     bool       `json:"crashed,omitempty"`
+
+	Lines   []string     `json:"lines,omitempty"`
+	Content *wtf.Content `json:"content,omitempty"`
+}
+
+// NewServer creates a server that listens on the given address, i.e.: "127.0.0.1:7788". Hosts
+// other than localhost that requests may be made for can be listed in "wtf.server.allowedHosts"
+func NewServer(addr string, dashboard Dashboard) *Server {
+	server := Server{
+		dashboard: dashboard,
+		mux:       http.NewServeMux(),
+	}
+
+	server.mux.HandleFunc("/widgets", server.handleWidgets)
+	server.mux.HandleFunc("/widgets/", server.handleWidget)
+
+	server.http = &http.Server{
+		Addr:    addr,
+		Handler: sameOriginOnly(addr, wtf.ToStrs(wtf.Config.UList("wtf.server.allowedHosts")), server.mux),
+	}
+
+	return &server
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Start begins listening, and returns an error if the address can't be listened on. Requests
+// are served in the background until Stop is called
+func (server *Server) Start() error {
+	listener, err := net.Listen("tcp", server.http.Addr)
+	if err != nil {
+		return err
+	}
+
+	go server.http.Serve(listener)
+
+	return nil
+}
+
+// Stop closes the listener and waits briefly for in-flight requests to finish
+func (server *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	server.http.Shutdown(ctx)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (server *Server) handleWidgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is allowed")
+		return
+	}
+
+	states := []widgetState{}
+	for _, widget := range server.dashboard.Widgets() {
+		states = append(states, stateOf(widget))
+	}
+
+	writeJSON(w, http.StatusOK, states)
+}
+
+// handleWidget serves "/widgets/<key>" and the actions under it
+func (server *Server) handleWidget(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/widgets/"), "/")
+
+	widget := server.widget(parts[0])
+	if widget == nil {
+		writeError(w, http.StatusNotFound, "no widget with the key '"+parts[0]+"'")
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		state := stateOf(widget)
+		state.Lines = wtf.PlainLines(widget.Content())
+		state.Content = widget.Model()
+
+		writeJSON(w, http.StatusOK, state)
+	case len(parts) == 2 && parts[1] == "refresh" && r.Method == http.MethodPost:
+		widget.RequestRefresh()
+		writeJSON(w, http.StatusAccepted, stateOf(widget))
+	case len(parts) == 2 && parts[1] == "focus" && r.Method == http.MethodPost:
+		if !server.dashboard.Focus(widget.Key()) {
+			writeError(w, http.StatusConflict, "the widget can't be focused")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, stateOf(widget))
+	case len(parts) == 1 || (len(parts) == 2 && (parts[1] == "refresh" || parts[1] == "focus")):
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (server *Server) widget(key string) wtf.Wtfable {
+	for _, widget := range server.dashboard.Widgets() {
+		if widget.Key() == key {
+			return widget
+		}
+	}
+
+	return nil
+}
+
+// sameOriginOnly refuses requests that a web page on another origin makes through the user's
+// browser, since anything listening on localhost can otherwise be driven by any site. Requests
+// for hosts that hostAllowed doesn't allow are refused too, so that a site whose name has been
+// rebound to 127.0.0.1 can't pass itself off as the same origin
+func sameOriginOnly(addr string, allowedHosts []string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hostAllowed(r.Host, addr, allowedHosts) {
+			writeError(w, http.StatusForbidden, "requests for the host '"+r.Host+"' are not allowed")
+			return
+		}
+
+		if origin := r.Header.Get("Origin"); origin != "" {
+			originURL, err := url.Parse(origin)
+			if err != nil || originURL.Host != r.Host {
+				writeError(w, http.StatusForbidden, "cross-origin requests are not allowed")
+				return
+			}
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// hostAllowed returns true if requests for the host are served. localHost is always allowed.
+// Otherwise, with allowedHosts listed, the host has to be one of them, with or without its port.
+// Without them, any host is allowed when listening on every interface or a network address,
+// i.e.: ":7788" or "0.0.0.0:7788" for a screen elsewhere on the network, and none when
+// listening on loopback only
+func hostAllowed(host, addr string, allowedHosts []string) bool {
+	if localHost(host, addr) {
+		return true
+	}
+
+	if len(allowedHosts) > 0 {
+		name := host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			name = hostname
+		}
+
+		for _, allowed := range allowedHosts {
+			if strings.EqualFold(allowed, host) || strings.EqualFold(allowed, name) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return !loopbackOnly(addr)
+}
+
+// loopbackOnly returns true if the listen address can only be reached from this machine
+func loopbackOnly(addr string) bool {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	if addr == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsLoopback()
+}
+
+// localHost returns true if the host a request was made for is the address being listened on,
+// or localhost on any port
+func localHost(host, addr string) bool {
+	if host == addr {
+		return true
+	}
+
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func stateOf(widget wtf.Wtfable) widgetState {
+	state := widgetState{
+		Key:         widget.Key(),
+		Title:       widget.Name(),
+		Focusable:   widget.Focusable(),
+		Hidden:      widget.Hidden(),
+		RefreshedAt: timeOrNil(widget.LastRefreshed()),
+		Crashed:     widget.Crashed(),
+	}
+
+	if err, failedAt := widget.LastRefreshError(); err != nil {
+		state.Error = err.Error()
+		state.FailedAt = timeOrNil(failedAt)
+	}
+
+	return state
+}
+
+// timeOrNil returns nil for the zero time, so that it's written as null rather than year 1
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type testWidget struct {
+	wtf.TextWidget
+}
+
+func (widget *testWidget) Refresh(ctx context.Context) error {
+	return nil
+}
+
+type testDashboard struct {
+	focused string
+	widgets []wtf.Wtfable
+}
+
+func (dashboard *testDashboard) Widgets() []wtf.Wtfable {
+	return dashboard.widgets
+}
+
+func (dashboard *testDashboard) Focus(key string) bool {
+	dashboard.focused = key
+	return true
+}
+
+func newTestServer() (*httptest.Server, *testDashboard) {
+	wtf.Config, _ = config.ParseYaml("wtf:\n  mods:\n    news:\n      enabled: true\n")
+
+	widget := &testWidget{TextWidget: wtf.NewTextWidget(nil, "News", "news", true)}
+	widget.SetContent("[green]headline")
+
+	dashboard := &testDashboard{widgets: []wtf.Wtfable{widget}}
+
+	return httptest.NewServer(NewServer("127.0.0.1:7788", dashboard).http.Handler), dashboard
+}
+
+func Test_Widgets(t *testing.T) {
+	ts, _ := newTestServer()
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/widgets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	states := []widgetState{}
+	json.NewDecoder(resp.Body).Decode(&states)
+
+	if len(states) != 1 || states[0].Key != "news" || states[0].Title != "News" {
+		t.Errorf("expected: the news widget, got: %v", states)
+	}
+}
+
+func Test_Widget(t *testing.T) {
+	ts, _ := newTestServer()
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		lines  []string
+	}{
+		{"/widgets/news", http.StatusOK, []string{"headline"}},
+		{"/widgets/weather", http.StatusNotFound, nil},
+		{"/widgets/news/unknown", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		state := widgetState{}
+		json.NewDecoder(resp.Body).Decode(&state)
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: expected: %v, got: %v", tt.path, tt.status, resp.StatusCode)
+		}
+
+		if strings.Join(state.Lines, "\n") != strings.Join(tt.lines, "\n") {
+			t.Errorf("%s: expected: %v, got: %v", tt.path, tt.lines, state.Lines)
+		}
+	}
+}
+
+func Test_Actions(t *testing.T) {
+	ts, dashboard := newTestServer()
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/widgets/news/focus", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || dashboard.focused != "news" {
+		t.Errorf("focus: expected: %v, got: %v", http.StatusOK, resp.StatusCode)
+	}
+
+	resp, err = http.Post(ts.URL+"/widgets/news/refresh", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("refresh: expected: %v, got: %v", http.StatusAccepted, resp.StatusCode)
+	}
+
+	select {
+	case <-dashboard.widgets[0].RefreshRequests():
+	default:
+		t.Errorf("refresh: expected a refresh request")
+	}
+}
+
+func Test_SameOriginOnly(t *testing.T) {
+	ts, dashboard := newTestServer()
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/widgets/news/focus", nil)
+	req.Header.Set("Origin", "https://example.com")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden || dashboard.focused != "" {
+		t.Errorf("expected: %v, got: %v", http.StatusForbidden, resp.StatusCode)
+	}
+}
+
+func Test_LocalHostOnly(t *testing.T) {
+	ts, dashboard := newTestServer()
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/widgets/news/focus", nil)
+	req.Host = "rebound.example.com"
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden || dashboard.focused != "" {
+		t.Errorf("expected: %v, got: %v", http.StatusForbidden, resp.StatusCode)
+	}
+
+	tests := []struct {
+		host     string
+		expected bool
+	}{
+		{"localhost:7788", true},
+		{"127.0.0.1:7788", true},
+		{"[::1]:7788", true},
+		{"wtf.lan:7788", true},
+		{"192.168.1.5:7788", false},
+		{"rebound.example.com:7788", false},
+	}
+
+	for _, tt := range tests {
+		actual := localHost(tt.host, "wtf.lan:7788")
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.host, tt.expected, actual)
+		}
+	}
+}
+
+func Test_HostAllowed(t *testing.T) {
+	tests := []struct {
+		host         string
+		addr         string
+		allowedHosts []string
+		expected     bool
+	}{
+		{"192.168.1.5:7788", "0.0.0.0:7788", nil, true},
+		{"192.168.1.5:7788", ":7788", nil, true},
+		{"tv.lan:7788", "192.168.1.5:7788", nil, true},
+		{"192.168.1.5:7788", "127.0.0.1:7788", nil, false},
+		{"rebound.example.com:7788", "localhost:7788", nil, false},
+		{"localhost:7788", ":7788", []string{"tv.lan"}, true},
+		{"tv.lan:7788", ":7788", []string{"tv.lan"}, true},
+		{"192.168.1.5:7788", ":7788", []string{"tv.lan"}, false},
+		{"192.168.1.5:7788", "127.0.0.1:7788", []string{"192.168.1.5:7788"}, true},
+	}
+
+	for _, tt := range tests {
+		actual := hostAllowed(tt.host, tt.addr, tt.allowedHosts)
+
+		if actual != tt.expected {
+			t.Errorf("%s on %s: expected: %v, got: %v", tt.host, tt.addr, tt.expected, actual)
+		}
+	}
+}
+
+func Test_WildcardListen(t *testing.T) {
+	wtf.Config, _ = config.ParseYaml("wtf:\n  mods:\n    news:\n      enabled: true\n")
+
+	widget := &testWidget{TextWidget: wtf.NewTextWidget(nil, "News", "news", true)}
+	server := NewServer(":7788", &testDashboard{widgets: []wtf.Wtfable{widget}})
+
+	ts := httptest.NewServer(server.http.Handler)
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/widgets", nil)
+	req.Host = "192.168.1.5:7788"
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected: %v, got: %v", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func Test_MarkupToHTML(t *testing.T) {
+	tests := []struct {
+		markup   string
+		expected string
+	}{
+		{"plain <text>", "plain &lt;text&gt;"},
+		{"[red]red[white] [::b]bold", `<span style="color:red">red</span><span style="color:white"> </span><span style="color:white;font-weight:bold">bold</span>`},
+		{"[black:orange]selected", `<span style="color:black;background:orange">selected</span>`},
+	}
+
+	for _, tt := range tests {
+		actual := markupToHTML(tt.markup)
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.markup, tt.expected, actual)
+		}
+	}
+}
+
+func Test_GridTracks(t *testing.T) {
+	columns := gridTracks([]int{35, 0, -2}, "ch")
+	if columns != "35ch 1fr 2fr" {
+		t.Errorf("columns: expected: %v, got: %v", "35ch 1fr 2fr", columns)
+	}
+
+	rows := gridTracks([]int{4, 0}, "lines")
+	if rows != "5em 1fr" {
+		t.Errorf("rows: expected: %v, got: %v", "5em 1fr", rows)
+	}
+}
+
+func Test_PageBoards(t *testing.T) {
+	wtf.Config, _ = config.ParseYaml(`
+wtf:
+  boards:
+    home:
+      grid: { columns: [40, 40], rows: [10] }
+      mods: [news]
+    work:
+      grid: { columns: [20], rows: [5, 5] }
+      mods: [jira]
+  mods:
+    jira:
+      enabled: true
+      position: { top: 1, left: 0, height: 1, width: 1 }
+    news:
+      enabled: true
+      position: { top: 0, left: 1, height: 1, width: 1 }
+`)
+
+	dashboard := &testDashboard{widgets: []wtf.Wtfable{
+		&testWidget{TextWidget: wtf.NewTextWidget(nil, "Jira", "jira", true)},
+		&testWidget{TextWidget: wtf.NewTextWidget(nil, "News", "news", true)},
+	}}
+
+	server := NewServer("", dashboard)
+	server.EnableWeb()
+
+	ts := httptest.NewServer(server.http.Handler)
+	defer ts.Close()
+
+	tests := []struct {
+		path     string
+		expected []string
+		missing  string
+	}{
+		{"/", []string{"grid-template-columns: 40ch 40ch", `id="news" style="grid-column: 2 / span 1; grid-row: 1 / span 1`}, `id="jira"`},
+		{"/?board=work", []string{"grid-template-columns: 20ch", `id="jira" style="grid-column: 1 / span 1; grid-row: 2 / span 1`}, `id="news"`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(ts.URL + tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		for _, expected := range tt.expected {
+			if !strings.Contains(string(body), expected) {
+				t.Errorf("%s: expected: %v, got: %v", tt.path, expected, string(body))
+			}
+		}
+
+		if strings.Contains(string(body), tt.missing) {
+			t.Errorf("%s: expected no %v", tt.path, tt.missing)
+		}
+	}
+}
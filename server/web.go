@@ -0,0 +1,278 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	// webLineHeight is the height of a line of widget content on the web page, in ems
+	webLineHeight = 1.25
+
+	// webPollInterval is how often the widgets are checked for new content to push to the page
+	webPollInterval = time.Second
+)
+
+// webWidget is a widget as it's laid out on the web page
+type webWidget struct {
+	Key    string
+	Title  string
+	Border string
+
+	// The grid lines the widget starts on, which are numbered from 1, and the number of
+	// columns and rows it spans
+	Column int
+	Row    int
+	Width  int
+	Height int
+}
+
+// webUpdate is the content of a widget, as it's pushed to the web page
+type webUpdate struct {
+	Key   string `json:"key"`
+	HTML  string `json:"html"`
+	Error string `json:"error,omitempty"`
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// EnableWeb also serves a web page at "/" that lays the widgets out on the same boards and
+// grids as the terminal does, and keeps their content up to date over server-sent events. The page is
+// self-contained and loads nothing from anywhere else
+func (server *Server) EnableWeb() {
+	server.mux.HandleFunc("/", server.handlePage)
+	server.mux.HandleFunc("/events", server.handleEvents)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (server *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	boards := wtf.BoardConfigs(server.dashboard.Widgets())
+	board := boardNamed(boards, r.URL.Query().Get("board"))
+	layout := board.Layout()
+
+	widgets := []webWidget{}
+	for _, widget := range board.Widgets {
+		if widget.Disabled() || !layout.Shows(widget) {
+			continue
+		}
+
+		pos := layout.PositionOf(widget)
+
+		widgets = append(widgets, webWidget{
+			Key:    widget.Key(),
+			Title:  widget.Name(),
+			Border: widget.BorderColor(),
+			Column: pos.Left() + 1,
+			Row:    pos.Top() + 1,
+			Width:  pos.Width(),
+			Height: pos.Height(),
+		})
+	}
+
+	names := []string{}
+	if len(boards) > 1 {
+		for _, config := range boards {
+			names = append(names, config.Name)
+		}
+	}
+
+	data := map[string]interface{}{
+		"Background": wtf.Config.UString("wtf.colors.background", "black"),
+		"Board":      board.Name,
+		"Boards":     names,
+		"Columns":    gridTracks(layout.Columns, "ch"),
+		"Rows":       gridTracks(layout.Rows, "lines"),
+		"Text":       wtf.Config.UString("wtf.colors.text", "white"),
+		"Title":      wtf.Config.UString("wtf.colors.title", "white"),
+		"Widgets":    widgets,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	pageTemplate.Execute(w, data)
+}
+
+// handleEvents streams the content of every widget, and then each widget's content again
+// whenever it changes, until the page goes away
+func (server *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	sent := map[string]webUpdate{}
+
+	ticker := time.NewTicker(webPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, widget := range server.dashboard.Widgets() {
+			update := updateFor(widget)
+			if sent[update.Key] == update {
+				continue
+			}
+
+			data, _ := json.Marshal(update)
+			fmt.Fprintf(w, "data: %s\n\n", data)
+
+			sent[update.Key] = update
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// boardNamed returns the board with the given name, or the first board, which the terminal
+// starts on, if there's no such board
+func boardNamed(boards []wtf.BoardConfig, name string) wtf.BoardConfig {
+	for _, board := range boards {
+		if board.Name == name {
+			return board
+		}
+	}
+
+	return boards[0]
+}
+
+func updateFor(widget wtf.Wtfable) webUpdate {
+	update := webUpdate{
+		Key:  widget.Key(),
+		HTML: markupToHTML(widget.Content()),
+	}
+
+	if err, _ := widget.LastRefreshError(); err != nil {
+		update.Error = err.Error()
+	}
+
+	return update
+}
+
+// gridTracks turns the sizes of the grid's columns or rows into CSS grid tracks. As in tview,
+// a positive size is fixed, in "ch" characters or "lines", and zero or a negative size is a
+// share of whatever space is left
+func gridTracks(sizes []int, unit string) string {
+	tracks := []string{}
+
+	for _, size := range sizes {
+		switch {
+		case size > 0 && unit == "lines":
+			tracks = append(tracks, fmt.Sprintf("%gem", float64(size)*webLineHeight))
+		case size > 0:
+			tracks = append(tracks, fmt.Sprintf("%d%s", size, unit))
+		case size == 0:
+			tracks = append(tracks, "1fr")
+		default:
+			tracks = append(tracks, fmt.Sprintf("%dfr", -size))
+		}
+	}
+
+	return strings.Join(tracks, " ")
+}
+
+// markupToHTML turns a widget's tview markup into HTML, with its colors as inline styles
+func markupToHTML(markup string) string {
+	str := ""
+
+	for _, span := range wtf.ParseMarkup(markup) {
+		styles := []string{}
+
+		if span.Fore != "" {
+			styles = append(styles, "color:"+cssColor(span.Fore))
+		}
+		if span.Back != "" {
+			styles = append(styles, "background:"+cssColor(span.Back))
+		}
+		if strings.Contains(span.Attrs, "b") {
+			styles = append(styles, "font-weight:bold")
+		}
+		if strings.Contains(span.Attrs, "u") {
+			styles = append(styles, "text-decoration:underline")
+		}
+		if strings.Contains(span.Attrs, "d") {
+			styles = append(styles, "opacity:0.6")
+		}
+
+		text := html.EscapeString(span.Text)
+		if len(styles) == 0 {
+			str = str + text
+			continue
+		}
+
+		str = str + fmt.Sprintf(`<span style="%s">%s</span>`, strings.Join(styles, ";"), text)
+	}
+
+	return str
+}
+
+// cssColor returns the CSS for a tview color. tview's color names are the W3C ones, which
+// browsers know too, so only characters that could break out of the style are removed
+func cssColor(color string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '#' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, color)
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>wtf</title>
+<style>
+  html, body { margin: 0; height: 100%; background: {{.Background}}; color: {{.Text}}; }
+  body { font: 14px/1.25 Menlo, Consolas, "DejaVu Sans Mono", monospace; }
+  nav { padding: 0 0.5ch; }
+  nav a { color: {{.Text}}; padding: 0 1ch; text-decoration: none; }
+  nav a.current { color: black; background: {{.Title}}; }
+  main { display: grid; height: 100vh; grid-template-columns: {{.Columns}}; grid-template-rows: {{.Rows}}; }
+  section { position: relative; min-height: 0; border: 1px solid; margin: 0.6em 0.5ch; }
+  section.failing { border-color: red !important; }
+  h1 { position: absolute; top: -0.7em; left: 1ch; margin: 0; padding: 0 0.5ch; font-size: inherit; font-weight: normal; background: {{.Background}}; color: {{.Title}}; }
+  pre { height: calc(100% - 0.6em); margin: 0.6em 0 0 0; overflow: hidden; font: inherit; white-space: pre; }
+</style>
+</head>
+<body>
+{{if .Boards}}<nav>{{range .Boards}}<a href="/?board={{.}}"{{if eq . $.Board}} class="current"{{end}}>{{.}}</a>{{end}}</nav>
+{{end}}<main>
+{{range .Widgets}}  <section id="{{.Key}}" style="grid-column: {{.Column}} / span {{.Width}}; grid-row: {{.Row}} / span {{.Height}}; border-color: {{.Border}}">
+    <h1>{{.Title}}</h1>
+    <pre></pre>
+  </section>
+{{end}}</main>
+<script>
+  new EventSource("/events").onmessage = function (event) {
+    var update = JSON.parse(event.data);
+    var section = document.getElementById(update.key);
+    if (!section) { return; }
+
+    section.querySelector("pre").innerHTML = update.html;
+    section.classList.toggle("failing", !!update.error);
+    section.title = update.error || "";
+  };
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,386 @@
+package wtf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+const (
+	actionPage = "action"
+
+	// actionTimeout caps how long an HTTP action waits for a response. The widget's HTTP client
+	// has a timeout of its own, and whichever of the two is shorter wins
+	actionTimeout = 30 * time.Second
+)
+
+// Action is a shell command or HTTP request that's bound to a key and run on a widget's
+// selected item. Its command, URL, body and headers are Go templates executed against the
+// item, i.e.: "{{.Key}}" for a Jira issue or "{{.ID}}" for a PagerDuty incident. Everything a
+// command's template outputs is quoted for the shell, and everything a URL's is escaped for
+// the URL's path, so that an item can't run commands of its own or send a request elsewhere.
+// Headers that would come out with a line break in them are rejected
+type Action struct {
+	Key  string
+	Name string
+
+	Command string
+
+	Method  string
+	URL     string
+	Body    string
+	Headers map[string]string
+
+	// widgetKey is the widget the action belongs to, whose HTTP client makes its requests
+	widgetKey string
+}
+
+// ItemSelector is implemented by widgets that have a list of items to select from, so that
+// actions can be run on the selected one
+type ItemSelector interface {
+	// SelectedItem returns the selected item, or nil if none is
+	SelectedItem() interface{}
+}
+
+// ActionRunner runs the actions of the focused widget, and shows a modal with the error when
+// one fails
+type ActionRunner struct {
+	app   *tview.Application
+	pages *tview.Pages
+}
+
+// NewActionRunner creates an action runner that shows its errors on the given pages
+func NewActionRunner(app *tview.Application, pages *tview.Pages) *ActionRunner {
+	return &ActionRunner{
+		app:   app,
+		pages: pages,
+	}
+}
+
+// actionFuncs are the functions available to action templates, on top of Go's own, to keep
+// item fields from being taken as shell syntax, breaking out of a JSON string or changing a
+// URL's path
+var actionFuncs = template.FuncMap{
+	"json":       jsonValue,
+	"pathEscape": pathEscape,
+	"quote":      shellQuote,
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ActionsFor reads the actions listed at "wtf.mods.<key>.actions", i.e.:
+//
+//	actions:
+//	  - key: "a"
+//	    name: Assign to me
+//	    command: "jira assign {{.Key}} me"
+//	  - key: "A"
+//	    name: Acknowledge
+//	    method: PUT
+//	    url: "https://api.pagerduty.com/incidents/{{.ID}}"
+//	    body: '{"incident": {"type": "incident_reference", "status": "acknowledged"}}'
+//	    headers:
+//	      Authorization: "Token token=abc123"
+func ActionsFor(key string) []Action {
+	actions := []Action{}
+
+	for idx := range Config.UList(ConfigKeyFor(key, "actions")) {
+		actionKey := fmt.Sprintf("%s.%d", ConfigKeyFor(key, "actions"), idx)
+
+		action := Action{
+			Key:     Config.UString(actionKey + ".key"),
+			Name:    Config.UString(actionKey + ".name"),
+			Command: Config.UString(actionKey + ".command"),
+			Method:  strings.ToUpper(Config.UString(actionKey+".method", http.MethodPost)),
+			URL:     Config.UString(actionKey + ".url"),
+			Body:    Config.UString(actionKey + ".body"),
+			Headers: map[string]string{},
+
+			widgetKey: key,
+		}
+
+		for name, value := range Config.UMap(actionKey + ".headers") {
+			action.Headers[name] = fmt.Sprint(value)
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+// Run runs the action on the item, and returns an error if the command exits with a failure
+// or the request doesn't get a 2xx response
+func (action *Action) Run(item interface{}) error {
+	switch {
+	case action.Command != "":
+		command, err := expandCommand(action.Command, item)
+		if err != nil {
+			return err
+		}
+
+		_, err = commandOutput(exec.Command("sh", "-c", command))
+		return err
+	case action.URL != "":
+		return action.request(item)
+	default:
+		return fmt.Errorf("the '%s' action has neither a command nor a url", action.Key)
+	}
+}
+
+// Handle runs the action bound to the key on the widget's selected item, in the background.
+// It returns false if the widget has no item selected or no action bound to the key, so that
+// the key can be handled as usual
+func (runner *ActionRunner) Handle(widget Wtfable, key string) bool {
+	selector, ok := widget.(ItemSelector)
+	if !ok {
+		return false
+	}
+
+	for _, action := range ActionsFor(widget.Key()) {
+		if action.Key != key {
+			continue
+		}
+
+		item := selector.SelectedItem()
+		if item == nil {
+			return false
+		}
+
+		go runner.run(widget, action, item)
+		return true
+	}
+
+	return false
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// run runs the action and then refreshes the widget, since the action has likely changed
+// the item. The widget's border flashes once it's done
+func (runner *ActionRunner) run(widget Wtfable, action Action, item interface{}) {
+	if err := action.Run(item); err != nil {
+		name := action.Name
+		if name == "" {
+			name = action.Key
+		}
+
+		runner.app.QueueUpdateDraw(func() {
+			runner.showError(widget, fmt.Sprintf("\n The '%s' action failed:\n\n %s", name, err.Error()))
+		})
+		return
+	}
+
+	widget.RequestRefresh()
+
+	if flashable, ok := widget.(flasher); ok {
+		flashable.Flash()
+	}
+}
+
+func (runner *ActionRunner) showError(widget Wtfable, msg string) {
+	closeFunc := func() {
+		runner.pages.RemovePage(actionPage)
+		runner.app.SetFocus(widget.TextView())
+	}
+
+	modal := NewBillboardModal(escapeTags(msg), closeFunc)
+
+	runner.pages.AddPage(actionPage, modal, false, true)
+	runner.app.SetFocus(modal)
+}
+
+func (action *Action) request(item interface{}) error {
+	url, err := expandURL(action.URL, item)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if action.Body != "" {
+		str, err := expandAction(action.Body, item)
+		if err != nil {
+			return err
+		}
+
+		body = strings.NewReader(str)
+	}
+
+	client, err := HTTPClient(action.widgetKey)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(action.Method, url, body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	for name, value := range action.Headers {
+		header, err := expandAction(value, item)
+		if err != nil {
+			return err
+		}
+
+		if strings.ContainsAny(header, "\r\n") {
+			return fmt.Errorf("the %s header has a line break in it", name)
+		}
+
+		req.Header.Set(name, header)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.New(strings.TrimSpace(resp.Status + " " + string(msg)))
+	}
+
+	return nil
+}
+
+// expandAction executes the action template against the item
+func expandAction(text string, item interface{}) (string, error) {
+	tmpl, err := parseAction(text)
+	if err != nil {
+		return "", err
+	}
+
+	return executeAction(tmpl, item)
+}
+
+// expandCommand executes the command template against the item, with the output of each of
+// its actions quoted for the shell. Actions that already end in quote aren't quoted twice
+func expandCommand(text string, item interface{}) (string, error) {
+	tmpl, err := parseAction(text)
+	if err != nil {
+		return "", err
+	}
+
+	escapeActions(tmpl.Tree.Root, "quote")
+
+	return executeAction(tmpl, item)
+}
+
+// expandURL executes the URL template against the item, with the output of each of its
+// actions escaped for the URL's path. Actions that already end in pathEscape or urlquery
+// aren't escaped twice
+func expandURL(text string, item interface{}) (string, error) {
+	tmpl, err := parseAction(text)
+	if err != nil {
+		return "", err
+	}
+
+	escapeActions(tmpl.Tree.Root, "pathEscape", "urlquery")
+
+	return executeAction(tmpl, item)
+}
+
+func parseAction(text string) (*template.Template, error) {
+	return template.New("action").Funcs(actionFuncs).Option("missingkey=error").Parse(text)
+}
+
+func executeAction(tmpl *template.Template, item interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, item); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// escapeActions pipes the output of every action in the tree through the first of the
+// escapers, including those inside if, range and with, the way html/template escapes for
+// HTML. Actions that already end in one of the escapers are left as they are
+func escapeActions(node parse.Node, escapers ...string) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+
+		for _, child := range node.Nodes {
+			escapeActions(child, escapers...)
+		}
+	case *parse.ActionNode:
+		pipe := node.Pipe
+		if len(pipe.Decl) > 0 || endsIn(pipe, escapers) {
+			return
+		}
+
+		escaper := parse.NewIdentifier(escapers[0]).SetTree(nil).SetPos(pipe.Position())
+		pipe.Cmds = append(pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      pipe.Position(),
+			Args:     []parse.Node{escaper},
+		})
+	case *parse.IfNode:
+		escapeActions(node.List, escapers...)
+		escapeActions(node.ElseList, escapers...)
+	case *parse.RangeNode:
+		escapeActions(node.List, escapers...)
+		escapeActions(node.ElseList, escapers...)
+	case *parse.WithNode:
+		escapeActions(node.List, escapers...)
+		escapeActions(node.ElseList, escapers...)
+	}
+}
+
+// endsIn returns true if the last command of the pipeline is a call to one of the functions
+func endsIn(pipe *parse.PipeNode, funcs []string) bool {
+	if len(pipe.Cmds) == 0 {
+		return false
+	}
+
+	ident, ok := pipe.Cmds[len(pipe.Cmds)-1].Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return false
+	}
+
+	for _, name := range funcs {
+		if ident.Ident == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonValue returns the value as JSON, i.e.: a string with its quotes and escapes
+func jsonValue(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// pathEscape escapes the value so that it's a single segment of a URL's path, whatever it
+// contains
+func pathEscape(value interface{}) string {
+	return url.PathEscape(fmt.Sprint(value))
+}
+
+// shellQuote quotes the value so that the shell passes it on as a single argument, whatever
+// it contains
+func shellQuote(value interface{}) string {
+	return "'" + strings.Replace(fmt.Sprint(value), "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,160 @@
+package wtf
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/olebedev/config"
+)
+
+type testItem struct {
+	ID    string
+	Title string
+}
+
+func Test_ExpandAction(t *testing.T) {
+	item := testItem{ID: "P42", Title: `it's "broken"; rm -rf /`}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"open {{.ID}}", "open P42"},
+		{"echo {{quote .Title}}", `echo 'it'\''s "broken"; rm -rf /'`},
+		{`{"title": {{json .Title}}}`, `{"title": "it's \"broken\"; rm -rf /"}`},
+		{"/incidents/{{urlquery .ID}}", "/incidents/P42"},
+	}
+
+	for _, test := range tests {
+		actual, err := expandAction(test.text, item)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.text, err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("%s: expected: %v, got: %v", test.text, test.expected, actual)
+		}
+	}
+
+	if _, err := expandAction("{{.Missing}}", item); err == nil {
+		t.Errorf("expected: an error for a missing field, got: nil")
+	}
+}
+
+func Test_ExpandCommand(t *testing.T) {
+	item := testItem{ID: "P42", Title: `it's "broken"; rm -rf /`}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"open {{.ID}}", "open 'P42'"},
+		{"echo {{.Title}}", `echo 'it'\''s "broken"; rm -rf /'`},
+		{"echo {{quote .Title}}", `echo 'it'\''s "broken"; rm -rf /'`},
+		{"echo {{.Title | printf \"%.4s\"}}", `echo 'it'\''s'`},
+		{"{{if .ID}}open {{.ID}}{{else}}{{.Title}}{{end}}", "open 'P42'"},
+		{"{{with $id := .ID}}open {{$id}}{{end}}", "open 'P42'"},
+	}
+
+	for _, test := range tests {
+		actual, err := expandCommand(test.text, item)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.text, err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("%s: expected: %v, got: %v", test.text, test.expected, actual)
+		}
+	}
+}
+
+func Test_ExpandURL(t *testing.T) {
+	item := testItem{ID: "P42", Title: "a/../b?c=d#e f"}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"http://example.com/incidents/{{.ID}}", "http://example.com/incidents/P42"},
+		{"http://example.com/{{.Title}}", "http://example.com/a%2F..%2Fb%3Fc=d%23e%20f"},
+		{"http://example.com/{{pathEscape .Title}}", "http://example.com/a%2F..%2Fb%3Fc=d%23e%20f"},
+		{"http://example.com/?q={{urlquery .Title}}", "http://example.com/?q=a%2F..%2Fb%3Fc%3Dd%23e+f"},
+		{"http://example.com/{{if .ID}}{{.Title}}{{end}}", "http://example.com/a%2F..%2Fb%3Fc=d%23e%20f"},
+	}
+
+	for _, test := range tests {
+		actual, err := expandURL(test.text, item)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.text, err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("%s: expected: %v, got: %v", test.text, test.expected, actual)
+		}
+	}
+}
+
+func Test_ActionRun(t *testing.T) {
+	var method, path, body, auth, agent string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		method, path, body, auth = r.Method, r.URL.Path, string(data), r.Header.Get("Authorization")
+		agent = r.Header.Get("User-Agent")
+
+		if path == "/fail" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer ts.Close()
+
+	Config, _ = config.ParseYaml(`
+wtf:
+  mods:
+    pagerduty:
+      http:
+        userAgent: wtf-actions
+      actions:
+        - key: a
+          method: put
+          url: "` + ts.URL + `/incidents/{{.ID}}"
+          body: "acknowledge {{.ID}}"
+          headers:
+            Authorization: "Token {{.Title}}"
+        - key: f
+          url: "` + ts.URL + `/fail"
+        - key: c
+          command: "test {{.Title}} = 'a;b'"
+`)
+
+	actions := ActionsFor("pagerduty")
+	if len(actions) != 3 {
+		t.Fatalf("expected: %v, got: %v", 3, len(actions))
+	}
+
+	item := testItem{ID: "P42", Title: "abc"}
+
+	if err := actions[0].Run(item); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if method != "PUT" || path != "/incidents/P42" || body != "acknowledge P42" || auth != "Token abc" {
+		t.Errorf("expected: %v, got: %v", "PUT /incidents/P42", method+" "+path+" "+body+" "+auth)
+	}
+	if agent != "wtf-actions" {
+		t.Errorf("user agent: expected: %v, got: %v", "wtf-actions", agent)
+	}
+
+	if err := actions[1].Run(item); err == nil {
+		t.Errorf("expected: an error for a 403, got: nil")
+	}
+
+	if err := actions[0].Run(testItem{ID: "P42", Title: "abc\r\nX-Injected: yes"}); err == nil {
+		t.Errorf("expected: an error for a header with a line break, got: nil")
+	}
+
+	if err := actions[2].Run(testItem{ID: "P42", Title: "a;b"}); err != nil {
+		t.Errorf("expected: the command to succeed, got: %v", err)
+	}
+}
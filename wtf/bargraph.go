@@ -11,7 +11,6 @@ import (
 type BarGraph struct {
 	enabled   bool
 	focusable bool
-	key       string
 	maxStars  int
 	name      string
 	starChar  string
@@ -20,6 +19,7 @@ type BarGraph struct {
 	View       *tview.TextView
 
 	Position
+	refreshable
 }
 
 type Bar struct {
@@ -33,13 +33,14 @@ func NewBarGraph(app *tview.Application, name string, configKey string, focusabl
 	widget := BarGraph{
 		enabled:    Config.UBool(fmt.Sprintf("wtf.mods.%s.enabled", configKey), false),
 		focusable:  focusable,
-		key:        configKey,
 		maxStars:   Config.UInt(fmt.Sprintf("wtf.mods.%s.graphStars", configKey), 20),
 		name:       Config.UString(fmt.Sprintf("wtf.mods.%s.title", configKey), name),
 		starChar:   Config.UString(fmt.Sprintf("wtf.mods.%s.graphIcon", configKey), "|"),
 		RefreshInt: Config.UInt(fmt.Sprintf("wtf.mods.%s.refreshInterval", configKey), 1),
 	}
 
+	widget.refreshable = newRefreshable(app, configKey)
+
 	widget.Position = NewPosition(
 		Config.UInt(fmt.Sprintf("wtf.mods.%s.position.top", configKey)),
 		Config.UInt(fmt.Sprintf("wtf.mods.%s.position.left", configKey)),
@@ -53,6 +54,10 @@ func NewBarGraph(app *tview.Application, name string, configKey string, focusabl
 }
 
 func (widget *BarGraph) BorderColor() string {
+	if widget.refreshState.failing() {
+		return errorColor()
+	}
+
 	if widget.Focusable() {
 		return Config.UString("wtf.colors.border.focusable", "red")
 	}
@@ -60,6 +65,12 @@ func (widget *BarGraph) BorderColor() string {
 	return Config.UString("wtf.colors.border.normal", "gray")
 }
 
+// ConfigKey returns the full config path to the named setting of this widget instance,
+// i.e.: "wtf.mods.<key>.<setting>"
+func (widget *BarGraph) ConfigKey(setting string) string {
+	return ConfigKeyFor(widget.key, setting)
+}
+
 func (widget *BarGraph) Disable() {
 	widget.enabled = false
 }
@@ -72,6 +83,11 @@ func (widget *BarGraph) Enabled() bool {
 	return widget.enabled
 }
 
+// Flash flashes the widget's border a few times, to draw attention to it
+func (widget *BarGraph) Flash() {
+	flashBorder(widget.app, widget.View, widget.BorderColor)
+}
+
 func (widget *BarGraph) Focusable() bool {
 	return widget.enabled && widget.focusable
 }
@@ -86,18 +102,31 @@ func (widget *BarGraph) IsPositionable() bool {
 	return widget.Position.IsValid()
 }
 
-func (widget *BarGraph) Key() string {
-	return widget.key
-}
-
 func (widget *BarGraph) Name() string {
 	return widget.name
 }
 
+// Notify raises a notification about this widget. See wtf.Notify for how it's delivered
+func (widget *BarGraph) Notify(notification Notification) {
+	Notify(widget.key, widget, notification)
+}
+
+// NotifyChanges raises each of the notifications that the widget's previous refresh didn't
+// also raise. Modules call it on every refresh with everything they'd notify about, i.e.: every
+// open incident, so that the first refresh and what's still the case aren't announced
+func (widget *BarGraph) NotifyChanges(notifications []Notification) {
+	notifyChanges(widget.key, widget, widget.notifications, notifications)
+}
+
 func (widget *BarGraph) RefreshInterval() int {
 	return widget.RefreshInt
 }
 
+// SetRefreshError puts the widget into its error state, or takes it back out when err is nil
+func (widget *BarGraph) SetRefreshError(err error) {
+	widget.setRefreshError(err, widget.BorderColor)
+}
+
 func (widget *BarGraph) SetFocusChar(char string) {
 	return
 }
@@ -113,6 +142,7 @@ func (widget *BarGraph) addView(app *tview.Application, configKey string) {
 
 	view.SetBackgroundColor(ColorFor(Config.UString("wtf.colors.background", "black")))
 	view.SetBorder(true)
+	view.SetDrawFunc(widget.refreshState.drawError)
 	view.SetBorderColor(ColorFor(widget.BorderColor()))
 	view.SetDynamicColors(true)
 	view.SetTitle(widget.Name())
@@ -125,13 +155,14 @@ func (widget *BarGraph) addView(app *tview.Application, configKey string) {
 	view.SetWrap(false)
 
 	widget.View = view
+	widget.view = view
 }
 
 // BuildBars will build a string of * to represent your data of [time][value]
 // time should be passed as a int64
 func (widget *BarGraph) BuildBars(data []Bar) {
 
-	widget.View.SetText(BuildStars(data, widget.maxStars, widget.starChar))
+	widget.SetContent(BuildStars(data, widget.maxStars, widget.starChar))
 
 }
 
@@ -0,0 +1,232 @@
+package wtf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Board is a named grid of widgets. Only one board is on screen at a time
+type Board struct {
+	Name         string
+	Display      *Display
+	FocusTracker *FocusTracker
+	Widgets      []Wtfable
+}
+
+// BoardConfig is a board as it's configured: its name, the config path its grid and layouts
+// are defined under, and the widgets on it
+type BoardConfig struct {
+	Name      string
+	ConfigKey string
+	Widgets   []Wtfable
+}
+
+// Boards holds every board defined under "wtf.boards" and switches between them. Without any
+// boards configured, every enabled widget goes on a single board laid out on "wtf.grid"
+type Boards struct {
+	Root *tview.Flex
+
+	app     *tview.Application
+	boards  []*Board
+	current int
+	pages   *tview.Pages
+	tabBar  *tview.TextView
+	widgets []Wtfable
+}
+
+// NewBoards lays the widgets out on their boards and shows the first one
+func NewBoards(app *tview.Application, widgets []Wtfable) *Boards {
+	boards := Boards{
+		Root: tview.NewFlex().SetDirection(tview.FlexRow),
+
+		app:     app,
+		pages:   tview.NewPages(),
+		tabBar:  tview.NewTextView(),
+		widgets: widgets,
+	}
+
+	boards.build()
+
+	boards.tabBar.SetDynamicColors(true)
+	boards.tabBar.SetBackgroundColor(ColorFor(Config.UString("wtf.colors.background", "black")))
+
+	if len(boards.boards) > 1 {
+		boards.Root.AddItem(boards.tabBar, 1, 0, false)
+	}
+	boards.Root.AddItem(boards.pages, 0, 1, true)
+
+	boards.SwitchTo(0)
+
+	return &boards
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// BoardConfigs returns the boards defined under "wtf.boards", in the order they're shown, or a
+// single unnamed board on "wtf.grid" with every widget on it if none are
+func BoardConfigs(widgets []Wtfable) []BoardConfig {
+	names := []string{}
+	for name := range Config.UMap("wtf.boards") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return []BoardConfig{{Name: "", ConfigKey: "wtf", Widgets: widgets}}
+	}
+
+	configs := []BoardConfig{}
+	for _, name := range names {
+		configs = append(configs, BoardConfig{
+			Name:      name,
+			ConfigKey: "wtf.boards." + name,
+			Widgets:   widgetsOn(name, widgets),
+		})
+	}
+
+	return configs
+}
+
+// Layout returns the layout the board starts with, before the terminal's size is known
+func (config BoardConfig) Layout() Layout {
+	return defaultLayout(config.ConfigKey)
+}
+
+// Current returns the board that's on screen
+func (boards *Boards) Current() *Board {
+	return boards.boards[boards.current]
+}
+
+// Focus shows the first board the widget with the given key is on, and focuses the widget. It
+// returns false if no board has it, or it can't be focused
+func (boards *Boards) Focus(key string) bool {
+	for idx, board := range boards.boards {
+		for _, widget := range board.Widgets {
+			if widget.Key() != key || !widget.Focusable() || !board.Display.Shows(widget) {
+				continue
+			}
+
+			boards.SwitchTo(idx)
+			return board.FocusTracker.FocusWidget(widget)
+		}
+	}
+
+	return false
+}
+
+// Next shows the board after the current one, wrapping around to the first
+func (boards *Boards) Next() {
+	boards.SwitchTo((boards.current + 1) % len(boards.boards))
+}
+
+// Prev shows the board before the current one, wrapping around to the last
+func (boards *Boards) Prev() {
+	boards.SwitchTo((boards.current + len(boards.boards) - 1) % len(boards.boards))
+}
+
+// SwitchTo shows the board at the given index, and returns false if there is no such board.
+// Widgets on the boards that aren't shown are marked hidden, so their refreshes can be
+// slowed down or paused
+func (boards *Boards) SwitchTo(idx int) bool {
+	if idx < 0 || idx >= len(boards.boards) {
+		return false
+	}
+
+	boards.Current().FocusTracker.None()
+	boards.current = idx
+
+	board := boards.Current()
+	boards.pages.SwitchToPage(board.Name)
+
+	visible := map[Wtfable]bool{}
+	for _, widget := range board.Widgets {
+		visible[widget] = board.Display.Shows(widget)
+	}
+
+	for _, widget := range boards.widgets {
+		setVisible(widget, visible[widget])
+	}
+
+	boards.tabBar.SetText(boards.tabs())
+
+	return true
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (boards *Boards) build() {
+	for _, config := range BoardConfigs(boards.widgets) {
+		boards.add(config.Name, config.ConfigKey, config.Widgets)
+	}
+}
+
+func (boards *Boards) add(name, configKey string, widgets []Wtfable) {
+	tracker := &FocusTracker{
+		App:     boards.app,
+		Idx:     -1,
+		Widgets: widgets,
+	}
+	tracker.AssignHotKeys()
+
+	board := &Board{
+		Name:         name,
+		Display:      NewDisplay(widgets, configKey),
+		FocusTracker: tracker,
+		Widgets:      widgets,
+	}
+
+	boards.boards = append(boards.boards, board)
+	boards.pages.AddPage(name, board.Display.Grid, true, false)
+}
+
+// tabs returns the text of the tab bar, with the current board highlighted
+func (boards *Boards) tabs() string {
+	tabs := []string{}
+
+	for idx, board := range boards.boards {
+		tab := fmt.Sprintf(" %d %s ", idx+1, board.Name)
+
+		if idx == boards.current {
+			tab = fmt.Sprintf("[black:%s]%s[-:-]", Config.UString("wtf.colors.border.focused", "gray"), tab)
+		}
+
+		tabs = append(tabs, tab)
+	}
+
+	return strings.Join(tabs, " ")
+}
+
+// widgetsOn returns the widgets listed in the named board's "mods", in the order listed
+func widgetsOn(name string, all []Wtfable) []Wtfable {
+	widgets := []Wtfable{}
+
+	for _, key := range ToStrs(Config.UList(fmt.Sprintf("wtf.boards.%s.mods", name))) {
+		for _, widget := range all {
+			if widget.Key() == key {
+				widgets = append(widgets, widget)
+			}
+		}
+	}
+
+	return widgets
+}
+
+// setVisible marks the widget as on or off screen. Widgets that were paused, or refreshing
+// slowly, catch up as soon as they're shown
+func setVisible(widget Wtfable, visible bool) {
+	wasHidden := widget.Hidden()
+	widget.SetHidden(!visible)
+
+	if wasHidden && visible && hiddenRefresh() != "normal" {
+		widget.RequestRefresh()
+	}
+}
+
+// hiddenRefresh returns how widgets on boards that aren't shown are refreshed: "normal",
+// "slow" or "pause"
+func hiddenRefresh() string {
+	return Config.UString("wtf.hiddenBoardRefresh", "slow")
+}
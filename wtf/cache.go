@@ -0,0 +1,122 @@
+package wtf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheSaveInterval is the least time between saves of a widget's content, so that widgets
+// that refresh every second aren't written to disk every second
+const cacheSaveInterval = time.Minute
+
+// CachedContent is what a widget displayed after a successful refresh, saved so that it can be
+// shown straight away the next time wtf starts
+type CachedContent struct {
+	Content string    `json:"content"`
+	Model   *Content  `json:"model,omitempty"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// Cache keeps the last good content of each widget in a JSON file of its own, named after the
+// widget's key, i.e.: "~/.config/wtf/cache/jira.json"
+type Cache struct {
+	dir string
+}
+
+// NewCache creates a cache that keeps its files in the given directory, which is created on
+// the first save
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Load returns the content saved for the widget, or nil if there isn't any
+func (cache *Cache) Load(key string) (*CachedContent, error) {
+	data, err := ioutil.ReadFile(cache.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cached := CachedContent{}
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+
+	return &cached, nil
+}
+
+// Save replaces the content saved for the widget. The file is written beside the old one and
+// renamed over it, so that a crash mid-write doesn't leave half a file behind
+func (cache *Cache) Save(key string, cached CachedContent) error {
+	if err := os.MkdirAll(cache.dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(cache.dir, ".cache-")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), cache.path(key))
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// cacheEnabled returns true if the widget's content should be cached. The cache is on by
+// default, and can be turned off everywhere with "wtf.cache.enabled" or for one module with
+// its own "cache.enabled"
+func cacheEnabled(key string) bool {
+	return Config.UBool(ConfigKeyFor(key, "cache.enabled"), Config.UBool("wtf.cache.enabled", true))
+}
+
+// path returns the widget's file. Path separators in the key are replaced, so that the file
+// always ends up directly in the cache directory
+func (cache *Cache) path(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, key)
+
+	return filepath.Join(cache.dir, name+".json")
+}
+
+// cacheAge returns how long ago content was cached, in the largest whole unit, i.e.: "5m"
+func cacheAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "<1m"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", age/time.Minute)
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", age/time.Hour)
+	default:
+		return fmt.Sprintf("%dd", age/(24*time.Hour))
+	}
+}
@@ -0,0 +1,78 @@
+package wtf
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_Cache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := NewCache(filepath.Join(dir, "cache"))
+
+	cached, err := cache.Load("jira")
+	if cached != nil || err != nil {
+		t.Fatalf("expected: nothing cached, got: %v, %v", cached, err)
+	}
+
+	model := NewContent()
+	model.AddSection("Issues").AddRow(Text("WTF-1"))
+	savedAt := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := cache.Save("../jira", CachedContent{Content: "[red]WTF-1", Model: model, SavedAt: savedAt}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "cache", ".._jira.json")); err != nil {
+		t.Errorf("expected: the file to be in the cache directory, got: %v", err)
+	}
+
+	cached, err = cache.Load("../jira")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cached.Content != "[red]WTF-1" || !cached.SavedAt.Equal(savedAt) || cached.Model.Sections[0].Rows[0].Cells[0].Text != "WTF-1" {
+		t.Errorf("expected: %v, got: %v", "[red]WTF-1", cached)
+	}
+}
+
+func Test_CacheAge(t *testing.T) {
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{30 * time.Second, "<1m"},
+		{5*time.Minute + 30*time.Second, "5m"},
+		{3*time.Hour + 59*time.Minute, "3h"},
+		{50 * time.Hour, "2d"},
+	}
+
+	for _, test := range tests {
+		if actual := cacheAge(test.age); actual != test.expected {
+			t.Errorf("%v: expected: %v, got: %v", test.age, test.expected, actual)
+		}
+	}
+}
+
+func Test_RefreshStateCached(t *testing.T) {
+	state := &refreshState{}
+	state.setCached(time.Now())
+
+	if !state.hasContent() || state.hasSucceeded() {
+		t.Fatalf("expected: cached content without a successful refresh")
+	}
+
+	state.set(nil)
+
+	if !state.cachedSince().IsZero() {
+		t.Errorf("expected: a successful refresh to replace the cached content")
+	}
+}
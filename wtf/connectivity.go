@@ -0,0 +1,225 @@
+package wtf
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+const (
+	// networkFailureThreshold is how many refreshes in a row have to fail with a network
+	// error before the network is checked
+	networkFailureThreshold = 3
+
+	// networkProbeTimeout is how long a check of the network waits to connect
+	networkProbeTimeout = 3 * time.Second
+)
+
+// networkErrorText is what network errors say once a module has turned them into plain text
+var networkErrorText = []string{
+	"connection refused",
+	"dial tcp",
+	"i/o timeout",
+	"network is unreachable",
+	"no route to host",
+	"no such host",
+}
+
+// Connectivity keeps track of whether the network can be reached. Widgets that fetch their
+// data over the network are paused while it can't, and all resume together once it can
+type Connectivity struct {
+	// Changed is called whenever the network goes down or comes back up
+	Changed func(offline bool)
+
+	mu       sync.Mutex
+	failures int
+	offline  bool
+	online   chan struct{}
+	probe    func() bool
+}
+
+// Network is the connectivity of the machine wtf is running on. It's checked by connecting to
+// "wtf.network.checkAddress"
+var Network = NewConnectivity(probeNetwork)
+
+// NewConnectivity creates a connectivity that starts out online, and uses the probe to check
+// whether the network is up
+func NewConnectivity(probe func() bool) *Connectivity {
+	online := make(chan struct{})
+	close(online)
+
+	return &Connectivity{
+		online: online,
+		probe:  probe,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Check checks the network right away, and marks it offline if it's down
+func (conn *Connectivity) Check() {
+	if !conn.probe() {
+		conn.goOffline()
+	}
+}
+
+// Offline returns true if the network is known to be down
+func (conn *Connectivity) Offline() bool {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	return conn.offline
+}
+
+// Online returns a channel that's closed once the network is up, which it already is unless
+// the network is offline
+func (conn *Connectivity) Online() <-chan struct{} {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	return conn.online
+}
+
+// ReportFailure records a refresh that failed. Once enough fail in a row with network errors,
+// the network is checked, and marked offline if it's down
+func (conn *Connectivity) ReportFailure(err error) {
+	if !IsNetworkError(err) {
+		return
+	}
+
+	conn.mu.Lock()
+	conn.failures++
+	check := conn.failures >= networkFailureThreshold && !conn.offline
+	conn.mu.Unlock()
+
+	if check {
+		conn.Check()
+	}
+}
+
+// ReportSuccess records a refresh that reached the network
+func (conn *Connectivity) ReportSuccess() {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.failures = 0
+}
+
+// IsNetworkError returns true if the error is the network being unreachable, rather than a
+// service returning an error
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+
+	switch err := err.(type) {
+	case *net.OpError, *net.DNSError:
+		return true
+	case net.Error:
+		if err.Timeout() {
+			return true
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, text := range networkErrorText {
+		if strings.Contains(msg, text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NetworkBound returns true if the widget fetches its data over the network, which the module
+// declares and "wtf.mods.<key>.network" can override
+func NetworkBound(key string) bool {
+	module, _ := ModuleFor(Config.UString(ConfigKeyFor(key, "type"), key))
+	return Config.UBool(ConfigKeyFor(key, "network"), module.Network)
+}
+
+// DrawOffline is a tview after-draw function that writes an offline indicator into the top
+// right corner of the screen while the network is down
+func DrawOffline(screen tcell.Screen) {
+	if !Network.Offline() {
+		return
+	}
+
+	width, _ := screen.Size()
+	msg := " ✗ offline "
+
+	tview.Print(screen, msg, 0, 0, width-1, tview.AlignRight, ColorFor(errorColor()))
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// goOffline marks the network as down, and checks it every "wtf.network.checkInterval"
+// seconds until it's back up
+func (conn *Connectivity) goOffline() {
+	conn.mu.Lock()
+	if conn.offline {
+		conn.mu.Unlock()
+		return
+	}
+
+	conn.offline = true
+	conn.online = make(chan struct{})
+	conn.mu.Unlock()
+
+	conn.changed(true)
+
+	go func() {
+		interval := time.Duration(Config.UInt("wtf.network.checkInterval", 15)) * time.Second
+		if interval <= 0 {
+			interval = 15 * time.Second
+		}
+
+		for !conn.probe() {
+			time.Sleep(interval)
+		}
+
+		conn.goOnline()
+	}()
+}
+
+func (conn *Connectivity) goOnline() {
+	conn.mu.Lock()
+	conn.failures = 0
+	conn.offline = false
+	close(conn.online)
+	conn.mu.Unlock()
+
+	conn.changed(false)
+}
+
+func (conn *Connectivity) changed(offline bool) {
+	if conn.Changed != nil {
+		conn.Changed(offline)
+	}
+}
+
+// probeNetwork returns true if "wtf.network.checkAddress" can be connected to. Setting it to
+// an empty string turns the check off, and the network is always taken to be up
+func probeNetwork() bool {
+	addr := Config.UString("wtf.network.checkAddress", "1.1.1.1:443")
+	if addr == "" {
+		return true
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, networkProbeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+
+	return true
+}
@@ -0,0 +1,79 @@
+package wtf
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+func Test_IsNetworkError(t *testing.T) {
+	dialErr := &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: network is unreachable")}}
+
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{errors.New("401 Unauthorized"), false},
+		{dialErr, true},
+		{&net.DNSError{Err: "no such host", Name: "example.com"}, true},
+		{&url.Error{Op: "Get", URL: "https://example.com", Err: &net.DNSError{Err: "server misbehaving", Name: "example.com"}}, true},
+		{&url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("stopped after 10 redirects")}, false},
+		{errors.New("Get https://example.com: dial tcp: lookup example.com: no such host"), true},
+	}
+
+	for _, test := range tests {
+		if actual := IsNetworkError(test.err); actual != test.expected {
+			t.Errorf("%v: expected: %v, got: %v", test.err, test.expected, actual)
+		}
+	}
+}
+
+func Test_Connectivity(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  network:\n    checkInterval: 1\n")
+
+	var up int32
+	changes := make(chan bool, 2)
+
+	conn := NewConnectivity(func() bool { return atomic.LoadInt32(&up) == 1 })
+	conn.Changed = func(offline bool) { changes <- offline }
+
+	networkErr := errors.New("dial tcp: i/o timeout")
+
+	conn.ReportFailure(errors.New("500 Internal Server Error"))
+	conn.ReportFailure(networkErr)
+	conn.ReportFailure(networkErr)
+
+	if conn.Offline() {
+		t.Fatalf("expected: online before %d network failures", networkFailureThreshold)
+	}
+
+	conn.ReportFailure(networkErr)
+
+	if !conn.Offline() || !<-changes {
+		t.Fatalf("expected: offline after %d network failures", networkFailureThreshold)
+	}
+
+	select {
+	case <-conn.Online():
+		t.Fatalf("expected: Online to block while offline")
+	default:
+	}
+
+	atomic.StoreInt32(&up, 1)
+
+	select {
+	case <-conn.Online():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("expected: online once the network is back")
+	}
+
+	if conn.Offline() || <-changes {
+		t.Errorf("expected: online once the network is back")
+	}
+}
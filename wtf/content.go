@@ -0,0 +1,225 @@
+package wtf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Status is how good or bad a row or cell is. The renderer shows it as a color
+type Status int
+
+const (
+	StatusNone Status = iota
+	StatusOK
+	StatusWarning
+	StatusError
+)
+
+// Content is what a widget displays, kept apart from how it's drawn, so that it can be
+// rendered as tview markup, printed as JSON, or compared in tests
+type Content struct {
+	Sections []Section `json:"sections"`
+
+	// Selected is the index of the selected row, counting only selectable rows across all
+	// sections, or -1 if none is
+	Selected int `json:"selected"`
+
+	// Page is which of Pages is shown, for widgets that page through several sources, i.e.
+	// repos. The page is marked with sigils above the content
+	Page  int `json:"page,omitempty"`
+	Pages int `json:"pages,omitempty"`
+}
+
+// Section is a group of rows under an optional title. TitleColor, a tview color, overrides
+// the subheading color the title is otherwise shown in
+type Section struct {
+	Title      string `json:"title,omitempty"`
+	TitleColor string `json:"-"`
+	Rows       []Row  `json:"rows"`
+}
+
+// Row is one line of content, made up of columns that are lined up with the same columns of
+// the other rows in its section
+type Row struct {
+	Cells      []Cell `json:"cells"`
+	Link       string `json:"link,omitempty"`
+	Selectable bool   `json:"selectable,omitempty"`
+	Status     Status `json:"status,omitempty"`
+}
+
+// Cell is one column of a row. Color, a tview color, overrides the color of its status
+type Cell struct {
+	Text   string `json:"text"`
+	Align  int    `json:"-"`
+	Color  string `json:"-"`
+	Status Status `json:"status,omitempty"`
+}
+
+// NewContent returns empty content with nothing selected
+func NewContent() *Content {
+	return &Content{Selected: -1}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// AddSection appends a section with the given title and returns it, for rows to be added to
+func (content *Content) AddSection(title string) *Section {
+	content.Sections = append(content.Sections, Section{Title: title})
+	return &content.Sections[len(content.Sections)-1]
+}
+
+// SelectedRow returns the selected row, or nil if none is
+func (content *Content) SelectedRow() *Row {
+	idx := 0
+
+	for i := range content.Sections {
+		for j := range content.Sections[i].Rows {
+			row := &content.Sections[i].Rows[j]
+			if !row.Selectable {
+				continue
+			}
+
+			if idx == content.Selected {
+				return row
+			}
+			idx++
+		}
+	}
+
+	return nil
+}
+
+// AddRow appends a row made of the given cells, one per column
+func (section *Section) AddRow(cells ...Cell) *Row {
+	section.Rows = append(section.Rows, Row{Cells: cells})
+	return &section.Rows[len(section.Rows)-1]
+}
+
+// Text returns a plain left-aligned cell
+func Text(text string) Cell {
+	return Cell{Text: text}
+}
+
+/* -------------------- Rendering -------------------- */
+
+// RenderMarkup turns the content into the tview markup displayed by the widget configured
+// under the given key. Selectable rows are striped with the widget's row colors, and wrapped in
+// regions named after their index so the view can scroll to them. The selected row is only
+// highlighted while the widget has focus
+func RenderMarkup(content *Content, key string, focused bool) string {
+	sections := []string{}
+	item := 0
+
+	for _, section := range content.Sections {
+		str := ""
+
+		if section.Title != "" {
+			titleColor := section.TitleColor
+			if titleColor == "" {
+				titleColor = Config.UString("wtf.colors.subheading", "red")
+			}
+
+			str = str + fmt.Sprintf(" [%s]%s[white]\n", titleColor, escapeTags(section.Title))
+		}
+
+		widths := columnWidths(section.Rows)
+
+		for _, row := range section.Rows {
+			rowColor := statusColor(row.Status, "white")
+
+			if row.Selectable {
+				rowColor = RowColor(key, item)
+				if focused && item == content.Selected {
+					rowColor = DefaultFocussedRowColor()
+				}
+
+				str = str + fmt.Sprintf(`["%d"][""]`, item)
+				item++
+			}
+
+			str = str + renderRow(row, widths, rowColor) + "\n"
+		}
+
+		sections = append(sections, str)
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func columnWidths(rows []Row) []int {
+	widths := []int{}
+
+	for _, row := range rows {
+		for idx, cell := range row.Cells {
+			if idx >= len(widths) {
+				widths = append(widths, 0)
+			}
+
+			if width := textWidth(cell.Text); width > widths[idx] {
+				widths[idx] = width
+			}
+		}
+	}
+
+	return widths
+}
+
+// renderRow lines each cell up with its column. The last cell isn't padded, so long text at
+// the end of a row doesn't leave trailing space behind it
+func renderRow(row Row, widths []int, rowColor string) string {
+	cells := []string{}
+
+	for idx, cell := range row.Cells {
+		text := cell.Text
+		padding := widths[idx] - textWidth(text)
+
+		if cell.Align == tview.AlignRight {
+			text = strings.Repeat(" ", padding) + text
+		} else if idx < len(row.Cells)-1 {
+			text = text + strings.Repeat(" ", padding)
+		}
+
+		color := cell.Color
+		if color == "" {
+			color = statusColor(cell.Status, rowColor)
+		}
+
+		cells = append(cells, fmt.Sprintf("[%s]%s", color, escapeTags(text)))
+	}
+
+	return fmt.Sprintf("[%s] %s", rowColor, strings.Join(cells, " "))
+}
+
+// escapeTags stops text that happens to look like a tview tag, i.e.: "[draft]", from being
+// treated as one
+func escapeTags(text string) string {
+	return tagPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		if tag == "[]" {
+			return tag
+		}
+
+		return tag[:len(tag)-1] + "[]"
+	})
+}
+
+// textWidth returns how many columns the text takes up on screen, which for wide characters
+// and emoji isn't the number of runes
+func textWidth(text string) int {
+	return tview.StringWidth(escapeTags(text))
+}
+
+// statusColor returns the configured color of the status, or the fallback for StatusNone
+func statusColor(status Status, fallback string) string {
+	switch status {
+	case StatusOK:
+		return Config.UString("wtf.colors.status.ok", "green")
+	case StatusWarning:
+		return Config.UString("wtf.colors.status.warning", "yellow")
+	case StatusError:
+		return Config.UString("wtf.colors.status.error", "red")
+	default:
+		return fallback
+	}
+}
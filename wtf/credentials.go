@@ -0,0 +1,157 @@
+package wtf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// credentialProvider resolves the part of a credential reference after its "<scheme>:" prefix
+type credentialProvider func(ref string) (string, error)
+
+// credentialProviders are the secret stores a credential can be read from, by scheme. A
+// credential without one of these prefixes is used as-is
+var credentialProviders = map[string]credentialProvider{
+	"cmd":            credentialFromCommand,
+	"file":           credentialFromFile,
+	"pass":           credentialFromPass,
+	"secret-service": credentialFromSecretService,
+}
+
+// credentials caches every credential resolved from a secret store, by reference, so each
+// store is only asked once. It is only ever held in memory. credentialsMu guards the cache
+// only, while each reference's lock in credentialLocks is held while its store is asked, so
+// that a slow store doesn't hold up credentials from other stores
+var (
+	credentials     = map[string]string{}
+	credentialLocks = map[string]*sync.Mutex{}
+	credentialsMu   sync.Mutex
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// Credential returns the credential at the given config path, or from the named environment
+// variable if the config doesn't set one. Either may be a reference to a secret store instead
+// of the credential itself:
+//
+//	cmd:op read op://work/jira/token     the output of a shell command
+//	file:~/.secrets/jira                 the contents of a file
+//	pass:work/jira                       the first line of a pass(1) entry
+//	secret-service:service=jira,user=me  the freedesktop Secret Service item with these attributes
+func Credential(configPath, envVar string) (string, error) {
+	value := Config.UString(configPath, os.Getenv(envVar))
+
+	idx := strings.Index(value, ":")
+	if idx < 0 {
+		return value, nil
+	}
+
+	provider, ok := credentialProviders[value[:idx]]
+	if !ok {
+		return value, nil
+	}
+
+	lock := credentialLock(value)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Another widget may have resolved the same reference while this one waited for the lock
+	if credential, ok := cachedCredential(value); ok {
+		return credential, nil
+	}
+
+	credential, err := provider(value[idx+1:])
+	if err != nil {
+		return "", fmt.Errorf("could not read %s from %s: %v", configPath, value[:idx], err)
+	}
+
+	credentialsMu.Lock()
+	credentials[value] = credential
+	credentialsMu.Unlock()
+
+	return credential, nil
+}
+
+// ForgetCredentials empties the credential cache, so that credentials are read from their
+// secret stores again the next time they're used
+func ForgetCredentials() {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+
+	credentials = map[string]string{}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func cachedCredential(ref string) (string, bool) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+
+	credential, ok := credentials[ref]
+	return credential, ok
+}
+
+// credentialLock returns the lock held while the reference is resolved
+func credentialLock(ref string) *sync.Mutex {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+
+	lock, ok := credentialLocks[ref]
+	if !ok {
+		lock = &sync.Mutex{}
+		credentialLocks[ref] = lock
+	}
+
+	return lock
+}
+
+func credentialFromCommand(cmd string) (string, error) {
+	return commandOutput(exec.Command("sh", "-c", cmd))
+}
+
+func credentialFromFile(path string) (string, error) {
+	absPath, err := ExpandHomeDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// credentialFromPass reads the first line of a pass entry, which by convention is the password
+func credentialFromPass(name string) (string, error) {
+	output, err := commandOutput(exec.Command("pass", "show", name))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.SplitN(output, "\n", 2)[0], nil
+}
+
+// commandOutput runs the command and returns its trimmed output. If the command fails,
+// its error output is returned as the error
+func commandOutput(cmd *exec.Cmd) (string, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
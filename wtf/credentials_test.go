@@ -0,0 +1,122 @@
+package wtf
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+func Test_Credential(t *testing.T) {
+	file, err := ioutil.TempFile("", "wtf_credential")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	file.WriteString("from-file\n")
+	file.Close()
+
+	Config, _ = config.ParseYaml(`
+wtf:
+  mods:
+    plain:
+      apiKey: "abc123"
+    fromFile:
+      apiKey: "file:` + file.Name() + `"
+    fromCmd:
+      apiKey: "cmd:echo from-cmd"
+    unknownScheme:
+      apiKey: "https://example.com"
+`)
+
+	os.Setenv("WTF_TEST_CREDENTIAL", "from-env")
+	defer os.Unsetenv("WTF_TEST_CREDENTIAL")
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{"plain value", "wtf.mods.plain.apiKey", "abc123"},
+		{"file reference", "wtf.mods.fromFile.apiKey", "from-file"},
+		{"command reference", "wtf.mods.fromCmd.apiKey", "from-cmd"},
+		{"unknown scheme", "wtf.mods.unknownScheme.apiKey", "https://example.com"},
+		{"environment variable", "wtf.mods.missing.apiKey", "from-env"},
+	}
+
+	for _, tt := range tests {
+		actual, err := Credential(tt.path, "WTF_TEST_CREDENTIAL")
+
+		if err != nil || actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v (%v)", tt.name, tt.expected, actual, err)
+		}
+	}
+}
+
+func Test_CredentialIsCached(t *testing.T) {
+	ForgetCredentials()
+
+	file, err := ioutil.TempFile("", "wtf_credential")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	file.WriteString("first")
+	file.Close()
+
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    cached:\n      apiKey: \"file:" + file.Name() + "\"\n")
+
+	Credential("wtf.mods.cached.apiKey", "")
+	ioutil.WriteFile(file.Name(), []byte("second"), 0600)
+
+	if actual, _ := Credential("wtf.mods.cached.apiKey", ""); actual != "first" {
+		t.Errorf("cached: expected: first, got: %v", actual)
+	}
+
+	ForgetCredentials()
+
+	if actual, _ := Credential("wtf.mods.cached.apiKey", ""); actual != "second" {
+		t.Errorf("forgotten: expected: second, got: %v", actual)
+	}
+}
+
+func Test_CredentialDoesNotWaitForOtherStores(t *testing.T) {
+	ForgetCredentials()
+
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    slow:\n      apiKey: \"cmd:sleep 2; echo slow\"\n    fast:\n      apiKey: \"cmd:echo fast\"\n")
+
+	go Credential("wtf.mods.slow.apiKey", "")
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	if actual, _ := Credential("wtf.mods.fast.apiKey", ""); actual != "fast" {
+		t.Errorf("fast: expected: fast, got: %v", actual)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("fast: expected it not to wait for the slow store, took %v", elapsed)
+	}
+}
+
+func Test_CredentialErrors(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    broken:\n      apiKey: \"file:/this/file/does/not/exist\"\n")
+
+	if _, err := Credential("wtf.mods.broken.apiKey", ""); err == nil {
+		t.Errorf("missing file: expected an error, got none")
+	}
+}
+
+func Test_SecretAttributes(t *testing.T) {
+	attributes, err := secretAttributes("service=jira, user=me")
+	if err != nil || attributes["service"] != "jira" || attributes["user"] != "me" {
+		t.Errorf("valid: expected: map[service:jira user:me], got: %v (%v)", attributes, err)
+	}
+
+	if _, err := secretAttributes("jira"); err == nil {
+		t.Errorf("invalid: expected an error, got none")
+	}
+}
@@ -1,56 +1,107 @@
 package wtf
 
 import (
+	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 )
 
 type Display struct {
 	Grid *tview.Grid
+
+	defaultLayout Layout
+	layoutIdx     int
+	layouts       []Layout
+	widgets       []Wtfable
 }
 
-func NewDisplay(widgets []Wtfable) *Display {
+// NewDisplay lays the widgets out on the grid defined under the given config path, i.e.: "wtf"
+// for "wtf.grid". If layouts are defined under it too, the one that best fits the terminal is
+// used instead, and it's chosen again whenever the terminal is resized
+func NewDisplay(widgets []Wtfable, configKey string) *Display {
 	display := Display{
 		Grid: tview.NewGrid(),
+
+		defaultLayout: defaultLayout(configKey),
+		layoutIdx:     -1,
+		layouts:       LayoutsFor(configKey),
+		widgets:       widgets,
 	}
 
-	display.build(widgets)
+	display.Grid.SetBorder(false)
 	display.Grid.SetBackgroundColor(ColorFor(Config.UString("wtf.colors.background", "black")))
+	display.Grid.SetDrawFunc(display.fitLayout)
+
+	display.build()
 
 	return &display
 }
 
+/* -------------------- Exported Functions -------------------- */
+
+// Shows returns true if the current layout puts the widget on screen
+func (display *Display) Shows(widget Wtfable) bool {
+	layout := display.layout()
+
+	return !widget.Disabled() && layout.Shows(widget)
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (display *Display) add(widget Wtfable) {
-	if widget.Disabled() {
+	if !display.Shows(widget) {
 		return
 	}
 
-	if !widget.IsPositionable() {
-		return
-	}
+	layout := display.layout()
+	pos := layout.PositionOf(widget)
 
 	display.Grid.AddItem(
 		widget.TextView(),
-		widget.Top(),
-		widget.Left(),
-		widget.Height(),
-		widget.Width(),
+		pos.Top(),
+		pos.Left(),
+		pos.Height(),
+		pos.Width(),
 		0,
 		0,
 		false,
 	)
 }
 
-func (display *Display) build(widgets []Wtfable) *tview.Grid {
-	display.Grid.SetColumns(ToInts(Config.UList("wtf.grid.columns"))...)
-	display.Grid.SetRows(ToInts(Config.UList("wtf.grid.rows"))...)
-	display.Grid.SetBorder(false)
+func (display *Display) build() *tview.Grid {
+	layout := display.layout()
 
-	for _, widget := range widgets {
+	display.Grid.Clear()
+	display.Grid.SetColumns(layout.Columns...)
+	display.Grid.SetRows(layout.Rows...)
+
+	for _, widget := range display.widgets {
 		display.add(widget)
-		go Schedule(widget)
 	}
 
 	return display.Grid
 }
+
+// fitLayout is a tview draw function that switches to a different layout, just before the
+// grid is drawn, if the terminal has been resized enough to need one
+func (display *Display) fitLayout(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	screenWidth, screenHeight := screen.Size()
+
+	if idx := BestLayout(display.layouts, screenWidth, screenHeight); idx != display.layoutIdx {
+		display.layoutIdx = idx
+		display.build()
+
+		for _, widget := range display.widgets {
+			setVisible(widget, display.Shows(widget))
+		}
+	}
+
+	return x, y, width, height
+}
+
+func (display *Display) layout() *Layout {
+	if display.layoutIdx < 0 {
+		return &display.defaultLayout
+	}
+
+	return &display.layouts[display.layoutIdx]
+}
@@ -70,7 +70,7 @@ func (tracker *FocusTracker) FocusOn(char string) bool {
 	hasFocusable := false
 
 	for idx, focusable := range tracker.focusables() {
-		if focusable.FocusChar() == char {
+		if focusable.FocusChar() == char && !focusable.Hidden() {
 			tracker.blur(tracker.Idx)
 			tracker.Idx = idx
 			tracker.focus(tracker.Idx)
@@ -83,6 +83,32 @@ func (tracker *FocusTracker) FocusOn(char string) bool {
 	return hasFocusable
 }
 
+// FocusWidget sets the focus on the given widget, and returns false if it can't be focused
+func (tracker *FocusTracker) FocusWidget(widget Wtfable) bool {
+	for idx, focusable := range tracker.focusables() {
+		if focusable == widget && !focusable.Hidden() {
+			tracker.blur(tracker.Idx)
+			tracker.Idx = idx
+			tracker.focus(tracker.Idx)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Focused returns the widget that has focus, or nil if none of them do
+func (tracker *FocusTracker) Focused() Wtfable {
+	for _, widget := range tracker.Widgets {
+		if widget.TextView() == tracker.App.GetFocus() {
+			return widget
+		}
+	}
+
+	return nil
+}
+
 // Next sets the focus on the next widget in the widget list. If the current widget is
 // the last widget, sets focus on the first widget.
 func (tracker *FocusTracker) Next() {
@@ -134,11 +160,19 @@ func (tracker *FocusTracker) blur(idx int) {
 	view.SetBorderColor(ColorFor(widget.BorderColor()))
 }
 
+// decrement moves to the previous focusable widget, skipping over any that the board's
+// layout has hidden
 func (tracker *FocusTracker) decrement() {
-	tracker.Idx = tracker.Idx - 1
+	for range tracker.focusables() {
+		tracker.Idx = tracker.Idx - 1
 
-	if tracker.Idx < 0 {
-		tracker.Idx = len(tracker.focusables()) - 1
+		if tracker.Idx < 0 {
+			tracker.Idx = len(tracker.focusables()) - 1
+		}
+
+		if !tracker.focusableAt(tracker.Idx).Hidden() {
+			return
+		}
 	}
 }
 
@@ -189,11 +223,19 @@ func (tracker *FocusTracker) focusState() FocusState {
 	return appBoardFocused
 }
 
+// increment moves to the next focusable widget, skipping over any that the board's layout
+// has hidden
 func (tracker *FocusTracker) increment() {
-	tracker.Idx = tracker.Idx + 1
+	for range tracker.focusables() {
+		tracker.Idx = tracker.Idx + 1
 
-	if tracker.Idx == len(tracker.focusables()) {
-		tracker.Idx = 0
+		if tracker.Idx >= len(tracker.focusables()) {
+			tracker.Idx = 0
+		}
+
+		if !tracker.focusableAt(tracker.Idx).Hidden() {
+			return
+		}
 	}
 }
 
@@ -4,23 +4,33 @@ import (
 	"github.com/rivo/tview"
 )
 
+// HelpfulWidget is a widget with keyboard commands, and a help window that lists them
 type HelpfulWidget struct {
-	app      *tview.Application
-	helpText string
-	pages    *tview.Pages
-	view     *tview.TextView
+	Keymap *Keymap
+
+	app   *tview.Application
+	pages *tview.Pages
+	view  *tview.TextView
 }
 
-func NewHelpfulWidget(app *tview.Application, pages *tview.Pages, helpText string) HelpfulWidget {
+func NewHelpfulWidget(app *tview.Application, pages *tview.Pages, keymap *Keymap) HelpfulWidget {
 	return HelpfulWidget{
-		app:      app,
-		helpText: helpText,
-		pages:    pages,
+		Keymap: keymap,
+
+		app:   app,
+		pages: pages,
 	}
 }
 
+// Commands returns the widget's keymap, so that its commands can be run from the command palette
+func (widget *HelpfulWidget) Commands() *Keymap {
+	return widget.Keymap
+}
+
+// SetView sets the view the help window returns focus to, and binds the help command to it
 func (widget *HelpfulWidget) SetView(view *tview.TextView) {
 	widget.view = view
+	widget.Keymap.On(HelpKeyBinding.Action, widget.ShowHelp)
 }
 
 func (widget *HelpfulWidget) ShowHelp() {
@@ -29,7 +39,7 @@ func (widget *HelpfulWidget) ShowHelp() {
 		widget.app.SetFocus(widget.view)
 	}
 
-	modal := NewBillboardModal(widget.helpText, closeFunc)
+	modal := NewBillboardModal(widget.Keymap.HelpText(), closeFunc)
 
 	widget.pages.AddPage("help", modal, false, true)
 	widget.app.SetFocus(modal)
@@ -0,0 +1,300 @@
+package wtf
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// httpSettings are the settings an HTTP client is built from. They're compared to decide
+// whether a client can be reused after the config changes
+type httpSettings struct {
+	caBundle   string
+	clientCert string
+	clientKey  string
+	proxy      string
+	rateLimit  float64
+	timeout    int
+	userAgent  string
+	verify     bool
+}
+
+// httpClientEntry is a client along with the settings it was built from
+type httpClientEntry struct {
+	client   *http.Client
+	settings httpSettings
+}
+
+// httpClients are the clients built so far, by widget key, so that each widget reuses its
+// connections and shares one rate limit across its requests
+var (
+	httpClients   = map[string]httpClientEntry{}
+	httpClientsMu sync.Mutex
+)
+
+// contextTransport makes every request with the given context, for libraries that make their
+// own requests without one
+type contextTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+}
+
+// httpTransport sets the user agent and enforces the rate limit before passing each request
+// on to the real transport
+type httpTransport struct {
+	limiter   *rateLimiter
+	next      http.RoundTripper
+	userAgent string
+}
+
+// rateLimiter spaces requests out evenly, so that there are never more than a given number a
+// second
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// HTTPClient returns the HTTP client for the widget configured under "wtf.mods.<key>". It's set
+// up from the widget's "http" settings, each of which falls back to the same setting under
+// "wtf.http":
+//
+//	timeout                  seconds before a request is given up on, 30 by default
+//	proxy                    proxy URL, instead of the one in HTTP_PROXY and HTTPS_PROXY
+//	caBundle                 PEM file of certificate authorities to trust on top of the system's
+//	clientCertificate        PEM file of a certificate to present to the server
+//	clientKey                PEM file of its private key, if it isn't in clientCertificate
+//	verifyServerCertificate  false skips verifying the server's certificate
+//	userAgent                User-Agent header for requests that don't set their own
+//	rateLimit                most requests a second, unlimited by default
+//
+// The module's own "verifyServerCertificate" setting, which some modules already have, takes
+// precedence over the "http" one. The same client is returned for as long as its settings
+// don't change.
+//
+// Two modules can't use it, because their libraries make their own clients and don't take one:
+// todoist, whose library makes a new default client for every request, and spotifyweb, whose
+// authenticator keeps the client it makes its requests with unexported
+func HTTPClient(key string) (*http.Client, error) {
+	settings := httpSettingsFor(key)
+
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+
+	if entry, ok := httpClients[key]; ok && entry.settings == settings {
+		return entry.client, nil
+	}
+
+	client, err := newHTTPClient(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClients[key] = httpClientEntry{client: client, settings: settings}
+
+	return client, nil
+}
+
+// HTTPClientWithContext returns a copy of the widget's HTTP client that makes every request
+// with ctx, so that they're cancelled along with it. It's for libraries that make their own
+// requests and don't take a context. The copy shares the client's connections and rate limit
+func HTTPClientWithContext(ctx context.Context, key string) (*http.Client, error) {
+	client, err := HTTPClient(key)
+	if err != nil {
+		return nil, err
+	}
+
+	withContext := *client
+	withContext.Transport = &contextTransport{ctx: ctx, next: client.Transport}
+
+	return &withContext, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return transport.next.RoundTrip(req.WithContext(transport.ctx))
+}
+
+// RoundTrip implements http.RoundTripper
+func (transport *httpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if transport.limiter != nil {
+		if err := transport.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if transport.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = withHeaderCopy(req)
+		req.Header.Set("User-Agent", transport.userAgent)
+	}
+
+	return transport.next.RoundTrip(req)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func httpSettingsFor(key string) httpSettings {
+	setting := func(name string) string {
+		return ConfigKeyFor(key, "http."+name)
+	}
+
+	verify := Config.UBool(setting("verifyServerCertificate"), Config.UBool("wtf.http.verifyServerCertificate", true))
+
+	return httpSettings{
+		caBundle:   Config.UString(setting("caBundle"), Config.UString("wtf.http.caBundle", "")),
+		clientCert: Config.UString(setting("clientCertificate"), Config.UString("wtf.http.clientCertificate", "")),
+		clientKey:  Config.UString(setting("clientKey"), Config.UString("wtf.http.clientKey", "")),
+		proxy:      Config.UString(setting("proxy"), Config.UString("wtf.http.proxy", "")),
+		rateLimit:  Config.UFloat64(setting("rateLimit"), Config.UFloat64("wtf.http.rateLimit", 0)),
+		timeout:    Config.UInt(setting("timeout"), Config.UInt("wtf.http.timeout", 30)),
+		userAgent:  Config.UString(setting("userAgent"), Config.UString("wtf.http.userAgent", "")),
+		verify:     Config.UBool(ConfigKeyFor(key, "verifyServerCertificate"), verify),
+	}
+}
+
+func newHTTPClient(settings httpSettings) (*http.Client, error) {
+	tlsConfig, err := tlsConfigFor(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same settings as http.DefaultTransport
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	if settings.proxy != "" {
+		proxyURL, err := url.Parse(settings.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy '%s': %v", settings.proxy, err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	wrapped := &httpTransport{
+		next:      transport,
+		userAgent: settings.userAgent,
+	}
+
+	if settings.rateLimit > 0 {
+		wrapped.limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / settings.rateLimit)}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(settings.timeout) * time.Second,
+		Transport: wrapped,
+	}, nil
+}
+
+func tlsConfigFor(settings httpSettings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !settings.verify,
+	}
+
+	if settings.caBundle != "" {
+		path, err := ExpandHomeDir(settings.caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in '%s'", settings.caBundle)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.clientCert != "" {
+		certPath, err := ExpandHomeDir(settings.clientCert)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPath := certPath
+		if settings.clientKey != "" {
+			if keyPath, err = ExpandHomeDir(settings.clientKey); err != nil {
+				return nil, err
+			}
+		}
+
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// withHeaderCopy returns a shallow copy of the request with its own copy of the headers, since
+// a RoundTripper mustn't change the request it's given
+func withHeaderCopy(req *http.Request) *http.Request {
+	copied := req.WithContext(req.Context())
+
+	copied.Header = make(http.Header, len(req.Header))
+	for name, values := range req.Header {
+		copied.Header[name] = append([]string(nil), values...)
+	}
+
+	return copied
+}
+
+// wait blocks until the next request is allowed, or the context is done
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	limiter.mu.Lock()
+
+	now := time.Now()
+	if limiter.next.Before(now) {
+		limiter.next = now
+	}
+
+	delay := limiter.next.Sub(now)
+	limiter.next = limiter.next.Add(limiter.interval)
+
+	limiter.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package wtf
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+func Test_HTTPClient(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  http:\n    userAgent: wtf-test\n    timeout: 5\n  mods:\n    jira:\n      http:\n        rateLimit: 20\n")
+	httpClients = map[string]httpClientEntry{}
+
+	agents := make(chan string, 3)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents <- r.Header.Get("User-Agent")
+	}))
+	defer ts.Close()
+
+	client, err := HTTPClient("jira")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.Timeout != 5*time.Second {
+		t.Errorf("timeout: expected: %v, got: %v", 5*time.Second, client.Timeout)
+	}
+
+	if again, _ := HTTPClient("jira"); again != client {
+		t.Errorf("expected: the same client while its settings are unchanged")
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+
+		if agent := <-agents; agent != "wtf-test" {
+			t.Errorf("user agent: expected: %v, got: %v", "wtf-test", agent)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected: 3 requests at 20 a second to take at least 100ms, got: %v", elapsed)
+	}
+}
+
+func Test_HTTPClientTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "wtf-http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	caBundle := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := ioutil.WriteFile(caBundle, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		yaml     string
+		expected bool
+	}{
+		{"default", "wtf:\n  mods:\n    gerrit:\n      enabled: true\n", false},
+		{"verifyServerCertificate", "wtf:\n  mods:\n    gerrit:\n      verifyServerCertificate: false\n", true},
+		{"caBundle", "wtf:\n  http:\n    caBundle: " + caBundle + "\n", true},
+	}
+
+	for _, test := range tests {
+		Config, _ = config.ParseYaml(test.yaml)
+
+		client, err := HTTPClient("gerrit")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		resp, err := client.Get(ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		if actual := err == nil; actual != test.expected {
+			t.Errorf("%s: expected: %v, got: %v (%v)", test.name, test.expected, actual, err)
+		}
+	}
+}
+
+func Test_HTTPClientWithContext(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    jira:\n      enabled: true\n")
+	httpClients = map[string]httpClientEntry{}
+
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client, err := HTTPClientWithContext(ctx, "jira")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Errorf("expected: the request to be cancelled along with its context")
+	}
+}
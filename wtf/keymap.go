@@ -0,0 +1,595 @@
+package wtf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+)
+
+// keyChordTimeout is how long a chord waits for its next key before starting over
+const keyChordTimeout = time.Second
+
+// KeyBinding is a command that can be run from the keyboard
+type KeyBinding struct {
+	// Action names the command in the config, i.e.: "next"
+	Action string
+
+	// Keys are the keys that run the command unless the config rebinds it. Each is a key name,
+	// i.e.: "j", "G", "space", "ctrl-d", "alt-x", "enter", "esc" or "down", or a chord of key
+	// names pressed one after the other, separated by spaces, i.e.: "g t"
+	Keys []string
+
+	// Help describes the command in the help window
+	Help string
+
+	// PassThrough is true for app commands that still let the focused widget see the key
+	PassThrough bool
+}
+
+// AppKeys are the commands that work everywhere, whichever widget has focus. They're rebound
+// with "wtf.keys.<action>"
+var AppKeys = []KeyBinding{
+	{Action: "focusNext", Keys: []string{"tab"}, Help: "Focus the next widget"},
+	{Action: "focusPrev", Keys: []string{"backtab"}, Help: "Focus the previous widget"},
+	{Action: "unfocus", Keys: []string{"esc"}, Help: "Unfocus the focused widget", PassThrough: true},
+	{Action: "nextBoard", Keys: []string{"ctrl-n"}, Help: "Show the next board"},
+	{Action: "prevBoard", Keys: []string{"ctrl-p"}, Help: "Show the previous board"},
+	{Action: "refreshAll", Keys: []string{"ctrl-r"}, Help: "Refresh every widget"},
+	{Action: "retryCrashed", Keys: []string{"ctrl-t"}, Help: "Retry the widgets that crashed"},
+	{Action: "zoom", Keys: []string{"z"}, Help: "Show the focused widget full screen"},
+	{Action: "palette", Keys: []string{":"}, Help: "Search widgets and commands"},
+	{Action: "search", Keys: []string{"/"}, Help: "Search the content of every widget"},
+}
+
+// HelpKeyBinding shows and hides a widget's help window. Every module with keys binds it
+var HelpKeyBinding = KeyBinding{Action: "help", Keys: []string{"?"}, Help: "Show/hide this help window"}
+
+// RefreshKeyBinding refreshes the focused widget
+var RefreshKeyBinding = KeyBinding{Action: "refresh", Keys: []string{"r"}, Help: "Refresh the data"}
+
+// keyPresets replace the default keys of the commands they name, and are chosen with
+// "wtf.keys.preset". Keys set in the config take precedence over them
+var keyPresets = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"focusNext":  {"tab", "ctrl-w w"},
+		"focusPrev":  {"backtab", "ctrl-w W"},
+		"nextBoard":  {"g t"},
+		"prevBoard":  {"g T"},
+		"next":       {"j", "down"},
+		"prev":       {"k", "up"},
+		"nextSource": {"l", "right"},
+		"prevSource": {"h", "left"},
+	},
+	"emacs": {
+		"focusNext":  {"tab", "ctrl-x o"},
+		"unfocus":    {"esc", "ctrl-g"},
+		"nextBoard":  {"ctrl-x ]"},
+		"prevBoard":  {"ctrl-x ["},
+		"next":       {"ctrl-n", "down"},
+		"prev":       {"ctrl-p", "up"},
+		"nextSource": {"ctrl-f", "right"},
+		"prevSource": {"ctrl-b", "left"},
+		"unselect":   {"esc", "ctrl-g"},
+	},
+}
+
+// keyAliases are the other names a key can be given in the config
+var keyAliases = map[string]string{
+	"backspace2": "backspace",
+	"ctrl-[":     "esc",
+	"ctrl-h":     "backspace",
+	"ctrl-i":     "tab",
+	"ctrl-m":     "enter",
+	"del":        "delete",
+	"escape":     "esc",
+	"pagedown":   "pgdn",
+	"pageup":     "pgup",
+	"return":     "enter",
+	"shift-tab":  "backtab",
+}
+
+// namedKeys are the names of every key that isn't a character
+var namedKeys = map[string]bool{"space": true}
+
+func init() {
+	for _, name := range tcell.KeyNames {
+		namedKeys[normalizeKey(name)] = true
+	}
+}
+
+// Commandable is implemented by widgets with keyboard commands, so that their commands can also
+// be run from the command palette
+type Commandable interface {
+	Commands() *Keymap
+}
+
+// Keymap binds keys, and chords of several keys, to a widget's commands. Each command's keys
+// come from the first of these that sets them:
+//
+//	wtf.mods.<key>.keys.<action>   the widget's own config
+//	wtf.keys.<action>              every widget's config
+//	wtf.keys.preset                "vim" or "emacs"
+//
+// and otherwise from the command's default keys. Setting a command's keys to an empty list
+// unbinds it
+type Keymap struct {
+	// Notes are shown in the help window above the commands
+	Notes string
+
+	bindings  []KeyBinding
+	chords    map[string]string
+	handlers  map[string]func()
+	pending   string
+	pendingAt time.Time
+	prefixes  map[string]bool
+	title     string
+}
+
+// NewKeymap creates a keymap for the widget configured under "wtf.mods.<configKey>", or for the
+// app as a whole if configKey is empty. The title names it in the help window
+func NewKeymap(title string, configKey string, bindings []KeyBinding) *Keymap {
+	keymap := Keymap{
+		bindings: resolveKeys(Config, configKey, bindings),
+		chords:   map[string]string{},
+		handlers: map[string]func(){},
+		prefixes: map[string]bool{},
+		title:    title,
+	}
+
+	// Where two commands share a key the first one declared gets it, which KeyProblems reports
+	for idx := len(keymap.bindings) - 1; idx >= 0; idx-- {
+		binding := keymap.bindings[idx]
+
+		for _, key := range binding.Keys {
+			keymap.chords[key] = binding.Action
+
+			steps := strings.Split(key, " ")
+			for end := 1; end < len(steps); end++ {
+				keymap.prefixes[strings.Join(steps[:end], " ")] = true
+			}
+		}
+	}
+
+	return &keymap
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Bindings returns the commands with the keys they're bound to
+func (keymap *Keymap) Bindings() []KeyBinding {
+	return keymap.bindings
+}
+
+// Handle runs the command bound to the key, and returns true if the key was used, either to run
+// a command or as part of a chord that isn't finished yet
+func (keymap *Keymap) Handle(event *tcell.EventKey) bool {
+	binding, ok := keymap.Match(event)
+	if !ok {
+		return false
+	}
+
+	if binding.Action == "" {
+		return true
+	}
+
+	return keymap.Run(binding.Action)
+}
+
+// HelpText returns the help window text listing each command and its keys
+func (keymap *Keymap) HelpText() string {
+	return KeysHelp(keymap.title, keymap.Notes, keymap.bindings)
+}
+
+// InputCapture is a tview input capture function that runs the commands bound to keys, and
+// passes every other key along
+func (keymap *Keymap) InputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if keymap.Handle(event) {
+		return nil
+	}
+
+	return event
+}
+
+// Match returns the command the key finishes. If the key starts or continues a chord, it's
+// matched with an empty command until the chord is finished
+func (keymap *Keymap) Match(event *tcell.EventKey) (KeyBinding, bool) {
+	name := keyName(event)
+
+	if keymap.pending != "" && time.Since(keymap.pendingAt) > keyChordTimeout {
+		keymap.pending = ""
+	}
+
+	if name == "" {
+		keymap.pending = ""
+		return KeyBinding{}, false
+	}
+
+	key := name
+	if keymap.pending != "" {
+		key = keymap.pending + " " + name
+	}
+
+	keymap.pending = ""
+
+	if action, ok := keymap.chords[key]; ok {
+		return keymap.binding(action), true
+	}
+
+	if keymap.prefixes[key] {
+		keymap.pending = key
+		keymap.pendingAt = time.Now()
+		return KeyBinding{}, true
+	}
+
+	// A key that doesn't continue the chord starts over on its own
+	if key != name {
+		return keymap.Match(event)
+	}
+
+	return KeyBinding{}, false
+}
+
+// On sets the function that runs the command
+func (keymap *Keymap) On(action string, handler func()) {
+	keymap.handlers[action] = handler
+}
+
+// Run runs the command, and returns false if nothing runs it
+func (keymap *Keymap) Run(action string) bool {
+	handler, ok := keymap.handlers[action]
+	if !ok {
+		return false
+	}
+
+	handler()
+
+	return true
+}
+
+// ActionWords returns the command's name as lower-case words, i.e.: "refresh all" for
+// "refreshAll"
+func ActionWords(action string) string {
+	words := []rune{}
+
+	for idx, ch := range action {
+		if unicode.IsUpper(ch) && idx > 0 {
+			words = append(words, ' ')
+		}
+		words = append(words, unicode.ToLower(ch))
+	}
+
+	return string(words)
+}
+
+// KeysHelp returns the help window text for the commands
+func KeysHelp(title string, notes string, bindings []KeyBinding) string {
+	keysWidth, actionWidth := 0, 0
+	for _, binding := range bindings {
+		if width := utf8.RuneCountInString(strings.Join(binding.Keys, ", ")); width > keysWidth {
+			keysWidth = width
+		}
+
+		if len(binding.Action) > actionWidth {
+			actionWidth = len(binding.Action)
+		}
+	}
+
+	str := fmt.Sprintf("\n Keyboard commands for %s:\n\n", title)
+
+	if notes != "" {
+		str = str + notes + "\n\n"
+	}
+
+	for _, binding := range bindings {
+		if len(binding.Keys) == 0 {
+			continue
+		}
+
+		keys := strings.Join(binding.Keys, ", ")
+		padding := strings.Repeat(" ", keysWidth-utf8.RuneCountInString(keys))
+
+		str = str + fmt.Sprintf("   %s%s  %-*s  %s\n", keys, padding, actionWidth, binding.Action, binding.Help)
+	}
+
+	return str
+}
+
+// KeyProblems checks the keys the config binds to the commands of the widget configured under
+// "wtf.mods.<configKey>", or to the app's commands if configKey is empty. It returns a
+// description of each key that isn't a key, each command that doesn't exist, and each key
+// that's bound to more than one command
+func KeyProblems(conf *config.Config, configKey string, bindings []KeyBinding) []string {
+	problems := []string{}
+
+	path := "wtf.keys"
+	if configKey != "" {
+		path = ConfigKeyFor(configKey, "keys")
+	}
+
+	preset := conf.UString("wtf.keys.preset", "default")
+	if _, ok := keyPresets[preset]; !ok && configKey == "" {
+		problems = append(problems, fmt.Sprintf("unknown preset '%s', expected default, vim or emacs", preset))
+	}
+
+	for action := range conf.UMap(path) {
+		if configKey == "" && action == "preset" {
+			continue
+		}
+
+		if !knownAction(action, configKey, bindings) {
+			problems = append(problems, fmt.Sprintf("%s: unknown command", action))
+		}
+	}
+
+	resolved := resolveKeys(conf, configKey, bindings)
+
+	for _, binding := range resolved {
+		for _, key := range binding.Keys {
+			for _, step := range strings.Split(key, " ") {
+				if !isKey(step) {
+					problems = append(problems, fmt.Sprintf("%s: '%s' isn't a key", binding.Action, step))
+				}
+			}
+		}
+	}
+
+	problems = append(problems, keyConflicts(resolved)...)
+
+	if configKey != "" {
+		problems = append(problems, appKeyConflicts(resolveKeys(conf, "", AppKeys), resolved)...)
+	}
+
+	sort.Strings(problems)
+
+	return problems
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (keymap *Keymap) binding(action string) KeyBinding {
+	for _, binding := range keymap.bindings {
+		if binding.Action == action {
+			return binding
+		}
+	}
+
+	return KeyBinding{}
+}
+
+// appKeysFor returns the keys the config binds to the app command, which may be none
+func appKeysFor(action string) []string {
+	for _, binding := range resolveKeys(Config, "", AppKeys) {
+		if binding.Action == action {
+			return binding.Keys
+		}
+	}
+
+	return []string{}
+}
+
+// appKeyConflicts returns the widget keys that never reach the widget because an app command
+// takes them first
+func appKeyConflicts(appBindings []KeyBinding, bindings []KeyBinding) []string {
+	conflicts := []string{}
+
+	for _, app := range appBindings {
+		if app.PassThrough {
+			continue
+		}
+
+		for _, appKey := range app.Keys {
+			for _, binding := range bindings {
+				for _, key := range binding.Keys {
+					if key == appKey || strings.HasPrefix(key, appKey+" ") || strings.HasPrefix(appKey, key+" ") {
+						conflicts = append(
+							conflicts,
+							fmt.Sprintf("%s: '%s' is taken by the app's %s command", binding.Action, key, app.Action),
+						)
+					}
+				}
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// configuredKeys returns the keys set at the path, which can be a single key or a list of them
+func configuredKeys(conf *config.Config, path string) ([]string, bool) {
+	if list, err := conf.List(path); err == nil {
+		keys := []string{}
+		for _, key := range list {
+			keys = append(keys, fmt.Sprint(key))
+		}
+		return keys, true
+	}
+
+	if key, err := conf.String(path); err == nil {
+		if key == "" {
+			return []string{}, true
+		}
+		return []string{key}, true
+	}
+
+	return nil, false
+}
+
+// isKey returns true if the normalized key name is a key that can be pressed
+func isKey(name string) bool {
+	name = strings.TrimPrefix(name, "alt-")
+	return utf8.RuneCountInString(name) == 1 || namedKeys[name]
+}
+
+// keyConflicts returns the keys bound to more than one command, and the keys that make a chord
+// impossible to finish because they're also bound on their own
+func keyConflicts(bindings []KeyBinding) []string {
+	conflicts := []string{}
+	owners := map[string]string{}
+
+	for _, binding := range bindings {
+		for _, key := range binding.Keys {
+			if owner, ok := owners[key]; ok && owner != binding.Action {
+				conflicts = append(conflicts, fmt.Sprintf("%s: '%s' is also bound to %s", binding.Action, key, owner))
+				continue
+			}
+
+			owners[key] = binding.Action
+		}
+	}
+
+	for key, owner := range owners {
+		for chord, chordOwner := range owners {
+			if strings.HasPrefix(chord, key+" ") {
+				conflicts = append(
+					conflicts,
+					fmt.Sprintf("%s: '%s' can't be finished because '%s' is bound to %s", chordOwner, chord, key, owner),
+				)
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// keyName returns the name of the pressed key, as it's written in the config
+func keyName(event *tcell.EventKey) string {
+	name := ""
+
+	switch {
+	case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+		name = "space"
+	case event.Key() == tcell.KeyRune:
+		name = string(event.Rune())
+	default:
+		tcellName, ok := tcell.KeyNames[event.Key()]
+		if !ok {
+			return ""
+		}
+		name = normalizeKey(tcellName)
+	}
+
+	if event.Modifiers()&tcell.ModAlt != 0 {
+		name = "alt-" + name
+	}
+
+	return name
+}
+
+// knownAction returns true if the command can be bound at the config path the keymap is read
+// from. "wtf.keys" binds the app's commands, and any module's
+func knownAction(action string, configKey string, bindings []KeyBinding) bool {
+	for _, binding := range bindings {
+		if binding.Action == action {
+			return true
+		}
+	}
+
+	if configKey != "" {
+		return false
+	}
+
+	for _, name := range ModuleNames() {
+		module, _ := ModuleFor(name)
+		for _, binding := range module.Keys {
+			if binding.Action == action {
+				return true
+			}
+		}
+	}
+
+	return action == HelpKeyBinding.Action || action == RefreshKeyBinding.Action
+}
+
+// normalizeKey returns the name the key is matched by. Single characters are case-sensitive, and
+// the names of other keys aren't
+func normalizeKey(key string) string {
+	if key == " " {
+		return "space"
+	}
+
+	key = strings.TrimSpace(key)
+	if utf8.RuneCountInString(key) <= 1 {
+		return key
+	}
+
+	lower := strings.ToLower(key)
+	if strings.HasPrefix(lower, "alt-") || strings.HasPrefix(lower, "alt+") {
+		return "alt-" + normalizeKey(key[4:])
+	}
+
+	if strings.HasPrefix(lower, "ctrl+") {
+		lower = "ctrl-" + lower[5:]
+	}
+
+	if alias, ok := keyAliases[lower]; ok {
+		return alias
+	}
+
+	return lower
+}
+
+// normalizeKeys returns the keys with each key name, and each step of each chord, normalized
+func normalizeKeys(keys []string) []string {
+	normalized := []string{}
+
+	for _, key := range keys {
+		if key == " " {
+			normalized = append(normalized, "space")
+			continue
+		}
+
+		steps := strings.Fields(key)
+		for idx, step := range steps {
+			steps[idx] = normalizeKey(step)
+		}
+
+		if len(steps) > 0 {
+			normalized = append(normalized, strings.Join(steps, " "))
+		}
+	}
+
+	return normalized
+}
+
+// resolveKeys returns the bindings with the keys the config binds them to. With no config, the
+// bindings keep their default keys
+func resolveKeys(conf *config.Config, configKey string, bindings []KeyBinding) []KeyBinding {
+	resolved := make([]KeyBinding, 0, len(bindings))
+
+	preset := map[string][]string{}
+	if conf != nil {
+		preset = keyPresets[conf.UString("wtf.keys.preset", "default")]
+	}
+
+	for _, binding := range bindings {
+		keys := binding.Keys
+
+		if presetKeys, ok := preset[binding.Action]; ok {
+			keys = presetKeys
+		}
+
+		if conf != nil {
+			if configured, ok := configuredKeys(conf, "wtf.keys."+binding.Action); ok {
+				keys = configured
+			}
+
+			if configKey != "" {
+				if configured, ok := configuredKeys(conf, ConfigKeyFor(configKey, "keys."+binding.Action)); ok {
+					keys = configured
+				}
+			}
+		}
+
+		binding.Keys = normalizeKeys(keys)
+		resolved = append(resolved, binding)
+	}
+
+	return resolved
+}
@@ -0,0 +1,195 @@
+package wtf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+)
+
+var testKeys = []KeyBinding{
+	{Action: "next", Keys: []string{"j"}, Help: "Select next item"},
+	{Action: "prev", Keys: []string{"k"}, Help: "Select previous item"},
+	{Action: "delete", Keys: []string{"ctrl-d"}, Help: "Delete the selected item"},
+}
+
+func Test_NewKeymapKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected [][]string
+	}{
+		{
+			"default",
+			"wtf:\n  mods:\n    todo:\n      enabled: true\n",
+			[][]string{{"j"}, {"k"}, {"ctrl-d"}},
+		},
+		{
+			"preset",
+			"wtf:\n  keys:\n    preset: vim\n",
+			[][]string{{"j", "down"}, {"k", "up"}, {"ctrl-d"}},
+		},
+		{
+			"wtf.keys",
+			"wtf:\n  keys:\n    preset: vim\n    next: Down\n",
+			[][]string{{"down"}, {"k", "up"}, {"ctrl-d"}},
+		},
+		{
+			"module keys",
+			"wtf:\n  keys:\n    next: down\n  mods:\n    todo:\n      keys:\n        next: ['n', 'Ctrl+N']\n        delete: ''\n",
+			[][]string{{"n", "ctrl-n"}, {"k"}, {}},
+		},
+	}
+
+	for _, test := range tests {
+		Config, _ = config.ParseYaml(test.yaml)
+
+		actual := [][]string{}
+		for _, binding := range NewKeymap("Todo", "todo", testKeys).Bindings() {
+			actual = append(actual, binding.Keys)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected: %v, got: %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func Test_KeymapHandle(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    todo:\n      keys:\n        next: 'g j'\n        prev: [k, 'g g k']\n")
+
+	keymap := NewKeymap("Todo", "todo", testKeys)
+
+	ran := []string{}
+	for _, binding := range testKeys {
+		action := binding.Action
+		keymap.On(action, func() { ran = append(ran, action) })
+	}
+
+	press := func(keys string) {
+		for _, ch := range keys {
+			keymap.Handle(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone))
+		}
+	}
+
+	press("gj")
+	press("k")
+	press("ggk")
+	press("gxk")
+	keymap.Handle(tcell.NewEventKey(tcell.KeyCtrlD, 0, tcell.ModCtrl))
+
+	expected := []string{"next", "prev", "prev", "prev", "delete"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Errorf("expected: %v, got: %v", expected, ran)
+	}
+
+	if keymap.Handle(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)) {
+		t.Errorf("expected: an unbound key to pass through")
+	}
+
+	press("g")
+	keymap.pendingAt = time.Now().Add(-2 * keyChordTimeout)
+	press("j")
+
+	if len(ran) != len(expected) {
+		t.Errorf("expected: a chord to start over once it times out, got: %v", ran)
+	}
+}
+
+func Test_KeyProblems(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		configKey string
+		expected  []string
+	}{
+		{
+			"none",
+			"wtf:\n  keys:\n    preset: vim\n",
+			"todo",
+			[]string{},
+		},
+		{
+			"module",
+			"wtf:\n  mods:\n    todo:\n      keys:\n        next: k\n        prev: [k, 'ctrl-nope']\n        jump: x\n        delete: ctrl-r\n",
+			"todo",
+			[]string{
+				"delete: 'ctrl-r' is taken by the app's refreshAll command",
+				"jump: unknown command",
+				"prev: 'ctrl-nope' isn't a key",
+				"prev: 'k' is also bound to next",
+			},
+		},
+		{
+			"chords",
+			"wtf:\n  keys:\n    nextBoard: g\n    prevBoard: 'g T'\n",
+			"",
+			[]string{"prevBoard: 'g T' can't be finished because 'g' is bound to nextBoard"},
+		},
+		{
+			"preset",
+			"wtf:\n  keys:\n    preset: nano\n",
+			"",
+			[]string{"unknown preset 'nano', expected default, vim or emacs"},
+		},
+	}
+
+	for _, test := range tests {
+		conf, _ := config.ParseYaml(test.yaml)
+
+		bindings := testKeys
+		if test.configKey == "" {
+			bindings = AppKeys
+		}
+
+		actual := KeyProblems(conf, test.configKey, bindings)
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected: %v, got: %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func Test_KeysHelp(t *testing.T) {
+	bindings := resolveKeys(nil, "", testKeys)
+
+	actual := KeysHelp("Todo", "", bindings)
+
+	expected := "   ctrl-d  delete  Delete the selected item\n"
+	if !strings.Contains(actual, expected) {
+		t.Errorf("expected: %q in %q", expected, actual)
+	}
+
+	if !strings.HasPrefix(actual, "\n Keyboard commands for Todo:\n\n   j       next") {
+		t.Errorf("unexpected help: %q", actual)
+	}
+}
+
+func Test_KeyName(t *testing.T) {
+	tests := []struct {
+		event    *tcell.EventKey
+		expected string
+	}{
+		{tcell.NewEventKey(tcell.KeyRune, 'G', tcell.ModNone), "G"},
+		{tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), "space"},
+		{tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt), "alt-x"},
+		{tcell.NewEventKey(tcell.KeyCtrlN, 0, tcell.ModCtrl), "ctrl-n"},
+		{tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModNone), "backtab"},
+		{tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), "enter"},
+	}
+
+	for _, test := range tests {
+		if actual := keyName(test.event); actual != test.expected {
+			t.Errorf("expected: %v, got: %v", test.expected, actual)
+		}
+	}
+
+	for _, alias := range []string{"Escape", "ctrl+[", "ESC"} {
+		if actual := normalizeKey(alias); actual != "esc" {
+			t.Errorf("%s: expected: esc, got: %v", alias, actual)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package wtf
+
+import (
+	"fmt"
+)
+
+// Layout is one arrangement of a board's widgets, used once the terminal is at least
+// MinWidth columns wide and MinHeight rows high. Widgets a layout doesn't position keep the
+// position from their own config
+type Layout struct {
+	MinHeight int
+	MinWidth  int
+
+	Columns   []int
+	Rows      []int
+	Hidden    map[string]bool
+	Positions map[string]Position
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// LayoutsFor reads the layouts listed at "<configKey>.layouts". Each layout uses the grid
+// at "<configKey>.grid" unless it defines its own
+func LayoutsFor(configKey string) []Layout {
+	layouts := []Layout{}
+
+	for idx := range Config.UList(configKey + ".layouts") {
+		layouts = append(layouts, layoutAt(fmt.Sprintf("%s.layouts.%d", configKey, idx), configKey))
+	}
+
+	return layouts
+}
+
+// BestLayout returns the index of the layout to use on a terminal of the given size: the one
+// with the largest minimum width that fits, and of those the one with the largest minimum
+// height. It returns -1 if none of them fit
+func BestLayout(layouts []Layout, width, height int) int {
+	best := -1
+
+	for idx, layout := range layouts {
+		if layout.MinWidth > width || layout.MinHeight > height {
+			continue
+		}
+
+		if best < 0 || layout.MinWidth > layouts[best].MinWidth ||
+			(layout.MinWidth == layouts[best].MinWidth && layout.MinHeight > layouts[best].MinHeight) {
+			best = idx
+		}
+	}
+
+	return best
+}
+
+// PositionOf returns where the layout puts the widget
+func (layout *Layout) PositionOf(widget Wtfable) Position {
+	if pos, ok := layout.Positions[widget.Key()]; ok {
+		return pos
+	}
+
+	return NewPosition(widget.Top(), widget.Left(), widget.Width(), widget.Height())
+}
+
+// Shows returns true if the layout puts the widget on screen
+func (layout *Layout) Shows(widget Wtfable) bool {
+	if layout.Hidden[widget.Key()] {
+		return false
+	}
+
+	pos := layout.PositionOf(widget)
+	return pos.IsValid()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// defaultLayout returns the layout made of the grid at "<configKey>.grid", or "wtf.grid" if
+// there isn't one, and each widget's own position
+func defaultLayout(configKey string) Layout {
+	return Layout{
+		Columns:   ToInts(Config.UList(configKey+".grid.columns", Config.UList("wtf.grid.columns"))),
+		Rows:      ToInts(Config.UList(configKey+".grid.rows", Config.UList("wtf.grid.rows"))),
+		Hidden:    map[string]bool{},
+		Positions: map[string]Position{},
+	}
+}
+
+func layoutAt(path, configKey string) Layout {
+	layout := defaultLayout(configKey)
+
+	layout.MinHeight = Config.UInt(path + ".minHeight")
+	layout.MinWidth = Config.UInt(path + ".minWidth")
+
+	if columns, err := Config.List(path + ".grid.columns"); err == nil {
+		layout.Columns = ToInts(columns)
+	}
+
+	if rows, err := Config.List(path + ".grid.rows"); err == nil {
+		layout.Rows = ToInts(rows)
+	}
+
+	for _, key := range ToStrs(Config.UList(path + ".hidden")) {
+		layout.Hidden[key] = true
+	}
+
+	for key := range Config.UMap(path + ".positions") {
+		posKey := fmt.Sprintf("%s.positions.%s", path, key)
+
+		layout.Positions[key] = NewPosition(
+			Config.UInt(posKey+".top"),
+			Config.UInt(posKey+".left"),
+			Config.UInt(posKey+".width"),
+			Config.UInt(posKey+".height"),
+		)
+	}
+
+	return layout
+}
@@ -0,0 +1,53 @@
+package wtf
+
+import (
+	"testing"
+)
+
+func Test_BestLayout(t *testing.T) {
+	layouts := []Layout{
+		{MinWidth: 120},
+		{MinWidth: 80},
+		{MinWidth: 120, MinHeight: 40},
+	}
+
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		expected int
+	}{
+		{
+			name:     "too small for any",
+			width:    60,
+			height:   20,
+			expected: -1,
+		},
+		{
+			name:     "narrow",
+			width:    100,
+			height:   50,
+			expected: 1,
+		},
+		{
+			name:     "wide but short",
+			width:    150,
+			height:   30,
+			expected: 0,
+		},
+		{
+			name:     "wide and tall",
+			width:    150,
+			height:   50,
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := BestLayout(layouts, tt.width, tt.height)
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package wtf
+
+import (
+	"strings"
+)
+
+// Span is a run of widget content drawn in the same colors and attributes. An empty color is
+// the default one
+type Span struct {
+	Text  string
+	Fore  string
+	Back  string
+	Attrs string
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ParseMarkup splits tview markup into spans of plain text and the colors they're drawn in, so
+// that it can be drawn by something other than tview, i.e.: a web page. Region tags are
+// dropped, and escaped brackets are turned back into brackets
+func ParseMarkup(markup string) []Span {
+	spans := []Span{}
+	current := Span{}
+
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+
+		if len(spans) > 0 && sameStyle(spans[len(spans)-1], current) {
+			spans[len(spans)-1].Text += text
+			return
+		}
+
+		span := current
+		span.Text = text
+		spans = append(spans, span)
+	}
+
+	last := 0
+	for _, loc := range tagPattern.FindAllStringIndex(markup, -1) {
+		appendText(markup[last:loc[0]])
+		last = loc[1]
+
+		tag := markup[loc[0]:loc[1]]
+
+		switch {
+		case escapedTagPattern.MatchString(tag):
+			appendText(escapedTagPattern.ReplaceAllString(tag, "[$1$2]"))
+		case colorTagPattern.MatchString(tag):
+			current = styled(current, tag)
+		}
+	}
+	appendText(markup[last:])
+
+	return spans
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func sameStyle(a, b Span) bool {
+	return a.Fore == b.Fore && a.Back == b.Back && a.Attrs == b.Attrs
+}
+
+// styled applies a "[fore:back:attrs]" color tag to the span's style. A missing part leaves
+// that part as it was, and "-" resets it to the default
+func styled(span Span, tag string) Span {
+	parts := strings.Split(strings.Trim(tag, "[]"), ":")
+
+	fields := []*string{&span.Fore, &span.Back, &span.Attrs}
+	for idx, part := range parts {
+		switch part {
+		case "":
+		case "-":
+			*fields[idx] = ""
+		default:
+			*fields[idx] = part
+		}
+	}
+
+	return span
+}
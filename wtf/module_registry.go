@@ -0,0 +1,148 @@
+package wtf
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rivo/tview"
+)
+
+// SettingType identifies the kind of value a module expects for one of its config settings
+type SettingType string
+
+const (
+	AnySetting    SettingType = "any"
+	BoolSetting   SettingType = "bool"
+	IntSetting    SettingType = "int"
+	ListSetting   SettingType = "list"
+	MapSetting    SettingType = "map"
+	StringSetting SettingType = "string"
+)
+
+// ConfigSchema maps each setting a module reads from "wtf.mods.<key>" to the type of value it
+// expects. Nested settings use their dotted path, i.e.: "colors.rows.even". A MapSetting
+// accepts any keys beneath it
+type ConfigSchema map[string]SettingType
+
+// CommonSettings are the settings every module understands, regardless of its type
+var CommonSettings = ConfigSchema{
+	"actions":                      ListSetting,
+	"cache.enabled":                BoolSetting,
+	"colors.background":            StringSetting,
+	"colors.rows.even":             StringSetting,
+	"colors.rows.odd":              StringSetting,
+	"colors.text":                  StringSetting,
+	"colors.title":                 StringSetting,
+	"enabled":                      BoolSetting,
+	"focusChar":                    IntSetting,
+	"http.caBundle":                StringSetting,
+	"http.clientCertificate":       StringSetting,
+	"http.clientKey":               StringSetting,
+	"http.proxy":                   StringSetting,
+	"http.rateLimit":               AnySetting,
+	"http.timeout":                 IntSetting,
+	"http.userAgent":               StringSetting,
+	"http.verifyServerCertificate": BoolSetting,
+	"keys":                         MapSetting,
+	"network":                      BoolSetting,
+	"notifications.enabled":        BoolSetting,
+	"notifications.sinks":          ListSetting,
+	"position.height":              IntSetting,
+	"position.left":                IntSetting,
+	"position.top":                 IntSetting,
+	"position.width":               IntSetting,
+	"refreshInterval":              IntSetting,
+	"title":                        StringSetting,
+	"type":                         StringSetting,
+	"verifyServerCertificate":      BoolSetting,
+}
+
+// WidgetFactory creates a new widget for the module instance configured under "wtf.mods.<configKey>"
+type WidgetFactory func(app *tview.Application, pages *tview.Pages, configKey string) Wtfable
+
+// Module describes a type of module that can be placed on the dashboard. Modules register
+// themselves with RegisterModule from an init() function
+type Module struct {
+	Name     string
+	Factory  WidgetFactory
+	Settings ConfigSchema
+
+	// Keys are the module's keyboard commands, which its help is generated from
+	Keys []KeyBinding
+
+	// HelpText is shown in the module's help above its keyboard commands, or on its own for
+	// modules that don't have any
+	HelpText string
+
+	// Network is true if the module fetches its data over the network, so that its widgets
+	// are paused while the network is down
+	Network bool
+}
+
+var (
+	modules   = map[string]Module{}
+	modulesMu sync.RWMutex
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// RegisterModule makes a module available to the config under its name. It panics if the
+// module has no name or factory, or if a module with the same name is already registered
+func RegisterModule(module Module) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+
+	if module.Name == "" {
+		panic("wtf: RegisterModule called without a module name")
+	}
+
+	if module.Factory == nil {
+		panic(fmt.Sprintf("wtf: RegisterModule called without a factory for '%s'", module.Name))
+	}
+
+	if _, dup := modules[module.Name]; dup {
+		panic(fmt.Sprintf("wtf: RegisterModule called twice for '%s'", module.Name))
+	}
+
+	modules[module.Name] = module
+}
+
+// ModuleFor returns the registered module with the given name, and whether or not it was found
+func ModuleFor(name string) (Module, bool) {
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	module, ok := modules[name]
+	return module, ok
+}
+
+// ModuleNames returns the names of all the registered modules, in alphabetical order
+func ModuleNames() []string {
+	modulesMu.RLock()
+	defer modulesMu.RUnlock()
+
+	names := []string{}
+	for name := range modules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Schema returns every setting the module understands, its own plus the common ones
+func (module Module) Schema() ConfigSchema {
+	schema := ConfigSchema{}
+
+	for setting, settingType := range CommonSettings {
+		schema[setting] = settingType
+	}
+
+	for setting, settingType := range module.Settings {
+		schema[setting] = settingType
+	}
+
+	return schema
+}
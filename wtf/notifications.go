@@ -0,0 +1,299 @@
+package wtf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus"
+	"github.com/rivo/tview"
+)
+
+const (
+	// flashCount is how many times a border is flashed
+	flashCount = 3
+
+	// flashInterval is how long a flashed border stays in each color
+	flashInterval = 300 * time.Millisecond
+)
+
+// Notification is something a module wants to bring to the user's attention, i.e.: a new
+// PagerDuty incident or a failed build
+type Notification struct {
+	// ID identifies what the notification is about, so that it isn't repeated every time the
+	// module refreshes. It defaults to the title and message
+	ID      string
+	Title   string
+	Message string
+
+	key    string
+	source interface{}
+}
+
+// notificationSink delivers a notification in one particular way
+type notificationSink func(notification Notification) error
+
+// notificationSinks are the ways a notification can be delivered, by the name they're
+// listed under in "wtf.notifications.sinks"
+var notificationSinks = map[string]notificationSink{
+	"bell":    notifyBell,
+	"command": notifyCommand,
+	"desktop": notifyDesktop,
+	"flash":   notifyFlash,
+}
+
+// quietSinks are the sinks that are still used during quiet hours
+var quietSinks = map[string]bool{
+	"flash": true,
+}
+
+// notificationHistory is what a widget's previous refresh had to notify about, by ID, so that
+// only what's new on each refresh is raised
+type notificationHistory struct {
+	ids    map[string]bool
+	loaded bool
+	mu     sync.Mutex
+}
+
+// notified records when each notification was last raised, by ID, so that repeats can be
+// dropped. Entries are pruned once they're older than the dedupe window
+var (
+	notified   = map[string]time.Time{}
+	notifiedMu sync.Mutex
+)
+
+// NotificationLog is where notifications that couldn't be delivered are reported, if set
+var NotificationLog func(msg string)
+
+// flasher is implemented by widgets that can flash their border
+type flasher interface {
+	Flash()
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Notify delivers the notification through each of the sinks listed in
+// "wtf.notifications.sinks", or the module's own "notifications.sinks":
+//
+//	bell     rings the terminal bell
+//	command  runs "wtf.notifications.command", with the notification in WTF_NOTIFY_MODULE,
+//	         WTF_NOTIFY_TITLE and WTF_NOTIFY_MESSAGE
+//	desktop  sends a desktop notification over D-Bus, as notify-send does
+//	flash    flashes the border of the widget that raised it
+//
+// A notification isn't delivered again while it keeps being raised within
+// "wtf.notifications.dedupe" seconds of the last time. During "wtf.notifications.quietHours",
+// i.e.: "22:00-07:00", only the border is flashed
+func Notify(key string, source interface{}, notification Notification) {
+	notification.key = key
+	notification.source = source
+	notification.ID = notification.id()
+
+	if !Config.UBool(ConfigKeyFor(key, "notifications.enabled"), true) {
+		return
+	}
+
+	if !firstNotification(key+"\n"+notification.ID, time.Now()) {
+		return
+	}
+
+	quiet := inQuietHours(Config.UString("wtf.notifications.quietHours", ""), time.Now())
+
+	for _, name := range notificationSinkNames(key) {
+		sink, ok := notificationSinks[name]
+		if !ok || (quiet && !quietSinks[name]) {
+			continue
+		}
+
+		go func(name string, sink notificationSink) {
+			if err := sink(notification); err != nil && NotificationLog != nil {
+				NotificationLog(fmt.Sprintf("could not send a notification through %s: %v", name, err))
+			}
+		}(name, sink)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// id returns the notification's ID, or its title and message if it doesn't have one
+func (notification Notification) id() string {
+	if notification.ID == "" {
+		return notification.Title + "\n" + notification.Message
+	}
+
+	return notification.ID
+}
+
+// changes returns the notifications that the previous call didn't have, and remembers these
+// ones in its place. The first call returns none, so that whatever was already the case when
+// the widget was created isn't announced
+func (history *notificationHistory) changes(notifications []Notification) []Notification {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	changes := []Notification{}
+	ids := map[string]bool{}
+
+	for _, notification := range notifications {
+		id := notification.id()
+		if history.loaded && !history.ids[id] && !ids[id] {
+			changes = append(changes, notification)
+		}
+
+		ids[id] = true
+	}
+
+	history.ids = ids
+	history.loaded = true
+
+	return changes
+}
+
+// notifyChanges raises each of the notifications the history doesn't have from the previous
+// refresh
+func notifyChanges(key string, source interface{}, history *notificationHistory, notifications []Notification) {
+	for _, notification := range history.changes(notifications) {
+		Notify(key, source, notification)
+	}
+}
+
+// firstNotification returns true if the notification hasn't been raised within the dedupe
+// window, and records that it has been raised now
+func firstNotification(id string, now time.Time) bool {
+	window := time.Duration(Config.UInt("wtf.notifications.dedupe", 3600)) * time.Second
+
+	notifiedMu.Lock()
+	defer notifiedMu.Unlock()
+
+	for other, last := range notified {
+		if now.Sub(last) >= window {
+			delete(notified, other)
+		}
+	}
+
+	_, seen := notified[id]
+	notified[id] = now
+
+	return !seen
+}
+
+// flashBorder flashes the view's border a few times, then sets it back to the color it should
+// be by then
+func flashBorder(app *tview.Application, view *tview.TextView, borderColor func() string) {
+	if app == nil {
+		return
+	}
+
+	flashColor := ColorFor(Config.UString("wtf.colors.border.flash", "yellow"))
+
+	for i := 0; i < flashCount; i++ {
+		app.QueueUpdateDraw(func() {
+			view.SetBorderColor(flashColor)
+		})
+		time.Sleep(flashInterval)
+
+		app.QueueUpdateDraw(func() {
+			if view.HasFocus() {
+				view.SetBorderColor(ColorFor(Config.UString("wtf.colors.border.focused", "gray")))
+			} else {
+				view.SetBorderColor(ColorFor(borderColor()))
+			}
+		})
+		time.Sleep(flashInterval)
+	}
+}
+
+// inQuietHours returns true if the time falls within the "15:04-15:04" range, which may run
+// over midnight
+func inQuietHours(hours string, now time.Time) bool {
+	parts := strings.Split(hours, "-")
+	if len(parts) != 2 {
+		return false
+	}
+
+	start, err := time.Parse("15:04", strings.TrimSpace(parts[0]))
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse("15:04", strings.TrimSpace(parts[1]))
+	if err != nil {
+		return false
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+
+	return minute >= startMinute || minute < endMinute
+}
+
+func notificationSinkNames(key string) []string {
+	sinks := Config.UList(ConfigKeyFor(key, "notifications.sinks"), Config.UList("wtf.notifications.sinks"))
+	return ToStrs(sinks)
+}
+
+/* -------------------- Sinks -------------------- */
+
+func notifyBell(notification Notification) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	_, err = tty.Write([]byte("\a"))
+	return err
+}
+
+func notifyCommand(notification Notification) error {
+	command := Config.UString("wtf.notifications.command", "")
+	if command == "" {
+		return errors.New("wtf.notifications.command is not set")
+	}
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(
+		os.Environ(),
+		"WTF_NOTIFY_MODULE="+notification.key,
+		"WTF_NOTIFY_TITLE="+notification.Title,
+		"WTF_NOTIFY_MESSAGE="+notification.Message,
+	)
+
+	_, err := commandOutput(cmd)
+	return err
+}
+
+// notifyDesktop sends the notification to the freedesktop notification server, the way
+// notify-send does
+func notifyDesktop(notification Notification) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return err
+	}
+
+	obj := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	call := obj.Call(
+		"org.freedesktop.Notifications.Notify", 0,
+		"wtf", uint32(0), "", notification.Title, notification.Message,
+		[]string{}, map[string]dbus.Variant{}, int32(-1),
+	)
+
+	return call.Err
+}
+
+func notifyFlash(notification Notification) error {
+	if widget, ok := notification.source.(flasher); ok {
+		widget.Flash()
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package wtf
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+func Test_Notify(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  notifications:\n    sinks: [test, flash]\n    dedupe: 60\n  mods:\n    quiet:\n      notifications:\n        enabled: false\n")
+
+	delivered := make(chan Notification, 10)
+	notificationSinks["test"] = func(notification Notification) error {
+		delivered <- notification
+		return nil
+	}
+	defer delete(notificationSinks, "test")
+
+	Notify("builds", nil, Notification{ID: "42", Title: "Build failed"})
+	Notify("builds", nil, Notification{ID: "42", Title: "Build failed again"})
+	Notify("quiet", nil, Notification{ID: "42", Title: "Build failed"})
+
+	select {
+	case notification := <-delivered:
+		if notification.Title != "Build failed" || notification.key != "builds" {
+			t.Errorf("expected: %v, got: %v", "Build failed", notification.Title)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected a notification")
+	}
+
+	select {
+	case notification := <-delivered:
+		t.Errorf("expected: no more notifications, got: %v", notification.Title)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func Test_FirstNotification(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  notifications:\n    dedupe: 60\n")
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"first", now, true},
+		{"repeated", now.Add(30 * time.Second), false},
+		{"still raised", now.Add(80 * time.Second), false},
+		{"raised again later", now.Add(200 * time.Second), true},
+	}
+
+	for _, tt := range tests {
+		actual := firstNotification("Test_FirstNotification", tt.at)
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func Test_NotificationHistory(t *testing.T) {
+	history := &notificationHistory{}
+
+	tests := []struct {
+		name     string
+		ids      []string
+		expected []string
+	}{
+		{"first load", []string{"1", "2"}, []string{}},
+		{"unchanged", []string{"1", "2"}, []string{}},
+		{"new", []string{"1", "2", "3"}, []string{"3"}},
+		{"resolved", []string{"3"}, []string{}},
+		{"raised again", []string{"1", "3"}, []string{"1"}},
+	}
+
+	for _, tt := range tests {
+		notifications := []Notification{}
+		for _, id := range tt.ids {
+			notifications = append(notifications, Notification{ID: id})
+		}
+
+		actual := []string{}
+		for _, notification := range history.changes(notifications) {
+			actual = append(actual, notification.ID)
+		}
+
+		if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: expected: %v, got: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func Test_FirstNotificationPrunes(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  notifications:\n    dedupe: 60\n")
+
+	now := time.Now()
+	firstNotification("Test_FirstNotificationPrunes old", now)
+	firstNotification("Test_FirstNotificationPrunes new", now.Add(90*time.Second))
+
+	if _, ok := notified["Test_FirstNotificationPrunes old"]; ok {
+		t.Errorf("expected: the old notification to be pruned")
+	}
+}
+
+func Test_InQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2018, 8, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name     string
+		hours    string
+		at       time.Time
+		expected bool
+	}{
+		{"not set", "", at(23, 0), false},
+		{"invalid", "late-early", at(23, 0), false},
+		{"within", "12:00-13:30", at(13, 0), true},
+		{"after", "12:00-13:30", at(13, 30), false},
+		{"over midnight, before", "22:00-07:00", at(23, 15), true},
+		{"over midnight, after", "22:00-07:00", at(6, 59), true},
+		{"over midnight, outside", "22:00-07:00", at(12, 0), false},
+	}
+
+	for _, tt := range tests {
+		actual := inQuietHours(tt.hours, tt.at)
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package wtf
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+const (
+	palettePage = "palette"
+
+	paletteWidth  = 70
+	paletteHeight = 20
+
+	// paletteHistorySize is how many of the most recently run commands are remembered
+	paletteHistorySize = 20
+)
+
+// PaletteCommand is something the command palette can do, i.e.: focus a widget or run one of
+// its commands
+type PaletteCommand struct {
+	// Name is what the command is searched by, i.e.: "git: pull"
+	Name string
+
+	// Help describes the command next to its name
+	Help string
+
+	Run func()
+}
+
+// Palette is a modal that fuzzy-searches commands by name and runs the chosen one. Commands
+// that were run recently are listed first, and the history is kept in a file so that it
+// outlasts wtf
+type Palette struct {
+	app         *tview.Application
+	commands    []PaletteCommand
+	history     []string
+	historyPath string
+	input       *tview.InputField
+	list        *tview.List
+	matches     []PaletteCommand
+	pages       *tview.Pages
+	returnTo    tview.Primitive
+	visible     bool
+}
+
+// NewPalette creates a palette that shows itself on the given pages, and keeps its history in
+// the file at historyPath. An empty historyPath keeps the history in memory only
+func NewPalette(app *tview.Application, pages *tview.Pages, historyPath string) *Palette {
+	palette := Palette{
+		app:         app,
+		history:     loadPaletteHistory(historyPath),
+		historyPath: historyPath,
+		pages:       pages,
+	}
+
+	return &palette
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Close hides the palette, if it's shown, and gives focus back to whatever had it before
+func (palette *Palette) Close() {
+	if !palette.visible {
+		return
+	}
+
+	palette.visible = false
+	palette.pages.RemovePage(palettePage)
+
+	if palette.returnTo != nil {
+		palette.app.SetFocus(palette.returnTo)
+	}
+}
+
+// Show opens the palette with the given commands to choose from
+func (palette *Palette) Show(commands []PaletteCommand) {
+	palette.commands = commands
+	palette.returnTo = palette.app.GetFocus()
+	palette.visible = true
+
+	modal := palette.build()
+	palette.filter("")
+
+	palette.pages.AddPage(palettePage, modal, false, true)
+	palette.app.SetFocus(palette.input)
+}
+
+// Visible returns true if the palette is open
+func (palette *Palette) Visible() bool {
+	return palette.visible
+}
+
+// FuzzyMatch returns true if every character of the query appears in the text, in order, and
+// a score of how well they match. Matches at the start of words, and runs of consecutive
+// characters, score higher. Matching ignores case
+func FuzzyMatch(query string, text string) (int, bool) {
+	needle := lowerRunes(strings.TrimSpace(query))
+	if len(needle) == 0 {
+		return 0, true
+	}
+
+	// Lowering rune by rune keeps the indexes of text and lower the same, which lowering the
+	// whole string doesn't when a rune's lower case is a different length, i.e.: "İ"
+	runes := []rune(text)
+	lower := lowerRunes(text)
+
+	score := 0
+	pos := 0
+
+	for _, ch := range needle {
+		if ch == ' ' {
+			continue
+		}
+
+		idx := pos
+		for idx < len(lower) && lower[idx] != ch {
+			idx++
+		}
+		if idx == len(lower) {
+			return 0, false
+		}
+
+		score++
+
+		switch {
+		case idx == pos && pos > 0:
+			// Straight after the previous match, which ends at pos
+			score += 5
+		case isWordStart(runes, idx):
+			score += 8
+		default:
+			score -= idx - pos
+		}
+
+		pos = idx + 1
+	}
+
+	if idx := strings.Index(string(lower), string(needle)); idx >= 0 {
+		score += 10
+		if idx == 0 {
+			score += 10
+		}
+	}
+
+	return score, true
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (palette *Palette) build() tview.Primitive {
+	palette.input = tview.NewInputField()
+	palette.input.SetLabel(": ")
+	palette.input.SetPlaceholder("Search widgets and commands")
+	palette.input.SetChangedFunc(palette.filter)
+	palette.input.SetInputCapture(palette.keyboardIntercept)
+
+	palette.list = tview.NewList()
+	palette.list.ShowSecondaryText(false)
+
+	return newPickerModal(palette.input, palette.list, paletteWidth, paletteHeight)
+}
+
+// filter lists the commands that match the query, best first
+func (palette *Palette) filter(query string) {
+	palette.matches = palette.rank(query)
+
+	nameWidth := 0
+	for _, command := range palette.matches {
+		if width := utf8.RuneCountInString(command.Name); width > nameWidth {
+			nameWidth = width
+		}
+	}
+
+	palette.list.Clear()
+	for _, command := range palette.matches {
+		text := fmt.Sprintf("%-*s  %s", nameWidth, command.Name, command.Help)
+		palette.list.AddItem(escapeTags(text), "", 0, nil)
+	}
+}
+
+func (palette *Palette) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc:
+		palette.Close()
+		return nil
+	case tcell.KeyEnter:
+		palette.run(palette.list.GetCurrentItem())
+		return nil
+	}
+
+	if moveListSelection(palette.list, event) {
+		return nil
+	}
+
+	return event
+}
+
+// rank returns the commands that match the query, best first. With no query, the most recently
+// run commands come first and the rest keep their order
+func (palette *Palette) rank(query string) []PaletteCommand {
+	recency := map[string]int{}
+	for idx, name := range palette.history {
+		recency[name] = paletteHistorySize - idx
+	}
+
+	type match struct {
+		command PaletteCommand
+		score   int
+	}
+
+	matches := []match{}
+
+	for _, command := range palette.commands {
+		score, ok := FuzzyMatch(query, command.Name)
+		if !ok {
+			// The help counts too, but for less than the name
+			if score, ok = FuzzyMatch(query, command.Name+" "+command.Help); !ok {
+				continue
+			}
+			score -= 10
+		}
+
+		if query == "" {
+			score = 0
+		}
+
+		matches = append(matches, match{command: command, score: score + recency[command.Name]})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	commands := []PaletteCommand{}
+	for _, match := range matches {
+		commands = append(commands, match.command)
+	}
+
+	return commands
+}
+
+// record moves the command to the top of the history, and saves it
+func (palette *Palette) record(name string) {
+	history := []string{name}
+
+	for _, prev := range palette.history {
+		if prev != name && len(history) < paletteHistorySize {
+			history = append(history, prev)
+		}
+	}
+
+	palette.history = history
+
+	// The history is a convenience, so failing to save it isn't worth interrupting for
+	savePaletteHistory(palette.historyPath, history)
+}
+
+func (palette *Palette) run(idx int) {
+	if idx < 0 || idx >= len(palette.matches) {
+		return
+	}
+
+	command := palette.matches[idx]
+
+	palette.Close()
+	palette.record(command.Name)
+
+	if command.Run != nil {
+		command.Run()
+	}
+}
+
+// isWordStart returns true if the rune at the index starts a word, either after a space or
+// punctuation, or as a capital letter after a lower-case one
+func isWordStart(runes []rune, idx int) bool {
+	if idx == 0 {
+		return true
+	}
+
+	if idx >= len(runes) {
+		return false
+	}
+
+	prev, curr := runes[idx-1], runes[idx]
+
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+
+	return unicode.IsLower(prev) && unicode.IsUpper(curr)
+}
+
+// lowerRunes returns the runes of the text in lower case, one for one
+func lowerRunes(text string) []rune {
+	runes := []rune(text)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+
+	return runes
+}
+
+func loadPaletteHistory(path string) []string {
+	history := []string{}
+
+	if path == "" {
+		return history
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return history
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" && len(history) < paletteHistorySize {
+			history = append(history, line)
+		}
+	}
+
+	return history
+}
+
+func savePaletteHistory(path string, history []string) {
+	if path == "" {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	ioutil.WriteFile(path, []byte(strings.Join(history, "\n")+"\n"), 0600)
+}
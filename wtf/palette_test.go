@@ -0,0 +1,117 @@
+package wtf
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_FuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query    string
+		text     string
+		expected bool
+	}{
+		{"", "git: pull", true},
+		{"gp", "git: pull", true},
+		{"GIT PULL", "git: pull", true},
+		{"pg", "git: pull", false},
+		{"todonew", "todo: new", true},
+		{"ra", "refresh all", true},
+	}
+
+	for _, test := range tests {
+		if _, actual := FuzzyMatch(test.query, test.text); actual != test.expected {
+			t.Errorf("%s in %s: expected: %v, got: %v", test.query, test.text, test.expected, actual)
+		}
+	}
+
+	wordStarts, _ := FuzzyMatch("ra", "refresh all")
+	scattered, _ := FuzzyMatch("ra", "prev board")
+	if wordStarts <= scattered {
+		t.Errorf("expected: word starts to score higher, got: %d and %d", wordStarts, scattered)
+	}
+
+	for _, pair := range [][2]string{{"ré", "xréy"}, {"ér", "xéry"}} {
+		wide, _ := FuzzyMatch(pair[0], pair[1])
+		narrow, _ := FuzzyMatch(strings.Replace(pair[0], "é", "e", -1), strings.Replace(pair[1], "é", "e", -1))
+
+		if wide != narrow {
+			t.Errorf("%s in %s: expected: %v, got: %v", pair[0], pair[1], narrow, wide)
+		}
+	}
+
+	// Their lower cases are shorter or longer than they are, so they'd throw off byte offsets
+	for _, pair := range [][3]string{
+		{"ist", "İstanbul: open", "Istanbul: open"},
+		{"so", "Go: İstanbul Open", "Go: Istanbul Open"},
+		{"ßa", "STRAẞE ABC", "STRAXE ABC"},
+	} {
+		query := pair[0]
+		actual, ok := FuzzyMatch(query, pair[1])
+		if !ok {
+			t.Errorf("%s in %s: expected: a match, got: none", query, pair[1])
+		}
+
+		expected, _ := FuzzyMatch(strings.Replace(query, "ß", "x", -1), pair[2])
+		if actual != expected {
+			t.Errorf("%s in %s: expected: %v, got: %v", query, pair[1], expected, actual)
+		}
+	}
+}
+
+func Test_PaletteRank(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-palette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	historyPath := filepath.Join(dir, "palette_history")
+
+	palette := NewPalette(nil, nil, historyPath)
+	palette.commands = []PaletteCommand{
+		{Name: "refresh all", Help: "Refresh every widget"},
+		{Name: "git: pull", Help: "Pull the current git repository"},
+		{Name: "todo: new", Help: "Create a new list item"},
+		{Name: "todo: prev", Help: "Select the previous item in the list"},
+	}
+
+	names := func(commands []PaletteCommand) []string {
+		result := []string{}
+		for _, command := range commands {
+			result = append(result, command.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{"refresh all", "git: pull", "todo: new", "todo: prev"}},
+		{"todo", []string{"todo: new", "todo: prev"}},
+		{"repository", []string{"git: pull"}},
+		{"tp", []string{"todo: prev", "git: pull"}},
+	}
+
+	for _, test := range tests {
+		if actual := names(palette.rank(test.query)); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%q: expected: %v, got: %v", test.query, test.expected, actual)
+		}
+	}
+
+	palette.record("todo: new")
+	palette.record("git: pull")
+
+	reloaded := NewPalette(nil, nil, historyPath)
+	reloaded.commands = palette.commands
+
+	expected := []string{"git: pull", "todo: new", "refresh all", "todo: prev"}
+	if actual := names(reloaded.rank("")); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("history: expected: %v, got: %v", expected, actual)
+	}
+}
@@ -0,0 +1,50 @@
+package wtf
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// newPickerModal creates the modal the command palette and search share: a field to type into,
+// above a list of what matches it
+func newPickerModal(input *tview.InputField, list *tview.List, width int, height int) *tview.Frame {
+	layout := tview.NewFlex().SetDirection(tview.FlexRow)
+	layout.AddItem(input, 1, 0, true)
+	layout.AddItem(list, 0, 1, false)
+
+	frame := tview.NewFrame(layout)
+	frame.SetRect(offscreen, offscreen, width, height)
+
+	drawFunc := func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		w, h := screen.Size()
+		frame.SetRect((w/2)-(width/2), (h/2)-(height/2), width, height)
+		return x, y, width, height
+	}
+
+	frame.SetBorder(true)
+	frame.SetBorders(1, 1, 0, 0, 1, 1)
+	frame.SetDrawFunc(drawFunc)
+
+	return frame
+}
+
+// moveListSelection moves the list's selection for the keys that move it while the field has
+// focus, and returns false for every other key. Tab is swallowed so that it can't move focus
+// out of the modal
+func moveListSelection(list *tview.List, event *tcell.EventKey) bool {
+	noFocus := func(tview.Primitive) {}
+
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+		list.InputHandler()(event, noFocus)
+	case tcell.KeyCtrlP:
+		list.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), noFocus)
+	case tcell.KeyCtrlN:
+		list.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), noFocus)
+	case tcell.KeyTab, tcell.KeyBacktab:
+	default:
+		return false
+	}
+
+	return true
+}
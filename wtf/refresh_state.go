@@ -0,0 +1,202 @@
+package wtf
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// refreshState records the outcome of a widget's most recent refresh, so that a failing widget
+// can show its error while keeping its last good content on screen. It also records whether
+// the widget is on screen at all, which decides how often it's refreshed, and whether what it
+// shows came from the cache rather than a refresh
+type refreshState struct {
+	mu sync.Mutex
+
+	cachedAt  time.Time
+	content   string
+	err       error
+	failedAt  time.Time
+	hidden    bool
+	model     *Content
+	refreshed time.Time
+	succeeded bool
+}
+
+// errorSanitizer strips the characters that would break an error message out of the one
+// line it's drawn on, or be mistaken for tview color tags
+var errorSanitizer = strings.NewReplacer("\n", " ", "\r", "", "[", "(", "]", ")")
+
+/* -------------------- Unexported Functions -------------------- */
+
+// crashed returns true if the widget's most recent refresh panicked
+func (state *refreshState) crashed() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	_, ok := state.err.(*CrashError)
+	return ok
+}
+
+// drawError is a tview draw function that writes the last error and the time it happened
+// onto the right of the widget's title bar. Content from the cache is marked with its age
+// instead, until there's an error to show
+func (state *refreshState) drawError(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	err, failedAt := state.lastError()
+	cachedAt := state.cachedSince()
+	maxWidth := width / 2
+
+	switch {
+	case width <= 6:
+	case err != nil:
+		msg := fmt.Sprintf(" ✗ %s %s ", failedAt.Format("15:04"), errorSanitizer.Replace(err.Error()))
+		tview.Print(screen, msg, x+width-1-maxWidth, y, maxWidth, tview.AlignRight, ColorFor(errorColor()))
+	case !cachedAt.IsZero():
+		msg := fmt.Sprintf(" cached %s ago ", cacheAge(time.Since(cachedAt)))
+		tview.Print(screen, msg, x+width-1-maxWidth, y, maxWidth, tview.AlignRight, ColorFor(staleColor()))
+	}
+
+	return x + 1, y + 1, width - 2, height - 2
+}
+
+// cachedSince returns when the content on screen was saved to the cache, or the zero time if it
+// came from a refresh
+func (state *refreshState) cachedSince() time.Time {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.cachedAt
+}
+
+func (state *refreshState) failing() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.err != nil
+}
+
+// lastContent returns the content the widget most recently displayed
+func (state *refreshState) lastContent() string {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.content
+}
+
+// lastRefreshed returns when the widget last finished refreshing, whether or not it succeeded
+func (state *refreshState) lastRefreshed() time.Time {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.refreshed
+}
+
+// lastModel returns the content model the widget most recently rendered, if it uses one
+func (state *refreshState) lastModel() *Content {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.model
+}
+
+// hasSucceeded returns true if at least one refresh has ever succeeded
+func (state *refreshState) hasSucceeded() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.succeeded
+}
+
+// hasContent returns true if there's content worth keeping on screen when a refresh fails,
+// from either a successful refresh or the cache
+func (state *refreshState) hasContent() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.succeeded || !state.cachedAt.IsZero()
+}
+
+func (state *refreshState) isHidden() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.hidden
+}
+
+func (state *refreshState) lastError() (error, time.Time) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.err, state.failedAt
+}
+
+func (state *refreshState) set(err error) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.err = err
+	state.refreshed = time.Now()
+
+	if err == nil {
+		state.cachedAt = time.Time{}
+		state.succeeded = true
+	} else {
+		state.failedAt = time.Now()
+	}
+}
+
+func (state *refreshState) setCached(savedAt time.Time) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.cachedAt = savedAt
+}
+
+func (state *refreshState) setContent(content string) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.content = content
+}
+
+func (state *refreshState) setModel(model *Content) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.model = model
+}
+
+func (state *refreshState) setHidden(hidden bool) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.hidden = hidden
+}
+
+// crashText is displayed in place of a widget's content once it has crashed, since whatever it
+// was showing can no longer be trusted. It names the keys bound to retryCrashed, if any are
+func crashText(crash *CrashError) string {
+	text := fmt.Sprintf(
+		"\n [%s]This widget crashed while refreshing:[white]\n\n %s\n\n The stack trace has been written to the log.",
+		errorColor(),
+		errorSanitizer.Replace(fmt.Sprintf("%v", crash.Value)),
+	)
+
+	if keys := appKeysFor("retryCrashed"); len(keys) > 0 {
+		text = text + fmt.Sprintf("\n Press %s to retry.", tview.Escape(strings.Join(keys, " or ")))
+	}
+
+	return text
+}
+
+func errorColor() string {
+	return Config.UString("wtf.colors.border.error", "red")
+}
+
+func staleColor() string {
+	return Config.UString("wtf.colors.border.stale", "yellow")
+}
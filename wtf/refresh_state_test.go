@@ -0,0 +1,67 @@
+package wtf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+)
+
+func Test_RefreshState(t *testing.T) {
+	state := &refreshState{}
+
+	if state.failing() || state.hasSucceeded() {
+		t.Fatalf("Expected a new state to be neither failing nor succeeded")
+	}
+
+	state.set(errors.New("boom"))
+
+	err, failedAt := state.lastError()
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("Expected boom but got %v", err)
+	}
+
+	if failedAt.IsZero() {
+		t.Fatalf("Expected the failure time to be recorded")
+	}
+
+	if state.hasSucceeded() {
+		t.Fatalf("Expected no success before the first good refresh")
+	}
+
+	state.set(nil)
+
+	if state.failing() {
+		t.Fatalf("Expected a successful refresh to clear the error")
+	}
+
+	if !state.hasSucceeded() {
+		t.Fatalf("Expected a successful refresh to be recorded")
+	}
+}
+
+func Test_CrashText(t *testing.T) {
+	tests := []struct {
+		yaml     string
+		expected string
+	}{
+		{yaml: "wtf:\n  colors: {}\n", expected: "Press ctrl-t to retry."},
+		{yaml: "wtf:\n  keys:\n    retryCrashed: [f5, ctrl-x r]\n", expected: "Press f5 or ctrl-x r to retry."},
+		{yaml: "wtf:\n  keys:\n    retryCrashed: []\n", expected: ""},
+	}
+
+	for _, test := range tests {
+		Config, _ = config.ParseYaml(test.yaml)
+
+		actual := ""
+		text := crashText(&CrashError{Value: "boom"})
+		if idx := strings.Index(text, "Press "); idx >= 0 {
+			actual = text[idx:]
+		}
+
+		if actual != test.expected {
+			t.Errorf("crashText %q: expected: %v, got: %v", test.yaml, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package wtf
+
+import (
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// refreshable is what TextWidget and BarGraph share for being refreshed by the scheduler and
+// keeping track of what they display
+type refreshable struct {
+	app             *tview.Application
+	key             string
+	notifications   *notificationHistory
+	refreshRequests chan struct{}
+	refreshState    *refreshState
+	view            *tview.TextView
+}
+
+func newRefreshable(app *tview.Application, configKey string) refreshable {
+	return refreshable{
+		app:             app,
+		key:             configKey,
+		notifications:   &notificationHistory{},
+		refreshRequests: make(chan struct{}, 1),
+		refreshState:    &refreshState{},
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Crashed returns true if the widget's most recent refresh panicked
+func (widget *refreshable) Crashed() bool {
+	return widget.refreshState.crashed()
+}
+
+// Content returns the content the widget most recently displayed, color tags and all
+func (widget *refreshable) Content() string {
+	return widget.refreshState.lastContent()
+}
+
+// Hidden returns true if the widget is on a board that isn't being shown
+func (widget *refreshable) Hidden() bool {
+	return widget.refreshState.isHidden()
+}
+
+func (widget *refreshable) Key() string {
+	return widget.key
+}
+
+// LastRefreshError returns the error from the widget's most recent refresh if it failed, and
+// when it failed
+func (widget *refreshable) LastRefreshError() (error, time.Time) {
+	return widget.refreshState.lastError()
+}
+
+// LastRefreshed returns when the widget last finished refreshing, or the zero time if it
+// never has
+func (widget *refreshable) LastRefreshed() time.Time {
+	return widget.refreshState.lastRefreshed()
+}
+
+// Model returns the content model the widget most recently rendered, or nil if it builds its
+// markup itself
+func (widget *refreshable) Model() *Content {
+	return widget.refreshState.lastModel()
+}
+
+// RefreshRequests delivers a value whenever a refresh of the widget has been requested
+func (widget *refreshable) RefreshRequests() <-chan struct{} {
+	return widget.refreshRequests
+}
+
+// Render displays the content model as tview markup, and keeps it for anything that wants the
+// content without the markup. Paged content is shown under the sigils for its page
+func (widget *refreshable) Render(content *Content) {
+	markup := RenderMarkup(content, widget.key, widget.view.HasFocus())
+	if content.Pages > 0 {
+		markup = SigilStr(content.Pages, content.Page, widget.view) + "\n" + markup
+	}
+
+	widget.refreshState.setModel(content)
+	widget.SetContent(markup)
+}
+
+// RequestRefresh asks the scheduler to refresh the widget as soon as possible. It never
+// blocks, and requests made while one is already pending are dropped
+func (widget *refreshable) RequestRefresh() {
+	select {
+	case widget.refreshRequests <- struct{}{}:
+	default:
+	}
+}
+
+// SetContent displays the content in the widget's view, and keeps a copy of it for anything
+// that isn't drawn to the screen, i.e.: 'wtf --once'
+func (widget *refreshable) SetContent(content string) {
+	widget.refreshState.setContent(content)
+	widget.view.SetText(content)
+}
+
+func (widget *refreshable) SetHidden(hidden bool) {
+	widget.refreshState.setHidden(hidden)
+}
+
+// ShowCached displays content saved by an earlier run, marked with its age, until the widget's
+// first successful refresh replaces it
+func (widget *refreshable) ShowCached(cached *CachedContent) {
+	widget.refreshState.setCached(cached.SavedAt)
+
+	if cached.Model != nil {
+		cached.Model.Selected = -1
+		widget.Render(cached.Model)
+		return
+	}
+
+	widget.SetContent(cached.Content)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// setRefreshError puts the widget into its error state, or takes it back out when err is nil.
+// The content from the last good refresh, or from the cache, stays on screen, unless there is
+// none, in which case the error is displayed instead. borderColor is the widget's BorderColor,
+// which depends on more than its refresh state
+func (widget *refreshable) setRefreshError(err error, borderColor func() string) {
+	wasFailing := widget.refreshState.failing()
+	wasCached := !widget.refreshState.cachedSince().IsZero()
+	widget.refreshState.set(err)
+
+	if err == nil && !wasFailing && !wasCached {
+		return
+	}
+
+	if crash, ok := err.(*CrashError); ok {
+		widget.view.SetWrap(true)
+		widget.view.SetText(crashText(crash))
+	} else if err != nil && !widget.refreshState.hasContent() {
+		widget.view.SetWrap(true)
+		widget.view.SetText(err.Error())
+	}
+
+	if widget.view.HasFocus() && err == nil {
+		widget.view.SetBorderColor(ColorFor(Config.UString("wtf.colors.border.focused", "gray")))
+	} else {
+		widget.view.SetBorderColor(ColorFor(borderColor()))
+	}
+
+	if widget.app != nil {
+		widget.app.Draw()
+	}
+}
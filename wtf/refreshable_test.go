@@ -0,0 +1,54 @@
+package wtf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/olebedev/config"
+)
+
+func Test_Refreshable(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    jira:\n      enabled: true\n    cpu:\n      enabled: true\n")
+
+	text := NewTextWidget(nil, "Jira", "jira", true)
+	graph := NewBarGraph(nil, "CPU", "cpu", false)
+
+	for key, widget := range map[string]*refreshable{"jira": &text.refreshable, "cpu": &graph.refreshable} {
+		if widget.Key() != key {
+			t.Errorf("Key: expected: %v, got: %v", key, widget.Key())
+		}
+
+		widget.RequestRefresh()
+		widget.RequestRefresh()
+
+		requests := 0
+	drain:
+		for {
+			select {
+			case <-widget.RefreshRequests():
+				requests++
+			default:
+				break drain
+			}
+		}
+
+		if requests != 1 {
+			t.Errorf("RequestRefresh %s: expected: %v, got: %v", key, 1, requests)
+		}
+	}
+
+	if text.ConfigKey("username") != "wtf.mods.jira.username" {
+		t.Errorf("ConfigKey: expected: %v, got: %v", "wtf.mods.jira.username", text.ConfigKey("username"))
+	}
+
+	text.SetContent("PROJ-1234")
+	text.SetRefreshError(errors.New("timed out"))
+
+	if text.Content() != "PROJ-1234" {
+		t.Errorf("SetRefreshError: expected: %v, got: %v", "PROJ-1234", text.Content())
+	}
+
+	if text.BorderColor() != errorColor() {
+		t.Errorf("BorderColor: expected: %v, got: %v", errorColor(), text.BorderColor())
+	}
+}
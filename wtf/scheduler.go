@@ -1,39 +1,310 @@
 package wtf
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
 	"time"
 )
 
-type Scheduler interface {
-	Refresh()
+const (
+	// maxBackoff caps how long a failing widget waits before its next refresh attempt
+	maxBackoff = 15 * time.Minute
+
+	// failureLogThreshold is the number of consecutive failures after which a widget's
+	// refresh errors are written to the log
+	failureLogThreshold = 2
+
+	// hiddenSlowdown is how many times longer a widget on a board that isn't being shown
+	// waits between refreshes, when "wtf.hiddenBoardRefresh" is "slow"
+	hiddenSlowdown = 5
+
+	// jitterFraction is the largest fraction of a widget's interval added at random to
+	// each wait, so widgets with the same interval don't all hit the network together
+	jitterFraction = 0.1
+)
+
+// Refresher is implemented by anything the Scheduler can refresh
+type Refresher interface {
+	Refresh(ctx context.Context) error
 	RefreshInterval() int
+	Crashed() bool
+	LastRefreshError() (error, time.Time)
+	LastRefreshed() time.Time
+	RefreshRequests() <-chan struct{}
+	RequestRefresh()
+	SetRefreshError(err error)
 }
 
-func Schedule(widget Wtfable) {
-	// Kick off the first refresh and then leave the rest to the timer
-	widget.Refresh()
+// CrashError is the error reported for a widget whose refresh panicked
+type CrashError struct {
+	Value interface{}
+	Stack []byte
+}
 
-	interval := time.Duration(widget.RefreshInterval()) * time.Second
+func (err *CrashError) Error() string {
+	return fmt.Sprintf("crashed: %v", err.Value)
+}
 
-	if interval <= 0 {
-		return
+// Scheduler owns the goroutines that refresh widgets on their intervals. Stopping it cancels
+// the context passed to every refresh and ends all of its goroutines
+type Scheduler struct {
+	cache  *Cache
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    func(msg string)
+}
+
+// NewScheduler creates a scheduler that writes repeated refresh failures to the given log
+// function. If there's a cache, each widget starts out showing the content it had when it was
+// last refreshed, and its content is saved to the cache as it refreshes
+func NewScheduler(log func(msg string), cache *Cache) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		cache:  cache,
+		ctx:    ctx,
+		cancel: cancel,
+		log:    log,
 	}
+}
 
-	tick := time.NewTicker(interval)
-	quit := make(chan struct{})
+/* -------------------- Exported Functions -------------------- */
+
+// Schedule refreshes the widget immediately and then on every refresh interval until the
+// scheduler is stopped or the widget is disabled
+func (scheduler *Scheduler) Schedule(widget Wtfable) {
+	go scheduler.run(widget)
+}
+
+// RefreshOnce refreshes the widget right away, outside of its schedule, and returns the error
+// it failed with, if any. A panic is returned as a CrashError
+func (scheduler *Scheduler) RefreshOnce(widget Wtfable) error {
+	return scheduler.refresh(widget)
+}
+
+// Stop cancels all in-flight refreshes and ends every scheduled goroutine
+func (scheduler *Scheduler) Stop() {
+	scheduler.cancel()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (scheduler *Scheduler) run(widget Wtfable) {
+	interval := time.Duration(widget.RefreshInterval()) * time.Second
+	failures := 0
+	saved := CachedContent{}
+	network := NetworkBound(widget.Key())
+
+	scheduler.restore(widget)
 
 	for {
-		select {
-		case <-tick.C:
-			if widget.Enabled() {
-				widget.Refresh()
-			} else {
-				tick.Stop()
+		if scheduler.ctx.Err() != nil || widget.Disabled() {
+			return
+		}
+
+		// While the network is down, network-bound widgets keep what they have on screen, and
+		// start over without any backoff once it's back
+		if network && Network.Offline() {
+			if !scheduler.waitForNetwork() {
 				return
 			}
-		case <-quit:
-			tick.Stop()
+
+			failures = 0
+		}
+
+		err := scheduler.refresh(widget)
+
+		// Errors caused by the scheduler being stopped mid-refresh aren't the widget's fault
+		if scheduler.ctx.Err() != nil {
+			return
+		}
+
+		widget.SetRefreshError(err)
+
+		if network {
+			scheduler.reportNetwork(err)
+		}
+
+		// A crashed widget is left alone until it's explicitly retried
+		if crash, ok := err.(*CrashError); ok {
+			scheduler.logCrash(widget, crash)
+
+			if !scheduler.wait(widget, 0) {
+				return
+			}
+
+			failures = 0
+			continue
+		}
+
+		if err != nil {
+			failures++
+			scheduler.logFailure(widget, err, failures)
+		} else {
+			failures = 0
+			saved = scheduler.save(widget, saved)
+		}
+
+		// A widget without an interval is only ever refreshed on request
+		delay := time.Duration(0)
+		if interval > 0 {
+			delay = withJitter(hiddenDelay(backoff(interval, failures), widget.Hidden()))
+		}
+
+		if !scheduler.wait(widget, delay) {
 			return
 		}
 	}
 }
+
+func (scheduler *Scheduler) logCrash(widget Wtfable, crash *CrashError) {
+	if scheduler.log == nil {
+		return
+	}
+
+	scheduler.log(fmt.Sprintf("[%s] refresh %s\n%s", widget.Key(), crash.Error(), crash.Stack))
+}
+
+func (scheduler *Scheduler) logFailure(widget Wtfable, err error, failures int) {
+	if scheduler.log == nil || failures < failureLogThreshold {
+		return
+	}
+
+	scheduler.log(fmt.Sprintf("[%s] refresh failed %d times in a row: %v", widget.Key(), failures, err))
+}
+
+// restore shows the widget's cached content, if it has any
+func (scheduler *Scheduler) restore(widget Wtfable) {
+	if scheduler.cache == nil || !cacheEnabled(widget.Key()) {
+		return
+	}
+
+	cached, err := scheduler.cache.Load(widget.Key())
+	if err != nil && scheduler.log != nil {
+		scheduler.log(fmt.Sprintf("[%s] could not read the cache: %v", widget.Key(), err))
+	}
+
+	if cached != nil && scheduler.ctx.Err() == nil {
+		widget.ShowCached(cached)
+	}
+}
+
+// save writes the widget's content to the cache, unless it's the same as what was last saved
+// or that was saved too recently, and returns what's in the cache now
+func (scheduler *Scheduler) save(widget Wtfable, last CachedContent) CachedContent {
+	if scheduler.cache == nil || !cacheEnabled(widget.Key()) {
+		return last
+	}
+
+	content := widget.Content()
+	if content == "" || content == last.Content || time.Since(last.SavedAt) < cacheSaveInterval {
+		return last
+	}
+
+	cached := CachedContent{
+		Content: content,
+		Model:   widget.Model(),
+		SavedAt: time.Now(),
+	}
+
+	if err := scheduler.cache.Save(widget.Key(), cached); err != nil {
+		if scheduler.log != nil {
+			scheduler.log(fmt.Sprintf("[%s] could not write the cache: %v", widget.Key(), err))
+		}
+		return last
+	}
+
+	return cached
+}
+
+// refresh refreshes the widget, turning a panic inside the refresh into a CrashError so that
+// the rest of the dashboard keeps running
+func (scheduler *Scheduler) refresh(widget Wtfable) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CrashError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return widget.Refresh(scheduler.ctx)
+}
+
+// reportNetwork tells the network's connectivity how a network-bound widget's refresh went
+func (scheduler *Scheduler) reportNetwork(err error) {
+	if err != nil {
+		Network.ReportFailure(err)
+	} else {
+		Network.ReportSuccess()
+	}
+}
+
+// waitForNetwork blocks until the network is back up, and returns false if the scheduler was
+// stopped in the meantime
+func (scheduler *Scheduler) waitForNetwork() bool {
+	select {
+	case <-scheduler.ctx.Done():
+		return false
+	case <-Network.Online():
+		return true
+	}
+}
+
+// wait blocks until the delay has passed or a refresh is requested, and returns false if the
+// scheduler was stopped in the meantime. A zero delay waits for a request only
+func (scheduler *Scheduler) wait(widget Wtfable, delay time.Duration) bool {
+	var next <-chan time.Time
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		next = timer.C
+	}
+
+	select {
+	case <-scheduler.ctx.Done():
+		return false
+	case <-widget.RefreshRequests():
+		return true
+	case <-next:
+		return true
+	}
+}
+
+// backoff doubles the interval for each consecutive failure, up to maxBackoff
+func backoff(interval time.Duration, failures int) time.Duration {
+	delay := interval
+
+	for i := 0; i < failures && delay < maxBackoff; i++ {
+		delay = delay * 2
+	}
+
+	if delay > maxBackoff && interval < maxBackoff {
+		return maxBackoff
+	}
+
+	return delay
+}
+
+// hiddenDelay stretches the delay of a widget that isn't on screen, or pauses it entirely by
+// returning zero
+func hiddenDelay(delay time.Duration, hidden bool) time.Duration {
+	if !hidden {
+		return delay
+	}
+
+	switch hiddenRefresh() {
+	case "pause":
+		return 0
+	case "slow":
+		return delay * hiddenSlowdown
+	default:
+		return delay
+	}
+}
+
+func withJitter(delay time.Duration) time.Duration {
+	return delay + time.Duration(rand.Float64()*jitterFraction*float64(delay))
+}
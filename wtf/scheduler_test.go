@@ -0,0 +1,114 @@
+package wtf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+func Test_Backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		failures int
+		expected time.Duration
+	}{
+		{
+			name:     "no failures",
+			interval: 30 * time.Second,
+			failures: 0,
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "one failure",
+			interval: 30 * time.Second,
+			failures: 1,
+			expected: 60 * time.Second,
+		},
+		{
+			name:     "three failures",
+			interval: 30 * time.Second,
+			failures: 3,
+			expected: 240 * time.Second,
+		},
+		{
+			name:     "capped",
+			interval: 30 * time.Second,
+			failures: 20,
+			expected: maxBackoff,
+		},
+		{
+			name:     "interval above the cap",
+			interval: time.Hour,
+			failures: 3,
+			expected: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		actual := backoff(tt.interval, tt.failures)
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func Test_WithJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		actual := withJitter(10 * time.Second)
+
+		if actual < 10*time.Second || actual > 11*time.Second {
+			t.Fatalf("Expected between 10s and 11s but got %v", actual)
+		}
+	}
+}
+
+type panickingWidget struct {
+	TextWidget
+}
+
+func (widget *panickingWidget) Refresh(ctx context.Context) error {
+	panic("boom")
+}
+
+func Test_RefreshRecoversFromPanics(t *testing.T) {
+	scheduler := NewScheduler(nil, nil)
+	widget := &panickingWidget{}
+
+	err := scheduler.refresh(widget)
+
+	crash, ok := err.(*CrashError)
+	if !ok {
+		t.Fatalf("Expected a *CrashError but got %v", err)
+	}
+
+	if crash.Value != "boom" || len(crash.Stack) == 0 {
+		t.Errorf("Expected the panic value and stack to be recorded, got: %v", crash)
+	}
+}
+
+func Test_HiddenDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		hiddenRefresh string
+		hidden        bool
+		expected      time.Duration
+	}{
+		{"visible", "pause", false, time.Minute},
+		{"hidden and normal", "normal", true, time.Minute},
+		{"hidden and slow", "slow", true, hiddenSlowdown * time.Minute},
+		{"hidden and paused", "pause", true, 0},
+	}
+
+	for _, tt := range tests {
+		Config, _ = config.ParseYaml("wtf:\n  hiddenBoardRefresh: " + tt.hiddenRefresh + "\n")
+		actual := hiddenDelay(time.Minute, tt.hidden)
+
+		if actual != tt.expected {
+			t.Errorf("%s: expected: %v, got: %v", tt.name, tt.expected, actual)
+		}
+	}
+}
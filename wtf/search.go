@@ -0,0 +1,221 @@
+package wtf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+const (
+	searchPage = "search"
+
+	searchWidth  = 90
+	searchHeight = 24
+
+	// searchMaxResults is the most matching lines listed, so that a short query over a lot of
+	// content stays quick to type
+	searchMaxResults = 500
+)
+
+// SearchResult is a line of a widget's content that matches a search
+type SearchResult struct {
+	Widget Wtfable
+
+	// Line is the index of the line in the widget's content, starting at 0
+	Line int
+	Text string
+}
+
+// Search is a modal that searches the current content of every widget as it's typed, and lists
+// the matching lines grouped by widget
+type Search struct {
+	app      *tview.Application
+	input    *tview.InputField
+	jump     func(SearchResult)
+	list     *tview.List
+	pages    *tview.Pages
+	results  []SearchResult
+	returnTo tview.Primitive
+	sources  []searchSource
+	visible  bool
+}
+
+// searchSource is a widget's content, split into lines without their colors
+type searchSource struct {
+	widget Wtfable
+	lines  []string
+}
+
+// NewSearch creates a search that shows itself on the given pages
+func NewSearch(app *tview.Application, pages *tview.Pages) *Search {
+	return &Search{
+		app:   app,
+		pages: pages,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Close hides the search, if it's shown, and gives focus back to whatever had it before
+func (search *Search) Close() {
+	if !search.visible {
+		return
+	}
+
+	search.visible = false
+	search.pages.RemovePage(searchPage)
+
+	if search.returnTo != nil {
+		search.app.SetFocus(search.returnTo)
+	}
+}
+
+// Show opens the search over the widgets' content as it is now. Choosing a result closes the
+// search and passes the result to jump
+func (search *Search) Show(widgets []Wtfable, jump func(SearchResult)) {
+	search.jump = jump
+	search.returnTo = search.app.GetFocus()
+	search.sources = searchSources(widgets)
+	search.visible = true
+
+	search.input = tview.NewInputField()
+	search.input.SetLabel("/ ")
+	search.input.SetPlaceholder("Search every widget")
+	search.input.SetChangedFunc(search.filter)
+	search.input.SetInputCapture(search.keyboardIntercept)
+
+	search.list = tview.NewList()
+	search.list.ShowSecondaryText(false)
+
+	modal := newPickerModal(search.input, search.list, searchWidth, searchHeight)
+
+	search.pages.AddPage(searchPage, modal, false, true)
+	search.app.SetFocus(search.input)
+}
+
+// Visible returns true if the search is open
+func (search *Search) Visible() bool {
+	return search.visible
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// filter lists the lines that match the query under a heading for each widget. Choosing a
+// heading jumps to the widget's first match
+func (search *Search) filter(query string) {
+	search.list.Clear()
+	search.results = []SearchResult{}
+
+	for _, group := range groupByWidget(findInSources(search.sources, query)) {
+		search.results = append(search.results, group[0])
+		search.list.AddItem(searchHeading(group[0].Widget, len(group)), "", 0, nil)
+
+		for _, result := range group {
+			line := fmt.Sprintf("  %4d  %s", result.Line+1, strings.TrimSpace(result.Text))
+
+			search.results = append(search.results, result)
+			search.list.AddItem(escapeTags(line), "", 0, nil)
+		}
+	}
+}
+
+func (search *Search) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc:
+		search.Close()
+		return nil
+	case tcell.KeyEnter:
+		search.run(search.list.GetCurrentItem())
+		return nil
+	}
+
+	if moveListSelection(search.list, event) {
+		return nil
+	}
+
+	return event
+}
+
+func (search *Search) run(idx int) {
+	if idx < 0 || idx >= len(search.results) {
+		return
+	}
+
+	result := search.results[idx]
+
+	search.Close()
+
+	if search.jump != nil {
+		search.jump(result)
+	}
+}
+
+// findInSources returns every line that contains the query, ignoring case, in the order of the
+// widgets and then of their lines
+func findInSources(sources []searchSource, query string) []SearchResult {
+	results := []SearchResult{}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return results
+	}
+
+	for _, source := range sources {
+		for idx, line := range source.lines {
+			if len(results) >= searchMaxResults {
+				return results
+			}
+
+			if strings.Contains(strings.ToLower(line), query) {
+				results = append(results, SearchResult{Widget: source.widget, Line: idx, Text: line})
+			}
+		}
+	}
+
+	return results
+}
+
+// groupByWidget splits the results into runs of results from the same widget
+func groupByWidget(results []SearchResult) [][]SearchResult {
+	groups := [][]SearchResult{}
+
+	for idx, result := range results {
+		if idx == 0 || results[idx-1].Widget != result.Widget {
+			groups = append(groups, []SearchResult{})
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], result)
+	}
+
+	return groups
+}
+
+func searchHeading(widget Wtfable, count int) string {
+	matches := "matches"
+	if count == 1 {
+		matches = "match"
+	}
+
+	return escapeTags(fmt.Sprintf("%s (%d %s)", widget.Name(), count, matches))
+}
+
+// searchSources takes a copy of each widget's content, so that the line numbers of the results
+// don't change under the search while it's open
+func searchSources(widgets []Wtfable) []searchSource {
+	sources := []searchSource{}
+
+	for _, widget := range widgets {
+		if !widget.Enabled() {
+			continue
+		}
+
+		sources = append(sources, searchSource{
+			widget: widget,
+			lines:  strings.Split(StripColorTags(widget.Content()), "\n"),
+		})
+	}
+
+	return sources
+}
@@ -0,0 +1,57 @@
+package wtf
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/olebedev/config"
+)
+
+type searchWidget struct {
+	TextWidget
+}
+
+func (widget *searchWidget) Refresh(ctx context.Context) error { return nil }
+
+func Test_FindInSources(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    jira:\n      enabled: true\n    github:\n      enabled: true\n    clocks:\n      enabled: false\n")
+
+	jira := searchWidget{NewTextWidget(nil, "Jira", "jira", true)}
+	jira.SetContent("[green]PROJ-1234[white] Fix the login page\nPROJ-99 Update docs\n[red]proj-1234[white] is blocked")
+
+	github := searchWidget{NewTextWidget(nil, "GitHub", "github", true)}
+	github.SetContent("\n#12 Fixes PROJ-1234 (open)")
+
+	clocks := searchWidget{NewTextWidget(nil, "Clocks", "clocks", false)}
+	clocks.SetContent("PROJ-1234")
+
+	sources := searchSources([]Wtfable{&jira, &github, &clocks})
+
+	results := findInSources(sources, " proj-1234 ")
+
+	actual := []string{}
+	for _, result := range results {
+		actual = append(actual, fmt.Sprintf("%s:%d:%s", result.Widget.Name(), result.Line, result.Text))
+	}
+
+	expected := []string{
+		"Jira:0:PROJ-1234 Fix the login page",
+		"Jira:2:proj-1234 is blocked",
+		"GitHub:1:#12 Fixes PROJ-1234 (open)",
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected: %v, got: %v", expected, actual)
+	}
+
+	groups := groupByWidget(results)
+	if len(groups) != 2 || len(groups[0]) != 2 || len(groups[1]) != 1 {
+		t.Errorf("expected: 2 matches from jira and 1 from github, got: %v", groups)
+	}
+
+	if results := findInSources(sources, ""); len(results) != 0 {
+		t.Errorf("expected: no results for an empty query, got: %v", results)
+	}
+}
@@ -0,0 +1,84 @@
+package wtf
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	secretServiceName = "org.freedesktop.secrets"
+	secretServicePath = "/org/freedesktop/secrets"
+)
+
+// secret is a Secret Service secret, as returned over D-Bus
+type secret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// credentialFromSecretService reads the secret of the first item in the freedesktop Secret
+// Service (i.e.: GNOME Keyring or KWallet) that has all the given attributes, written as
+// "attr=value,attr=value". The item has to be unlocked already
+func credentialFromSecretService(ref string) (string, error) {
+	attributes, err := secretAttributes(ref)
+	if err != nil {
+		return "", err
+	}
+
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return "", err
+	}
+
+	service := conn.Object(secretServiceName, secretServicePath)
+
+	var unlocked, locked []dbus.ObjectPath
+	err = service.Call("org.freedesktop.Secret.Service.SearchItems", 0, attributes).Store(&unlocked, &locked)
+	if err != nil {
+		return "", err
+	}
+
+	if len(unlocked) == 0 {
+		if len(locked) > 0 {
+			return "", errors.New("the matching item is locked")
+		}
+
+		return "", errors.New("no item matches " + ref)
+	}
+
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	err = service.Call("org.freedesktop.Secret.Service.OpenSession", 0, "plain", dbus.MakeVariant("")).Store(&output, &session)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Object(secretServiceName, session).Call("org.freedesktop.Secret.Session.Close", 0)
+
+	var item secret
+	err = conn.Object(secretServiceName, unlocked[0]).Call("org.freedesktop.Secret.Item.GetSecret", 0, session).Store(&item)
+	if err != nil {
+		return "", err
+	}
+
+	return string(item.Value), nil
+}
+
+// secretAttributes parses "attr=value,attr=value" into the attributes to search for
+func secretAttributes(ref string) (map[string]string, error) {
+	attributes := map[string]string{}
+
+	for _, pair := range strings.Split(ref, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.New("expected attributes like 'service=jira,user=me'")
+		}
+
+		attributes[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return attributes, nil
+}
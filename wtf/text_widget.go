@@ -13,13 +13,13 @@ type TextWidget struct {
 	enabled   bool
 	focusable bool
 	focusChar string
-	key       string
 	name      string
 
 	RefreshInt int
 	View       *tview.TextView
 
 	Position
+	refreshable
 }
 
 func NewTextWidget(app *tview.Application, name string, configKey string, focusable bool) TextWidget {
@@ -33,11 +33,12 @@ func NewTextWidget(app *tview.Application, name string, configKey string, focusa
 		enabled:    Config.UBool(fmt.Sprintf("wtf.mods.%s.enabled", configKey), false),
 		focusable:  focusable,
 		focusChar:  focusChar,
-		key:        configKey,
 		name:       Config.UString(fmt.Sprintf("wtf.mods.%s.title", configKey), name),
 		RefreshInt: Config.UInt(fmt.Sprintf("wtf.mods.%s.refreshInterval", configKey)),
 	}
 
+	widget.refreshable = newRefreshable(app, configKey)
+
 	widget.Position = NewPosition(
 		Config.UInt(fmt.Sprintf("wtf.mods.%s.position.top", configKey)),
 		Config.UInt(fmt.Sprintf("wtf.mods.%s.position.left", configKey)),
@@ -53,6 +54,10 @@ func NewTextWidget(app *tview.Application, name string, configKey string, focusa
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *TextWidget) BorderColor() string {
+	if widget.refreshState.failing() {
+		return errorColor()
+	}
+
 	if widget.Focusable() {
 		return Config.UString("wtf.colors.border.focusable", "red")
 	}
@@ -60,6 +65,12 @@ func (widget *TextWidget) BorderColor() string {
 	return Config.UString("wtf.colors.border.normal", "gray")
 }
 
+// ConfigKey returns the full config path to the named setting of this widget instance,
+// i.e.: "wtf.mods.<key>.<setting>"
+func (widget *TextWidget) ConfigKey(setting string) string {
+	return ConfigKeyFor(widget.key, setting)
+}
+
 func (widget *TextWidget) ContextualTitle(defaultStr string) string {
 	if widget.FocusChar() == "" {
 		return fmt.Sprintf(" %s ", defaultStr)
@@ -80,6 +91,11 @@ func (widget *TextWidget) Enabled() bool {
 	return widget.enabled
 }
 
+// Flash flashes the widget's border a few times, to draw attention to it
+func (widget *TextWidget) Flash() {
+	flashBorder(widget.app, widget.View, widget.BorderColor)
+}
+
 func (widget *TextWidget) Focusable() bool {
 	return widget.enabled && widget.focusable
 }
@@ -94,18 +110,31 @@ func (widget *TextWidget) IsPositionable() bool {
 	return widget.Position.IsValid()
 }
 
-func (widget *TextWidget) Key() string {
-	return widget.key
-}
-
 func (widget *TextWidget) Name() string {
 	return widget.name
 }
 
+// Notify raises a notification about this widget. See wtf.Notify for how it's delivered
+func (widget *TextWidget) Notify(notification Notification) {
+	Notify(widget.key, widget, notification)
+}
+
+// NotifyChanges raises each of the notifications that the widget's previous refresh didn't
+// also raise. Modules call it on every refresh with everything they'd notify about, i.e.: every
+// open incident, so that the first refresh and what's still the case aren't announced
+func (widget *TextWidget) NotifyChanges(notifications []Notification) {
+	notifyChanges(widget.key, widget, widget.notifications, notifications)
+}
+
 func (widget *TextWidget) RefreshInterval() int {
 	return widget.RefreshInt
 }
 
+// SetRefreshError puts the widget into its error state, or takes it back out when err is nil
+func (widget *TextWidget) SetRefreshError(err error) {
+	widget.setRefreshError(err, widget.BorderColor)
+}
+
 func (widget *TextWidget) SetFocusChar(char string) {
 	widget.focusChar = char
 }
@@ -140,13 +169,17 @@ func (widget *TextWidget) addView(app *tview.Application, configKey string) {
 	))
 
 	view.SetBorder(true)
+	view.SetDrawFunc(widget.refreshState.drawError)
 	view.SetBorderColor(ColorFor(widget.BorderColor()))
 	view.SetChangedFunc(func() {
-		app.Draw()
+		if app != nil {
+			app.Draw()
+		}
 	})
 	view.SetDynamicColors(true)
 	view.SetTitle(widget.ContextualTitle(widget.name))
 	view.SetWrap(false)
 
 	widget.View = view
+	widget.view = view
 }
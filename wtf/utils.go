@@ -20,6 +20,17 @@ const FriendlyDateFormat = "Mon, Jan 2"
 const FriendlyDateTimeFormat = "Mon, Jan 2, 15:04"
 const TimestampFormat = "2006-01-02T15:04:05-0700"
 
+// The tview color, region and escaped-bracket tags, as tview itself matches them
+var (
+	colorTagPattern   = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([lbdru]+|\-)?)?)?\]`)
+	regionTagPattern  = regexp.MustCompile(`\["([a-zA-Z0-9_,;: \-\.]*)"\]`)
+	escapedTagPattern = regexp.MustCompile(`\[([a-zA-Z0-9_,;: \-\."#]+)\[(\[*)\]`)
+
+	// tagPattern matches any of them, trying escaped brackets first so "[red[]" isn't
+	// mistaken for a color
+	tagPattern = regexp.MustCompile(escapedTagPattern.String() + "|" + colorTagPattern.String() + "|" + regionTagPattern.String())
+)
+
 func CenterText(str string, width int) string {
 	if width < 0 {
 		width = 0
@@ -28,6 +39,12 @@ func CenterText(str string, width int) string {
 	return fmt.Sprintf("%[1]*s", -width, fmt.Sprintf("%[1]*s", (width+len(str))/2, str))
 }
 
+// ConfigKeyFor returns the full config path to the named setting of the module instance
+// configured under "wtf.mods.<key>"
+func ConfigKeyFor(key, setting string) string {
+	return fmt.Sprintf("wtf.mods.%s.%s", key, setting)
+}
+
 func DefaultFocussedRowColor() string {
 	foreColor := Config.UString("wtf.colors.highlight.fore", "black")
 	backColor := Config.UString("wtf.colors.highlight.back", "orange")
@@ -169,6 +186,37 @@ func SigilStr(len, pos int, view *tview.TextView) string {
 	return sigils
 }
 
+// PlainLines splits widget content into lines without colors, trailing spaces, or the blank
+// lines widgets use for padding around their content
+func PlainLines(content string) []string {
+	lines := strings.Split(StripColorTags(content), "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, " \t\r")
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// StripColorTags returns the text without any of the tview tags that color it, so that it can
+// be printed outside of a widget
+func StripColorTags(text string) string {
+	return tagPattern.ReplaceAllStringFunc(text, func(tag string) string {
+		if escapedTagPattern.MatchString(tag) {
+			return escapedTagPattern.ReplaceAllString(tag, "[$1$2]")
+		}
+
+		return ""
+	})
+}
+
 /* -------------------- Slice Conversion -------------------- */
 
 func ToInts(slice []interface{}) []int {
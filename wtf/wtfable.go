@@ -6,14 +6,19 @@ import (
 
 type Wtfable interface {
 	Enabler
-	Scheduler
+	Refresher
 
 	BorderColor() string
+	Content() string
 	Focusable() bool
 	FocusChar() string
+	Hidden() bool
 	Key() string
+	Model() *Content
 	Name() string
 	SetFocusChar(string)
+	SetHidden(bool)
+	ShowCached(*CachedContent)
 	TextView() *tview.TextView
 
 	Top() int
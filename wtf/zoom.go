@@ -0,0 +1,60 @@
+package wtf
+
+import (
+	"github.com/rivo/tview"
+)
+
+const zoomPage = "zoom"
+
+// Zoom shows a single widget full screen, as a page of its own, and puts it back on its board
+// afterwards. The widget keeps its focus and its selection throughout
+type Zoom struct {
+	app      *tview.Application
+	pages    *tview.Pages
+	returnTo string
+	widget   Wtfable
+}
+
+// NewZoom creates a zoom that shows widgets on the given pages, and switches back to the
+// returnTo page once a widget is zoomed out
+func NewZoom(app *tview.Application, pages *tview.Pages, returnTo string) *Zoom {
+	return &Zoom{
+		app:      app,
+		pages:    pages,
+		returnTo: returnTo,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Active returns true if a widget is zoomed in
+func (zoom *Zoom) Active() bool {
+	return zoom.widget != nil
+}
+
+// HasFocus returns true if the zoomed widget has focus, rather than, i.e.: its help modal
+func (zoom *Zoom) HasFocus() bool {
+	return zoom.Active() && zoom.app.GetFocus() == zoom.widget.TextView()
+}
+
+// In shows the widget full screen
+func (zoom *Zoom) In(widget Wtfable) {
+	zoom.widget = widget
+
+	zoom.pages.AddAndSwitchToPage(zoomPage, widget.TextView(), true)
+	zoom.app.SetFocus(widget.TextView())
+}
+
+// Out puts the zoomed widget back on its board, still focused
+func (zoom *Zoom) Out() {
+	if !zoom.Active() {
+		return
+	}
+
+	view := zoom.widget.TextView()
+	zoom.widget = nil
+
+	zoom.pages.RemovePage(zoomPage)
+	zoom.pages.SwitchToPage(zoom.returnTo)
+	zoom.app.SetFocus(view)
+}
@@ -0,0 +1,72 @@
+package wtf
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+)
+
+func Test_ZoomInAndOut(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    jira:\n      enabled: true\n    github:\n      enabled: true\n")
+
+	jira := searchWidget{NewTextWidget(nil, "Jira", "jira", true)}
+	github := searchWidget{NewTextWidget(nil, "GitHub", "github", true)}
+
+	grid := tview.NewGrid().SetRows(0).SetColumns(0, 0)
+	grid.AddItem(jira.TextView(), 0, 0, 1, 1, 0, 0, false)
+	grid.AddItem(github.TextView(), 0, 1, 1, 1, 0, 0, false)
+
+	app := tview.NewApplication()
+	pages := tview.NewPages().AddPage("grid", grid, true, true)
+	app.SetFocus(jira.TextView())
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(80, 24)
+
+	// draw lays the pages out on the screen and returns where each widget ended up
+	draw := func() [2][4]int {
+		pages.SetRect(0, 0, 80, 24)
+		pages.Draw(screen)
+
+		var rects [2][4]int
+		for i, widget := range []*searchWidget{&jira, &github} {
+			x, y, width, height := widget.TextView().GetRect()
+			rects[i] = [4]int{x, y, width, height}
+		}
+
+		return rects
+	}
+
+	original := draw()
+
+	zoom := NewZoom(app, pages, "grid")
+	zoom.In(&jira)
+
+	if !zoom.HasFocus() {
+		t.Errorf("zoomed in: expected: the widget to have focus, got: %v", app.GetFocus())
+	}
+	if zoomed := draw(); zoomed[0] != [4]int{0, 0, 80, 24} {
+		t.Errorf("zoomed in: expected: %v, got: %v", [4]int{0, 0, 80, 24}, zoomed[0])
+	}
+
+	zoom.Out()
+
+	if zoom.Active() {
+		t.Errorf("zoomed out: expected: %v, got: %v", false, true)
+	}
+	if pages.HasPage(zoomPage) {
+		t.Errorf("zoomed out: expected: the zoom page to be removed, got: it's still there")
+	}
+	if restored := draw(); restored != original {
+		t.Errorf("zoomed out: expected: %v, got: %v", original, restored)
+	}
+	if app.GetFocus() != jira.TextView() {
+		t.Errorf("zoomed out: expected: the widget to keep focus, got: %v", app.GetFocus())
+	}
+}
@@ -0,0 +1,85 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func testContent() *Content {
+	content := NewContent()
+
+	status := content.AddSection("Status")
+	status.AddRow(Cell{Text: "Build:", Align: tview.AlignRight}, Cell{Text: "passed", Status: StatusOK})
+	status.AddRow(Cell{Text: "Deploy:", Align: tview.AlignRight}, Cell{Text: "[draft]", Status: StatusError})
+
+	links := content.AddSection("")
+	links.AddRow(Text("one")).Selectable = true
+	links.AddRow(Text("two")).Selectable = true
+
+	row := links.AddRow(Text("three"))
+	row.Link = "https://example.com"
+	row.Selectable = true
+
+	return content
+}
+
+/* -------------------- RenderMarkup() -------------------- */
+
+func TestRenderMarkup(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    test:\n      colors:\n        rows:\n          even: white\n          odd: blue\n")
+
+	content := testContent()
+	content.Selected = 1
+
+	expected := " [red]Status[white]\n" +
+		"[white] [white] Build: [green]passed\n" +
+		"[white] [white]Deploy: [red][draft[]\n" +
+		"\n" +
+		`["0"][""][white] [white]one` + "\n" +
+		`["1"][""][black:orange] [black:orange]two` + "\n" +
+		`["2"][""][white] [white]three` + "\n"
+
+	Equal(t, expected, RenderMarkup(content, "test", true))
+}
+
+func TestRenderMarkupUnfocused(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    test:\n      colors:\n        rows:\n          even: white\n          odd: blue\n")
+
+	content := testContent()
+	content.Selected = 1
+
+	Contains(t, RenderMarkup(content, "test", false), `["1"][""][blue] [blue]two`)
+}
+
+func TestRenderMarkupTitleColorAndWideText(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  colors:\n    subheading: red\n")
+
+	content := NewContent()
+	section := content.AddSection("Monday")
+	section.TitleColor = "forestgreen"
+	section.AddRow(Text("🔸"), Text("Standup"))
+	section.AddRow(Text("x"), Text("Lunch"))
+
+	expected := " [forestgreen]Monday[white]\n" +
+		"[white] [white]🔸 [white]Standup\n" +
+		"[white] [white]x  [white]Lunch\n"
+
+	Equal(t, expected, RenderMarkup(content, "test", false))
+}
+
+/* -------------------- SelectedRow() -------------------- */
+
+func TestSelectedRow(t *testing.T) {
+	content := testContent()
+	Nil(t, content.SelectedRow())
+
+	content.Selected = 2
+	Equal(t, "https://example.com", content.SelectedRow().Link)
+
+	content.Selected = 3
+	Nil(t, content.SelectedRow())
+}
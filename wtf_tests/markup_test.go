@@ -0,0 +1,24 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+/* -------------------- ParseMarkup() -------------------- */
+
+func TestParseMarkup(t *testing.T) {
+	Equal(t, []Span{}, ParseMarkup(""))
+	Equal(t, []Span{{Text: "cat"}}, ParseMarkup("cat"))
+
+	Equal(t,
+		[]Span{
+			{Text: "cat ", Fore: "red"},
+			{Text: "dog", Fore: "red", Back: "black", Attrs: "b"},
+			{Text: " [rat]"},
+		},
+		ParseMarkup(`[red]cat ["0"][:black:b]dog[-:-:-] [rat[]`),
+	)
+}
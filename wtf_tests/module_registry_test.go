@@ -0,0 +1,46 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func testFactory(app *tview.Application, pages *tview.Pages, configKey string) Wtfable {
+	return nil
+}
+
+/* -------------------- RegisterModule() -------------------- */
+
+func TestRegisterModule(t *testing.T) {
+	RegisterModule(Module{Name: "registrytest", Factory: testFactory, HelpText: "help"})
+
+	module, ok := ModuleFor("registrytest")
+	Equal(t, true, ok)
+	Equal(t, "help", module.HelpText)
+	Contains(t, ModuleNames(), "registrytest")
+
+	Panics(t, func() { RegisterModule(Module{Name: "registrytest", Factory: testFactory}) })
+	Panics(t, func() { RegisterModule(Module{Name: "nofactory"}) })
+	Panics(t, func() { RegisterModule(Module{Factory: testFactory}) })
+}
+
+/* -------------------- ModuleFor() -------------------- */
+
+func TestModuleForUnknown(t *testing.T) {
+	_, ok := ModuleFor("doesnotexist")
+	Equal(t, false, ok)
+}
+
+/* -------------------- Schema() -------------------- */
+
+func TestModuleSchema(t *testing.T) {
+	module := Module{Name: "schematest", Settings: ConfigSchema{"apiKey": StringSetting}}
+	schema := module.Schema()
+
+	Equal(t, StringSetting, schema["apiKey"])
+	Equal(t, IntSetting, schema["position.top"])
+	Equal(t, BoolSetting, schema["enabled"])
+}
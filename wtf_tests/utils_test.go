@@ -15,6 +15,13 @@ func TestCenterText(t *testing.T) {
 	Equal(t, "   cat   ", CenterText("cat", 9))
 }
 
+/* -------------------- ConfigKeyFor() -------------------- */
+
+func TestConfigKeyFor(t *testing.T) {
+	Equal(t, "wtf.mods.jira.apiKey", ConfigKeyFor("jira", "apiKey"))
+	Equal(t, "wtf.mods.prod_errors.colors.rows.even", ConfigKeyFor("prod_errors", "colors.rows.even"))
+}
+
 /* -------------------- FindMatch() -------------------- */
 
 func TestFindMatch(t *testing.T) {
@@ -84,3 +91,12 @@ func TestToStrs(t *testing.T) {
 
 	Equal(t, expected, ToStrs(source))
 }
+
+/* -------------------- StripColorTags() -------------------- */
+
+func TestStripColorTags(t *testing.T) {
+	Equal(t, "cat", StripColorTags("[red]cat[white]"))
+	Equal(t, "cat dog", StripColorTags("[green::b]cat[-:-:-] [#ff0000:black]dog"))
+	Equal(t, "cat", StripColorTags(`["0"]cat[""]`))
+	Equal(t, "[cat]", StripColorTags("[cat[]"))
+}